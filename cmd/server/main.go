@@ -4,9 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/FairleyC/space-sim-service/internal/cache"
 	"github.com/FairleyC/space-sim-service/internal/database"
+	"github.com/FairleyC/space-sim-service/internal/jobs"
+	"github.com/FairleyC/space-sim-service/internal/ratelimit"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
 	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
 	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/stream"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
+	grpctransport "github.com/FairleyC/space-sim-service/internal/transport/grpc"
 	transport "github.com/FairleyC/space-sim-service/internal/transport/http"
 )
 
@@ -27,9 +39,99 @@ func Run() error {
 		return err
 	}
 
-	commodityService := commodity.NewService(db)
-	solarSystemService := solarSystem.NewService(db)
-	httpHandler := transport.NewHandler(commodityService, solarSystemService)
+	authConfig, err := auth.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("auth.ConfigFromEnv() error: ", err)
+		return err
+	}
+
+	rateLimitConfig, err := ratelimit.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("ratelimit.ConfigFromEnv() error: ", err)
+		return err
+	}
+
+	cacheConfig, err := cache.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("cache.ConfigFromEnv() error: ", err)
+		return err
+	}
+
+	purger, err := jobs.NewPurgerFromEnv(db)
+	if err != nil {
+		fmt.Println("jobs.NewPurgerFromEnv() error: ", err)
+		return err
+	}
+
+	webhookDispatcher, err := jobs.NewWebhookDispatcherFromEnv(db)
+	if err != nil {
+		fmt.Println("jobs.NewWebhookDispatcherFromEnv() error: ", err)
+		return err
+	}
+
+	// background jobs stop once the server has shut down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go purger.Start(jobsCtx)
+	go webhookDispatcher.Start(jobsCtx)
+
+	streamService := stream.NewService(db)
+	go streamService.Start(jobsCtx)
+
+	authService := auth.NewService(db, authConfig)
+	auditService := audit.NewService(db)
+	eventService := events.NewService(db, transport.NewEventDataV1)
+	webhookService := webhook.NewService(db)
+	// reads of commodities and solar systems are cached in front of the
+	// database, and every store writing them invalidates the cache
+	var commodityStore commodity.Store = db
+	var solarSystemStore solarSystem.Store = db
+	var universeStore universe.Store = db
+	var productionStore production.Store = db
+	var shipStore ship.Store = db
+	if cacheConfig.Enabled() {
+		storeCache := cache.NewCache(cache.NewMemoryBackend(cacheConfig.Capacity), cacheConfig)
+		commodityStore = cache.NewCommodityStore(db, storeCache)
+		solarSystemStore = cache.NewSolarSystemStore(db, storeCache)
+		universeStore = cache.NewUniverseStore(db, storeCache)
+		productionStore = cache.NewProductionStore(db, storeCache)
+		shipStore = cache.NewShipStore(db, storeCache)
+	}
+
+	commodityService := commodity.NewService(commodityStore, auditService, eventService)
+	solarSystemService := solarSystem.NewService(solarSystemStore, auditService, eventService)
+	universeService := universe.NewService(universeStore, auditService, eventService)
+	productionService := production.NewService(productionStore, auditService, eventService)
+
+	productionScheduler, err := jobs.NewProductionSchedulerFromEnv(productionService)
+	if err != nil {
+		fmt.Println("jobs.NewProductionSchedulerFromEnv() error: ", err)
+		return err
+	}
+	go productionScheduler.Start(jobsCtx)
+
+	shipService := ship.NewService(shipStore, auditService, eventService)
+
+	arrivalScheduler, err := jobs.NewArrivalSchedulerFromEnv(shipService)
+	if err != nil {
+		fmt.Println("jobs.NewArrivalSchedulerFromEnv() error: ", err)
+		return err
+	}
+	go arrivalScheduler.Start(jobsCtx)
+
+	rateLimiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), rateLimitConfig)
+	httpHandler := transport.NewHandler(commodityService, solarSystemService, authService, auditService, webhookService, streamService, universeService, productionService, shipService, rateLimiter, cacheConfig.TTL)
+
+	grpcServer := grpctransport.NewServer(commodityService, solarSystemService, authService, streamService, rateLimiter)
+	go func() {
+		if err := grpcServer.Serve(); err != nil {
+			fmt.Println("grpcServer.Serve() error: ", err)
+		}
+	}()
+	// the REST handler's shutdown closes open streams, so
+	// stopping gRPC after it doesn't wait on them
+	defer grpcServer.Stop()
+
 	if err := httpHandler.Serve(); err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/database"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+	transport "github.com/FairleyC/space-sim-service/internal/transport/http"
+)
+
+// distributionFlag - reads a distribution written as
+// "kind:min:max[:mean:stdDev]", e.g. "logNormal:1:10000:100:0.8".
+type distributionFlag struct {
+	distribution *universe.Distribution
+}
+
+func (d distributionFlag) String() string {
+	if d.distribution == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%g:%g:%g:%g", d.distribution.Kind, d.distribution.Min, d.distribution.Max, d.distribution.Mean, d.distribution.StdDev)
+}
+
+func (d distributionFlag) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 && len(parts) != 5 {
+		return fmt.Errorf("expected kind:min:max[:mean:stdDev], got %q", value)
+	}
+
+	numbers := make([]float64, 4)
+	for i, part := range parts[1:] {
+		number, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", part)
+		}
+		numbers[i] = number
+	}
+
+	*d.distribution = universe.Distribution{
+		Kind:   universe.DistributionKind(parts[0]),
+		Min:    numbers[0],
+		Max:    numbers[1],
+		Mean:   numbers[2],
+		StdDev: numbers[3],
+	}
+	return nil
+}
+
+// Run - generates a universe from the flags and imports
+// it into the database the server's environment points to.
+func Run() error {
+	config := universe.DefaultGenerateConfig()
+	flag.Int64Var(&config.Seed, "seed", time.Now().UnixNano(), "seed to generate from, random by default")
+	flag.IntVar(&config.SolarSystems, "solar-systems", config.SolarSystems, "number of solar systems")
+	flag.IntVar(&config.Commodities, "commodities", config.Commodities, "number of commodities in the catalog")
+	flag.IntVar(&config.MinMarketsPerSystem, "min-markets", config.MinMarketsPerSystem, "fewest markets per solar system")
+	flag.IntVar(&config.MaxMarketsPerSystem, "max-markets", config.MaxMarketsPerSystem, "most markets per solar system")
+	flag.Var(distributionFlag{&config.BasePrice}, "base-price", "distribution of base prices, kind:min:max[:mean:stdDev]")
+	flag.Var(distributionFlag{&config.DemandQuantity}, "demand", "distribution of demand quantities, kind:min:max[:mean:stdDev]")
+	flag.Float64Var(&config.GalaxyRadius, "galaxy-radius", config.GalaxyRadius, "radius of the galaxy's disc in light years")
+	flag.Float64Var(&config.GalaxyThickness, "galaxy-thickness", config.GalaxyThickness, "thickness of the galaxy's disc in light years")
+	dryRun := flag.Bool("dry-run", false, "report what would change without changing it")
+	flag.Parse()
+
+	db, err := database.NewDatabase(context.Background())
+	if err != nil {
+		fmt.Println("database.NewDatabase() error: ", err)
+		return err
+	}
+
+	if err := db.Migrate(); err != nil {
+		fmt.Println("database.Migrate() error: ", err)
+		return err
+	}
+
+	universeService := universe.NewService(db, audit.NewService(db), events.NewService(db, transport.NewEventDataV1))
+
+	ctx := auth.WithPrincipal(context.Background(), auth.SystemPrincipal)
+	report, err := universeService.Generate(ctx, config, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seed %d, dry run %t\n", config.Seed, report.DryRun)
+	for _, counts := range []struct {
+		name   string
+		counts universe.Counts
+	}{
+		{name: "commodities", counts: report.Commodities},
+		{name: "solar systems", counts: report.SolarSystems},
+		{name: "markets", counts: report.Markets},
+	} {
+		fmt.Printf("%s: %d created, %d updated, %d unchanged\n", counts.name, counts.counts.Created, counts.counts.Updated, counts.counts.Unchanged)
+	}
+
+	return nil
+}
+
+func main() {
+	if err := Run(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
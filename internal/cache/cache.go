@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultTTL      = 30 * time.Second
+	DefaultCapacity = 10000
+)
+
+var ErrInvalidConfig = errors.New("invalid cache config")
+
+// stats - the hits and misses of every namespace, published as
+// "cache" with the rest of the process's expvar metrics.
+var stats = expvar.NewMap("cache")
+
+// Backend - holds the cached values. The in-process MemoryBackend
+// is used by default; a shared backend can be plugged in by
+// implementing this interface so instances see each other's
+// invalidations.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+type Config struct {
+	TTL      time.Duration
+	Capacity int
+}
+
+func DefaultConfig() Config {
+	return Config{TTL: DefaultTTL, Capacity: DefaultCapacity}
+}
+
+// ConfigFromEnv - reads how long values are cached and how many the
+// in-process backend holds from CACHE_TTL and CACHE_CAPACITY, e.g.
+// "30s" and "10000". A CACHE_TTL of "0s" turns caching off.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return Config{}, fmt.Errorf("%w: CACHE_TTL %q", ErrInvalidConfig, value)
+		}
+		config.TTL = ttl
+	}
+
+	if value := os.Getenv("CACHE_CAPACITY"); value != "" {
+		capacity, err := strconv.Atoi(value)
+		if err != nil || capacity <= 0 {
+			return Config{}, fmt.Errorf("%w: CACHE_CAPACITY %q", ErrInvalidConfig, value)
+		}
+		config.Capacity = capacity
+	}
+
+	return config, nil
+}
+
+// Enabled - whether values are cached at all.
+func (c Config) Enabled() bool {
+	return c.TTL > 0
+}
+
+// Cache - caches the results of reads by namespace, such as
+// "commodity", and drops a whole namespace when it is written to.
+// Values are stored encoded, so a caller can't change what the
+// next caller is handed.
+type Cache struct {
+	Backend Backend
+	TTL     time.Duration
+	// generation - counts invalidations, so a read that raced a
+	// write doesn't cache what it read from before the write.
+	generation atomic.Uint64
+}
+
+func NewCache(backend Backend, config Config) *Cache {
+	return &Cache{Backend: backend, TTL: config.TTL}
+}
+
+// Fetch - returns the cached value of the key, or loads and caches
+// it. Reads inside a transaction always load, as they may see writes
+// that are not committed yet. Errors are never cached.
+func Fetch[T any](ctx context.Context, c *Cache, namespace string, key string, load func() (T, error)) (T, error) {
+	if _, ok := ctx.Value(txKey{}).(*pendingInvalidations); ok {
+		return load()
+	}
+
+	key = namespace + ":" + key
+	cached, found, err := c.Backend.Get(ctx, key)
+	if err != nil {
+		// a failing cache backend should not take the API down with it
+		log.Println("Error reading cache", err)
+	}
+	if found {
+		var value T
+		if err := json.Unmarshal(cached, &value); err == nil {
+			stats.Add(namespace+".hits", 1)
+			return value, nil
+		}
+	}
+	stats.Add(namespace+".misses", 1)
+
+	generation := c.generation.Load()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		log.Println("Error encoding cache value", err)
+		return value, nil
+	}
+
+	if c.generation.Load() == generation {
+		if err := c.Backend.Set(ctx, key, encoded, c.TTL); err != nil {
+			log.Println("Error writing cache", err)
+		}
+	}
+
+	return value, nil
+}
+
+// Invalidate - drops every value cached under the namespaces. Inside
+// a transaction this waits until the transaction has ended, so no
+// reader can cache the values from before it commits in between.
+func (c *Cache) Invalidate(ctx context.Context, namespaces ...string) {
+	if pending, ok := ctx.Value(txKey{}).(*pendingInvalidations); ok {
+		pending.add(namespaces)
+		return
+	}
+
+	c.invalidate(ctx, namespaces)
+}
+
+func (c *Cache) invalidate(ctx context.Context, namespaces []string) {
+	c.generation.Add(1)
+	for _, namespace := range namespaces {
+		if err := c.Backend.DeletePrefix(ctx, namespace+":"); err != nil {
+			log.Println("Error invalidating cache", err)
+		}
+	}
+}
+
+type txKey struct{}
+
+type pendingInvalidations struct {
+	mu         sync.Mutex
+	namespaces []string
+}
+
+func (p *pendingInvalidations) add(namespaces []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.namespaces = append(p.namespaces, namespaces...)
+}
+
+// WithTx - runs fn through a store's WithTx, holding back the
+// invalidations of its writes until the transaction has ended.
+// They are applied after a rollback too, which costs a few misses
+// but never serves a stale value.
+func (c *Cache) WithTx(ctx context.Context, withTx func(context.Context, func(context.Context) error) error, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*pendingInvalidations); ok {
+		return withTx(ctx, fn)
+	}
+
+	pending := &pendingInvalidations{}
+	err := withTx(context.WithValue(ctx, txKey{}, pending), fn)
+	c.invalidate(ctx, pending.namespaces)
+
+	return err
+}
+
+// key - encodes the arguments of a read into the rest of its key.
+func key(method string, args ...any) string {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		// every argument is a plain value, so this is a programming error
+		panic(fmt.Sprintf("invalid cache key arguments: %v", err))
+	}
+	return method + ":" + string(encoded)
+}
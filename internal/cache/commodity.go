@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+)
+
+const NamespaceCommodity = "commodity"
+
+// CommodityStore - caches the reads of live commodities made through
+// a commodity.Store. The markets of a solar system carry the details
+// of their commodity, so every write invalidates solar systems too.
+type CommodityStore struct {
+	commodity.Store
+	Cache *Cache
+}
+
+func NewCommodityStore(store commodity.Store, cache *Cache) *CommodityStore {
+	return &CommodityStore{Store: store, Cache: cache}
+}
+
+func (s *CommodityStore) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return s.Cache.WithTx(ctx, s.Store.WithTx, fn)
+}
+
+func (s *CommodityStore) GetCommodityById(ctx context.Context, id string, includeDeleted bool) (commodity.Commodity, error) {
+	if includeDeleted {
+		return s.Store.GetCommodityById(ctx, id, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceCommodity, key("byId", id), func() (commodity.Commodity, error) {
+		return s.Store.GetCommodityById(ctx, id, includeDeleted)
+	})
+}
+
+func (s *CommodityStore) GetCommoditiesByPagination(ctx context.Context, pagination data.Pagination, filter commodity.Filter) ([]commodity.Commodity, error) {
+	if filter.IncludeDeleted {
+		return s.Store.GetCommoditiesByPagination(ctx, pagination, filter)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceCommodity, key("byPagination", pagination, filter), func() ([]commodity.Commodity, error) {
+		return s.Store.GetCommoditiesByPagination(ctx, pagination, filter)
+	})
+}
+
+func (s *CommodityStore) GetCommoditiesByIds(ctx context.Context, ids []string, includeDeleted bool) ([]commodity.Commodity, error) {
+	if includeDeleted {
+		return s.Store.GetCommoditiesByIds(ctx, ids, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceCommodity, key("byIds", ids), func() ([]commodity.Commodity, error) {
+		return s.Store.GetCommoditiesByIds(ctx, ids, includeDeleted)
+	})
+}
+
+func (s *CommodityStore) CreateCommodity(ctx context.Context, c commodity.Commodity) (commodity.Commodity, error) {
+	created, err := s.Store.CreateCommodity(ctx, c)
+	s.Cache.Invalidate(ctx, NamespaceCommodity, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *CommodityStore) RemoveCommodity(ctx context.Context, id string) error {
+	err := s.Store.RemoveCommodity(ctx, id)
+	s.Cache.Invalidate(ctx, NamespaceCommodity, NamespaceSolarSystem)
+	return err
+}
+
+func (s *CommodityStore) RestoreCommodity(ctx context.Context, id string) (commodity.Commodity, []commodity.DependentMarket, error) {
+	restored, markets, err := s.Store.RestoreCommodity(ctx, id)
+	s.Cache.Invalidate(ctx, NamespaceCommodity, NamespaceSolarSystem)
+	return restored, markets, err
+}
+
+func (s *CommodityStore) RemoveAllCommodityMarketsByCommodityId(ctx context.Context, id string) error {
+	err := s.Store.RemoveAllCommodityMarketsByCommodityId(ctx, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
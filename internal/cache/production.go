@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+)
+
+// ProductionStore - nothing it reads is cached, but the stock each
+// production cycle writes is part of the markets the solar system
+// store caches, so it invalidates them.
+type ProductionStore struct {
+	production.Store
+	Cache *Cache
+}
+
+func NewProductionStore(store production.Store, cache *Cache) *ProductionStore {
+	return &ProductionStore{Store: store, Cache: cache}
+}
+
+func (s *ProductionStore) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return s.Cache.WithTx(ctx, s.Store.WithTx, fn)
+}
+
+func (s *ProductionStore) SetFacilityStock(ctx context.Context, facility production.Facility, stock map[string]int) error {
+	err := s.Store.SetFacilityStock(ctx, facility, stock)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
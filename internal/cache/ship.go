@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
+)
+
+// ShipStore - nothing it reads is cached, but the stock each trade
+// writes is part of the markets the solar system store caches, so it
+// invalidates them.
+type ShipStore struct {
+	ship.Store
+	Cache *Cache
+}
+
+func NewShipStore(store ship.Store, cache *Cache) *ShipStore {
+	return &ShipStore{Store: store, Cache: cache}
+}
+
+func (s *ShipStore) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return s.Cache.WithTx(ctx, s.Store.WithTx, fn)
+}
+
+func (s *ShipStore) AdjustMarketStock(ctx context.Context, commodityMarketId string, delta int) error {
+	err := s.Store.AdjustMarketStock(ctx, commodityMarketId, delta)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
@@ -0,0 +1,272 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+const NamespaceSolarSystem = "solarSystem"
+
+// SolarSystemStore - caches the reads of live solar systems and their
+// markets made through a solarSystem.Store, above all GetSolarSystemById,
+// which takes a query for the system and another for its markets.
+type SolarSystemStore struct {
+	solarSystem.Store
+	Cache *Cache
+}
+
+func NewSolarSystemStore(store solarSystem.Store, cache *Cache) *SolarSystemStore {
+	return &SolarSystemStore{Store: store, Cache: cache}
+}
+
+func (s *SolarSystemStore) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return s.Cache.WithTx(ctx, s.Store.WithTx, fn)
+}
+
+func (s *SolarSystemStore) GetSolarSystemById(ctx context.Context, id string, includeDeleted bool) (solarSystem.SolarSystemWithCommodityMarkets, error) {
+	if includeDeleted {
+		return s.Store.GetSolarSystemById(ctx, id, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("byId", id), func() (solarSystem.SolarSystemWithCommodityMarkets, error) {
+		return s.Store.GetSolarSystemById(ctx, id, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetSolarSystemsByPagination(ctx context.Context, pagination data.Pagination, filter solarSystem.Filter) ([]solarSystem.SolarSystem, error) {
+	if filter.IncludeDeleted {
+		return s.Store.GetSolarSystemsByPagination(ctx, pagination, filter)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("byPagination", pagination, filter), func() ([]solarSystem.SolarSystem, error) {
+		return s.Store.GetSolarSystemsByPagination(ctx, pagination, filter)
+	})
+}
+
+func (s *SolarSystemStore) GetSolarSystemsByIds(ctx context.Context, ids []string, includeDeleted bool) ([]solarSystem.SolarSystem, error) {
+	if includeDeleted {
+		return s.Store.GetSolarSystemsByIds(ctx, ids, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("byIds", ids), func() ([]solarSystem.SolarSystem, error) {
+		return s.Store.GetSolarSystemsByIds(ctx, ids, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetSolarSystemsNearby(ctx context.Context, centre solarSystem.Coordinates, radius float64, pagination data.Pagination) ([]solarSystem.NearbySolarSystem, error) {
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("nearby", centre, radius, pagination), func() ([]solarSystem.NearbySolarSystem, error) {
+		return s.Store.GetSolarSystemsNearby(ctx, centre, radius, pagination)
+	})
+}
+
+func (s *SolarSystemStore) GetSolarSystemNeighbors(ctx context.Context, id string, k int) ([]solarSystem.NearbySolarSystem, error) {
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("neighbors", id, k), func() ([]solarSystem.NearbySolarSystem, error) {
+		return s.Store.GetSolarSystemNeighbors(ctx, id, k)
+	})
+}
+
+func (s *SolarSystemStore) GetCommodityMarketsBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	if includeDeleted {
+		return s.Store.GetCommodityMarketsBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("marketsBySolarSystemId", solarSystemId), func() ([]solarSystem.CommodityMarket, error) {
+		return s.Store.GetCommodityMarketsBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetCommodityMarketsBySolarSystemIds(ctx context.Context, solarSystemIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	if includeDeleted {
+		return s.Store.GetCommodityMarketsBySolarSystemIds(ctx, solarSystemIds, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("marketsBySolarSystemIds", solarSystemIds), func() ([]solarSystem.CommodityMarket, error) {
+		return s.Store.GetCommodityMarketsBySolarSystemIds(ctx, solarSystemIds, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetCommodityMarketsByCommodityIds(ctx context.Context, commodityIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	if includeDeleted {
+		return s.Store.GetCommodityMarketsByCommodityIds(ctx, commodityIds, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("marketsByCommodityIds", commodityIds), func() ([]solarSystem.CommodityMarket, error) {
+		return s.Store.GetCommodityMarketsByCommodityIds(ctx, commodityIds, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetCommodityMarketsByPagination(ctx context.Context, pagination data.Pagination, filter solarSystem.MarketFilter) ([]solarSystem.CommodityMarket, error) {
+	if filter.IncludeDeleted {
+		return s.Store.GetCommodityMarketsByPagination(ctx, pagination, filter)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("marketsByPagination", pagination, filter), func() ([]solarSystem.CommodityMarket, error) {
+		return s.Store.GetCommodityMarketsByPagination(ctx, pagination, filter)
+	})
+}
+
+func (s *SolarSystemStore) GetCommodityMarketById(ctx context.Context, id string, includeDeleted bool) (solarSystem.CommodityMarket, error) {
+	if includeDeleted {
+		return s.Store.GetCommodityMarketById(ctx, id, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("marketById", id), func() (solarSystem.CommodityMarket, error) {
+		return s.Store.GetCommodityMarketById(ctx, id, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetCelestialBodiesBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.CelestialBody, error) {
+	if includeDeleted {
+		return s.Store.GetCelestialBodiesBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("bodiesBySolarSystemId", solarSystemId), func() ([]solarSystem.CelestialBody, error) {
+		return s.Store.GetCelestialBodiesBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetCelestialBodyById(ctx context.Context, id string, includeDeleted bool) (solarSystem.CelestialBody, error) {
+	if includeDeleted {
+		return s.Store.GetCelestialBodyById(ctx, id, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("bodyById", id), func() (solarSystem.CelestialBody, error) {
+		return s.Store.GetCelestialBodyById(ctx, id, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetStationsBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.Station, error) {
+	if includeDeleted {
+		return s.Store.GetStationsBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("stationsBySolarSystemId", solarSystemId), func() ([]solarSystem.Station, error) {
+		return s.Store.GetStationsBySolarSystemId(ctx, solarSystemId, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) GetStationById(ctx context.Context, id string, includeDeleted bool) (solarSystem.StationWithCommodityMarkets, error) {
+	if includeDeleted {
+		return s.Store.GetStationById(ctx, id, includeDeleted)
+	}
+
+	return Fetch(ctx, s.Cache, NamespaceSolarSystem, key("stationById", id), func() (solarSystem.StationWithCommodityMarkets, error) {
+		return s.Store.GetStationById(ctx, id, includeDeleted)
+	})
+}
+
+func (s *SolarSystemStore) CreateSolarSystem(ctx context.Context, system solarSystem.SolarSystem) (solarSystem.SolarSystem, error) {
+	created, err := s.Store.CreateSolarSystem(ctx, system)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *SolarSystemStore) RemoveSolarSystem(ctx context.Context, id string) error {
+	err := s.Store.RemoveSolarSystem(ctx, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) RestoreSolarSystem(ctx context.Context, id string) (solarSystem.SolarSystemWithCommodityMarkets, error) {
+	restored, err := s.Store.RestoreSolarSystem(ctx, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return restored, err
+}
+
+func (s *SolarSystemStore) CreateCommodityMarket(ctx context.Context, solarSystemId string, stationId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error) {
+	created, err := s.Store.CreateCommodityMarket(ctx, solarSystemId, stationId, basePrice, demandQuantity, commodityId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *SolarSystemStore) RemoveCommodityMarket(ctx context.Context, solarSystemId string, id string) error {
+	err := s.Store.RemoveCommodityMarket(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) RestoreCommodityMarket(ctx context.Context, solarSystemId string, id string) (solarSystem.CommodityMarket, error) {
+	restored, err := s.Store.RestoreCommodityMarket(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return restored, err
+}
+
+func (s *SolarSystemStore) UpdateCommodityMarket(ctx context.Context, solarSystemId string, id string, update solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error) {
+	updated, err := s.Store.UpdateCommodityMarket(ctx, solarSystemId, id, update)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return updated, err
+}
+
+func (s *SolarSystemStore) UpsertCommodityMarket(ctx context.Context, solarSystemId string, commodityId string, basePrice float64, demandQuantity int) (solarSystem.CommodityMarket, bool, error) {
+	upserted, created, err := s.Store.UpsertCommodityMarket(ctx, solarSystemId, commodityId, basePrice, demandQuantity)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return upserted, created, err
+}
+
+func (s *SolarSystemStore) RemoveAllCommodityMarketsBySolarSystemId(ctx context.Context, solarSystemId string) error {
+	err := s.Store.RemoveAllCommodityMarketsBySolarSystemId(ctx, solarSystemId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) RemoveAllCommodityMarketsByStationId(ctx context.Context, stationId string) error {
+	err := s.Store.RemoveAllCommodityMarketsByStationId(ctx, stationId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) CreateCelestialBody(ctx context.Context, body solarSystem.CelestialBody) (solarSystem.CelestialBody, error) {
+	created, err := s.Store.CreateCelestialBody(ctx, body)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *SolarSystemStore) RemoveCelestialBody(ctx context.Context, solarSystemId string, id string) error {
+	err := s.Store.RemoveCelestialBody(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) RestoreCelestialBody(ctx context.Context, solarSystemId string, id string) (solarSystem.CelestialBody, error) {
+	restored, err := s.Store.RestoreCelestialBody(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return restored, err
+}
+
+func (s *SolarSystemStore) RemoveAllCelestialBodiesBySolarSystemId(ctx context.Context, solarSystemId string) error {
+	err := s.Store.RemoveAllCelestialBodiesBySolarSystemId(ctx, solarSystemId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) CreateStation(ctx context.Context, station solarSystem.Station) (solarSystem.Station, error) {
+	created, err := s.Store.CreateStation(ctx, station)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *SolarSystemStore) UpdateStation(ctx context.Context, solarSystemId string, id string, update solarSystem.StationUpdate) (solarSystem.Station, error) {
+	updated, err := s.Store.UpdateStation(ctx, solarSystemId, id, update)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return updated, err
+}
+
+func (s *SolarSystemStore) RemoveStation(ctx context.Context, solarSystemId string, id string) error {
+	err := s.Store.RemoveStation(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
+
+func (s *SolarSystemStore) RestoreStation(ctx context.Context, solarSystemId string, id string) (solarSystem.StationWithCommodityMarkets, error) {
+	restored, err := s.Store.RestoreStation(ctx, solarSystemId, id)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return restored, err
+}
+
+func (s *SolarSystemStore) RemoveAllStationsBySolarSystemId(ctx context.Context, solarSystemId string) error {
+	err := s.Store.RemoveAllStationsBySolarSystemId(ctx, solarSystemId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return err
+}
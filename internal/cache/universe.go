@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+)
+
+// UniverseStore - writes the same rows as the commodity and solar
+// system stores, so it invalidates what they have cached. Its own
+// reads are made inside an import's transaction and never cached.
+type UniverseStore struct {
+	universe.Store
+	Cache *Cache
+}
+
+func NewUniverseStore(store universe.Store, cache *Cache) *UniverseStore {
+	return &UniverseStore{Store: store, Cache: cache}
+}
+
+func (s *UniverseStore) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return s.Cache.WithTx(ctx, s.Store.WithTx, fn)
+}
+
+func (s *UniverseStore) CreateCommodity(ctx context.Context, c commodity.Commodity) (commodity.Commodity, error) {
+	created, err := s.Store.CreateCommodity(ctx, c)
+	s.Cache.Invalidate(ctx, NamespaceCommodity, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *UniverseStore) UpdateCommodity(ctx context.Context, c commodity.Commodity) (commodity.Commodity, error) {
+	updated, err := s.Store.UpdateCommodity(ctx, c)
+	s.Cache.Invalidate(ctx, NamespaceCommodity, NamespaceSolarSystem)
+	return updated, err
+}
+
+func (s *UniverseStore) CreateSolarSystem(ctx context.Context, system solarSystem.SolarSystem) (solarSystem.SolarSystem, error) {
+	created, err := s.Store.CreateSolarSystem(ctx, system)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *UniverseStore) UpdateSolarSystem(ctx context.Context, system solarSystem.SolarSystem) (solarSystem.SolarSystem, error) {
+	updated, err := s.Store.UpdateSolarSystem(ctx, system)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return updated, err
+}
+
+func (s *UniverseStore) CreateCommodityMarket(ctx context.Context, solarSystemId string, stationId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error) {
+	created, err := s.Store.CreateCommodityMarket(ctx, solarSystemId, stationId, basePrice, demandQuantity, commodityId)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return created, err
+}
+
+func (s *UniverseStore) UpdateCommodityMarket(ctx context.Context, solarSystemId string, id string, update solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error) {
+	updated, err := s.Store.UpdateCommodityMarket(ctx, solarSystemId, id, update)
+	s.Cache.Invalidate(ctx, NamespaceSolarSystem)
+	return updated, err
+}
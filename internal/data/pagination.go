@@ -51,6 +51,28 @@ func GetPagination(r *http.Request) Pagination {
 	}
 }
 
+// NewPagination - applies the defaults and limits of GetPagination
+// to values that did not come from a query string.
+func NewPagination(page int, perPage int, orderBy string) Pagination {
+	if page < 1 {
+		page = DefaultPage
+	}
+
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	return Pagination{
+		Page:    page,
+		PerPage: perPage,
+		OrderBy: orderBy,
+	}
+}
+
 func (p *Pagination) GetOffset() int {
 	return (p.Page - 1) * p.PerPage
 }
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ApiKeyRow struct {
+	ID        string
+	Name      sql.NullString
+	Prefix    sql.NullString
+	Role      sql.NullString
+	CreatedAt time.Time
+}
+
+func convertApiKeyRowToApiKey(row ApiKeyRow) auth.ApiKey {
+	return auth.ApiKey{
+		ID:        row.ID,
+		Name:      row.Name.String,
+		Prefix:    row.Prefix.String,
+		Role:      auth.Role(row.Role.String),
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func (d *Database) GetApiKeyByHash(ctx context.Context, hash string) (auth.ApiKey, error) {
+	var apiKeyRow ApiKeyRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, name, prefix, role, created_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`, hash)
+
+	err := row.Scan(&apiKeyRow.ID, &apiKeyRow.Name, &apiKeyRow.Prefix, &apiKeyRow.Role, &apiKeyRow.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return auth.ApiKey{}, auth.ErrApiKeyNotFound
+		}
+		return auth.ApiKey{}, fmt.Errorf("error scanning api key: %w", err)
+	}
+
+	return convertApiKeyRowToApiKey(apiKeyRow), nil
+}
+
+func (d *Database) GetApiKeysByPagination(ctx context.Context, pagination data.Pagination) ([]auth.ApiKey, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "name",
+			FormattedFieldName: "name",
+		},
+		{
+			FieldName:          "role",
+			FormattedFieldName: "role",
+		},
+		{
+			FieldName:          "createdat",
+			FormattedFieldName: "created_at",
+		},
+	}, "created_at")
+	direction := pagination.GetOrderByDirection()
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, prefix, role, created_at
+		FROM api_keys
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting api keys by pagination: %w", err)
+	}
+
+	defer rows.Close()
+
+	apiKeys := []auth.ApiKey{}
+	for rows.Next() {
+		var apiKeyRow ApiKeyRow
+		err := rows.Scan(&apiKeyRow.ID, &apiKeyRow.Name, &apiKeyRow.Prefix, &apiKeyRow.Role, &apiKeyRow.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning api key row: %w", err)
+		}
+
+		apiKeys = append(apiKeys, convertApiKeyRowToApiKey(apiKeyRow))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (d *Database) CreateApiKey(ctx context.Context, newApiKey auth.ApiKey, hash string) (auth.ApiKey, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return auth.ApiKey{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	var apiKeyRow ApiKeyRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		INSERT INTO api_keys (id, name, prefix, role, key_hash)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, name, prefix, role, created_at
+	`, newUuid.String(), newApiKey.Name, newApiKey.Prefix, string(newApiKey.Role), hash)
+
+	err = row.Scan(&apiKeyRow.ID, &apiKeyRow.Name, &apiKeyRow.Prefix, &apiKeyRow.Role, &apiKeyRow.CreatedAt)
+	if err != nil {
+		return auth.ApiKey{}, fmt.Errorf("error creating api key: %w", err)
+	}
+
+	return convertApiKeyRowToApiKey(apiKeyRow), nil
+}
+
+func (d *Database) RemoveApiKey(ctx context.Context, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		DELETE FROM api_keys
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return auth.ErrApiKeyNotFound
+	}
+
+	return nil
+}
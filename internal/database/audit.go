@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/google/uuid"
+)
+
+type AuditEntryRow struct {
+	ID         string
+	ActorID    string
+	ActorName  string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     []byte
+	After      []byte
+	CreatedAt  time.Time
+}
+
+func convertAuditEntryRowToEntry(row AuditEntryRow) audit.Entry {
+	return audit.Entry{
+		ID:         row.ID,
+		ActorID:    row.ActorID,
+		ActorName:  row.ActorName,
+		Action:     audit.Action(row.Action),
+		EntityType: row.EntityType,
+		EntityID:   row.EntityID,
+		Before:     row.Before,
+		After:      row.After,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func (d *Database) CreateAuditEntry(ctx context.Context, entry audit.Entry) error {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	_, err = d.conn(ctx).Exec(ctx, `
+		INSERT INTO audit_log (id, actor_id, actor_name, action, entity_type, entity_id, before, after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, newUuid.String(), entry.ActorID, entry.ActorName, string(entry.Action), entry.EntityType, entry.EntityID, []byte(entry.Before), []byte(entry.After))
+
+	if err != nil {
+		return fmt.Errorf("error creating audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Database) GetAuditEntriesByPagination(ctx context.Context, filter audit.Filter, pagination data.Pagination) ([]audit.Entry, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "createdat",
+			FormattedFieldName: "created_at",
+		},
+		{
+			FieldName:          "actor",
+			FormattedFieldName: "actor_id",
+		},
+		{
+			FieldName:          "entity",
+			FormattedFieldName: "entity_type",
+		},
+	}, "created_at")
+
+	// the audit log is read newest first unless asked otherwise
+	direction := "desc"
+	if pagination.OrderBy != "" {
+		direction = pagination.GetOrderByDirection()
+	}
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, actor_id, actor_name, action, entity_type, entity_id, before, after, created_at
+		FROM audit_log
+		WHERE ($3 = '' OR entity_type = $3)
+		AND ($4 = '' OR entity_id = $4)
+		AND ($5 = '' OR actor_id = $5)
+		AND ($6::timestamptz IS NULL OR created_at >= $6)
+		AND ($7::timestamptz IS NULL OR created_at <= $7)
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset, filter.EntityType, filter.EntityID, filter.ActorID, filter.From, filter.To)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting audit entries by pagination: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := []audit.Entry{}
+	for rows.Next() {
+		var row AuditEntryRow
+		err := rows.Scan(&row.ID, &row.ActorID, &row.ActorName, &row.Action, &row.EntityType, &row.EntityID, &row.Before, &row.After, &row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning audit entry row: %w", err)
+		}
+
+		entries = append(entries, convertAuditEntryRowToEntry(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return entries, nil
+}
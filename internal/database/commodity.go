@@ -3,11 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/FairleyC/space-sim-service/internal/data"
 	"github.com/FairleyC/space-sim-service/internal/services/commodity"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type CommodityRow struct {
@@ -15,6 +17,9 @@ type CommodityRow struct {
 	Name       sql.NullString
 	UnitMass   sql.NullFloat64
 	UnitVolume sql.NullFloat64
+	Category   sql.NullString
+	Tags       []string
+	DeletedAt  sql.NullTime
 }
 
 func convertCommodityRowToCommodity(row CommodityRow) commodity.Commodity {
@@ -23,27 +28,42 @@ func convertCommodityRowToCommodity(row CommodityRow) commodity.Commodity {
 		Name:       row.Name.String,
 		UnitMass:   row.UnitMass.Float64,
 		UnitVolume: row.UnitVolume.Float64,
+		Category:   commodity.Category(row.Category.String),
+		Tags:       nullTagsToTags(row.Tags),
+		DeletedAt:  nullTimeToPointer(row.DeletedAt),
 	}
 }
 
-func (d *Database) GetCommodityById(ctx context.Context, id string) (commodity.Commodity, error) {
+// nullTagsToTags - a commodity without tags lists none rather than null.
+func nullTagsToTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func (d *Database) GetCommodityById(ctx context.Context, id string, includeDeleted bool) (commodity.Commodity, error) {
 
 	var commodityRow CommodityRow
-	row := d.Pool.QueryRow(ctx, `
-		SELECT id, name, unitmass, unitvolume
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, name, unit_mass, unit_volume, category, tags, deleted_at
 		FROM commodities
 		WHERE id = $1
-	`, id)
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, id, includeDeleted)
 
-	err := row.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume)
+	err := row.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume, &commodityRow.Category, &commodityRow.Tags, &commodityRow.DeletedAt)
 	if err != nil {
-		return commodity.Commodity{}, commodity.ErrCommodityNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return commodity.Commodity{}, commodity.ErrCommodityNotFound
+		}
+		return commodity.Commodity{}, fmt.Errorf("error scanning commodity: %w", err)
 	}
 
 	return convertCommodityRowToCommodity(commodityRow), nil
 }
 
-func (d *Database) GetCommoditiesByPagination(ctx context.Context, pagination data.Pagination) ([]commodity.Commodity, error) {
+func (d *Database) GetCommoditiesByPagination(ctx context.Context, pagination data.Pagination, filter commodity.Filter) ([]commodity.Commodity, error) {
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 	orderBy := pagination.GetOrderByField([]data.AllowedField{
@@ -62,13 +82,16 @@ func (d *Database) GetCommoditiesByPagination(ctx context.Context, pagination da
 	}, "created_at")
 	direction := pagination.GetOrderByDirection()
 
-	rows, err := d.Pool.Query(ctx, `
-		SELECT id, name, unit_mass, unit_volume
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, unit_mass, unit_volume, category, tags, deleted_at
 		FROM commodities
+		WHERE ($3::boolean OR deleted_at IS NULL)
+		AND ($4 = '' OR category = $4)
+		AND ($5 = '' OR tags @> ARRAY[$5]::text[])
 		ORDER BY `+orderBy+` `+direction+`
 		LIMIT $1
 		OFFSET $2
-	`, limit, offset)
+	`, limit, offset, filter.IncludeDeleted, string(filter.Category), filter.Tag)
 
 	if err != nil {
 		return nil, fmt.Errorf("error getting commodities by pagination: %w", err)
@@ -79,7 +102,7 @@ func (d *Database) GetCommoditiesByPagination(ctx context.Context, pagination da
 	commodities := []commodity.Commodity{}
 	for rows.Next() {
 		var commodityRow CommodityRow
-		err := rows.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume)
+		err := rows.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume, &commodityRow.Category, &commodityRow.Tags, &commodityRow.DeletedAt)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning commodity row: %w", err)
 		}
@@ -94,6 +117,62 @@ func (d *Database) GetCommoditiesByPagination(ctx context.Context, pagination da
 	return commodities, nil
 }
 
+// GetCommoditiesByIds - fetches a batch of commodities in one query.
+// Ids that don't match a commodity are left out of the result.
+func (d *Database) GetCommoditiesByIds(ctx context.Context, ids []string, includeDeleted bool) ([]commodity.Commodity, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, unit_mass, unit_volume, category, tags, deleted_at
+		FROM commodities
+		WHERE id = ANY($1::uuid[])
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, ids, includeDeleted)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting commodities by ids: %w", err)
+	}
+
+	defer rows.Close()
+
+	commodities := []commodity.Commodity{}
+	for rows.Next() {
+		var commodityRow CommodityRow
+		err := rows.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume, &commodityRow.Category, &commodityRow.Tags, &commodityRow.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning commodity row: %w", err)
+		}
+
+		commodities = append(commodities, convertCommodityRowToCommodity(commodityRow))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return commodities, nil
+}
+
+// GetCommodityByName - the live commodity with the name, which the
+// active name index keeps unique.
+func (d *Database) GetCommodityByName(ctx context.Context, name string) (commodity.Commodity, error) {
+	var commodityRow CommodityRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, name, unit_mass, unit_volume, category, tags, deleted_at
+		FROM commodities
+		WHERE name = $1
+		AND deleted_at IS NULL
+	`, name)
+
+	err := row.Scan(&commodityRow.ID, &commodityRow.Name, &commodityRow.UnitMass, &commodityRow.UnitVolume, &commodityRow.Category, &commodityRow.Tags, &commodityRow.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return commodity.Commodity{}, commodity.ErrCommodityNotFound
+		}
+		return commodity.Commodity{}, fmt.Errorf("error scanning commodity: %w", err)
+	}
+
+	return convertCommodityRowToCommodity(commodityRow), nil
+}
+
 func (d *Database) CreateCommodity(ctx context.Context, newCommodity commodity.Commodity) (commodity.Commodity, error) {
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
@@ -106,31 +185,133 @@ func (d *Database) CreateCommodity(ctx context.Context, newCommodity commodity.C
 		Name:       sql.NullString{String: newCommodity.Name, Valid: true},
 		UnitMass:   sql.NullFloat64{Float64: newCommodity.UnitMass, Valid: true},
 		UnitVolume: sql.NullFloat64{Float64: newCommodity.UnitVolume, Valid: true},
+		Category:   sql.NullString{String: string(newCommodity.Category), Valid: true},
+		Tags:       nullTagsToTags(newCommodity.Tags),
 	}
 
-	rows, err := d.Pool.Query(ctx, `
-		INSERT INTO commodities (id, name, unit_mass, unit_volume)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, unit_mass, unit_volume
-	`, newRow.ID, newRow.Name, newRow.UnitMass, newRow.UnitVolume)
+	_, err = d.conn(ctx).Exec(ctx, `
+		INSERT INTO commodities (id, name, unit_mass, unit_volume, category, tags)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, newRow.ID, newRow.Name, newRow.UnitMass, newRow.UnitVolume, newRow.Category, newRow.Tags)
 
 	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return commodity.Commodity{}, commodity.ErrCommodityConflict
+		}
 		return commodity.Commodity{}, fmt.Errorf("error creating commodity: %w", err)
 	}
 
-	defer rows.Close()
-
 	return newCommodity, nil
 }
 
+// UpdateCommodity - overwrites the unit mass, volume, category and
+// tags of a live commodity. Its name is its natural key and is left
+// as it is.
+func (d *Database) UpdateCommodity(ctx context.Context, updatedCommodity commodity.Commodity) (commodity.Commodity, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE commodities
+		SET unit_mass = $1, unit_volume = $2, category = $4, tags = $5, updated_at = now()
+		WHERE id = $3
+		AND deleted_at IS NULL
+	`, updatedCommodity.UnitMass, updatedCommodity.UnitVolume, updatedCommodity.ID, string(updatedCommodity.Category), nullTagsToTags(updatedCommodity.Tags))
+	if err != nil {
+		return commodity.Commodity{}, fmt.Errorf("error updating commodity: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return commodity.Commodity{}, commodity.ErrCommodityNotFound
+	}
+
+	return d.GetCommodityById(ctx, updatedCommodity.ID, false)
+}
+
+// RemoveCommodity - soft deletes the commodity. It is hidden from
+// reads until restored, and hard deleted by the purge job.
 func (d *Database) RemoveCommodity(ctx context.Context, id string) error {
-	_, err := d.Pool.Exec(ctx, `
-		DELETE FROM commodities
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE commodities
+		SET deleted_at = now()
 		WHERE id = $1
+		AND deleted_at IS NULL
 	`, id)
 	if err != nil {
 		return fmt.Errorf("error deleting commodity: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return commodity.ErrCommodityNotFound
+	}
+
 	return nil
 }
+
+// RestoreCommodity - clears the commodity's soft delete along with
+// that of the markets deleted in the same transaction, as long as
+// their solar system has not been deleted since.
+// RestoreCommodity - brings the commodity back along with the markets
+// deleted with it, other than those of deleted solar systems, and
+// returns the markets it restored.
+func (d *Database) RestoreCommodity(ctx context.Context, id string) (commodity.Commodity, []commodity.DependentMarket, error) {
+	var restoredCommodity commodity.Commodity
+	restoredMarkets := []commodity.DependentMarket{}
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		deletedCommodity, err := d.GetCommodityById(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if deletedCommodity.DeletedAt == nil {
+			restoredCommodity = deletedCommodity
+			return nil
+		}
+
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE commodities
+			SET deleted_at = NULL
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return commodity.ErrCommodityConflict
+			}
+			return fmt.Errorf("error restoring commodity: %w", err)
+		}
+
+		rows, err := d.conn(ctx).Query(ctx, `
+			UPDATE solar_system_commodity_markets market
+			SET deleted_at = NULL
+			FROM solar_systems solar_system
+			WHERE market.solar_system_id = solar_system.id
+			AND solar_system.deleted_at IS NULL
+			AND market.commodity_id = $1
+			AND market.deleted_at = $2
+			RETURNING market.id, market.solar_system_id, solar_system.name
+		`, id, *deletedCommodity.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("error restoring commodity markets: %w", err)
+		}
+
+		for rows.Next() {
+			var market commodity.DependentMarket
+			if err := rows.Scan(&market.ID, &market.SolarSystemID, &market.SolarSystemName); err != nil {
+				rows.Close()
+				return fmt.Errorf("error scanning commodity market row: %w", err)
+			}
+
+			restoredMarkets = append(restoredMarkets, market)
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error restoring commodity markets: %w", err)
+		}
+
+		restoredCommodity, err = d.GetCommodityById(ctx, id, false)
+		return err
+	})
+	if err != nil {
+		return commodity.Commodity{}, nil, err
+	}
+
+	return restoredCommodity, restoredMarkets, nil
+}
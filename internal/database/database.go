@@ -2,9 +2,11 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -42,3 +44,11 @@ func NewDatabase(ctx context.Context) (*Database, error) {
 func (d *Database) Ping(ctx context.Context) error {
 	return d.Pool.Ping(ctx)
 }
+
+func nullTimeToPointer(nullTime sql.NullTime) *time.Time {
+	if !nullTime.Valid {
+		return nil
+	}
+
+	return &nullTime.Time
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type LegalityRuleRow struct {
+	ID            string
+	SolarSystemID string
+	CommodityID   sql.NullString
+	Category      sql.NullString
+	Status        string
+	TaxRate       float64
+}
+
+// fields - the scan targets of the columns every legality rule query
+// selects, in order: id, solar_system_id, commodity_id, category,
+// status, tax_rate.
+func (row *LegalityRuleRow) fields() []any {
+	return []any{&row.ID, &row.SolarSystemID, &row.CommodityID, &row.Category, &row.Status, &row.TaxRate}
+}
+
+func convertLegalityRuleRowToLegalityRule(row LegalityRuleRow) solarSystem.LegalityRule {
+	return solarSystem.LegalityRule{
+		ID:            row.ID,
+		SolarSystemID: row.SolarSystemID,
+		CommodityID:   row.CommodityID.String,
+		Category:      commodity.Category(row.Category.String),
+		Status:        solarSystem.LegalityStatus(row.Status),
+		TaxRate:       row.TaxRate,
+	}
+}
+
+// GetLegalityRulesBySolarSystemId - category rules first, then those
+// of single commodities.
+func (d *Database) GetLegalityRulesBySolarSystemId(ctx context.Context, solarSystemId string) ([]solarSystem.LegalityRule, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, solar_system_id, commodity_id, category, status, tax_rate
+		FROM legality_rules
+		WHERE solar_system_id = $1
+		ORDER BY commodity_id NULLS FIRST, category, created_at
+	`, solarSystemId)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting legality rules: %w", err)
+	}
+
+	defer rows.Close()
+
+	rules := []solarSystem.LegalityRule{}
+	for rows.Next() {
+		var row LegalityRuleRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			return nil, fmt.Errorf("error scanning legality rule row: %w", err)
+		}
+
+		rules = append(rules, convertLegalityRuleRowToLegalityRule(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (d *Database) GetLegalityRuleById(ctx context.Context, id string) (solarSystem.LegalityRule, error) {
+	var ruleRow LegalityRuleRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, solar_system_id, commodity_id, category, status, tax_rate
+		FROM legality_rules
+		WHERE id = $1
+	`, id)
+
+	if err := row.Scan(ruleRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.LegalityRule{}, solarSystem.ErrLegalityRuleNotFound
+		}
+		return solarSystem.LegalityRule{}, fmt.Errorf("error scanning legality rule: %w", err)
+	}
+
+	return convertLegalityRuleRowToLegalityRule(ruleRow), nil
+}
+
+// GetEffectiveLegalityRule - the rule of the solar system that decides
+// how the commodity is treated: its own rule if it has one, otherwise
+// the rule of its category.
+func (d *Database) GetEffectiveLegalityRule(ctx context.Context, solarSystemId string, commodityId string) (solarSystem.LegalityRule, error) {
+	var ruleRow LegalityRuleRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT rule.id, rule.solar_system_id, rule.commodity_id, rule.category, rule.status, rule.tax_rate
+		FROM legality_rules rule
+		WHERE rule.solar_system_id = $1
+		AND (
+			rule.commodity_id = $2
+			OR rule.category = (SELECT category FROM commodities WHERE id = $2 AND category <> '')
+		)
+		ORDER BY rule.commodity_id IS NULL
+		LIMIT 1
+	`, solarSystemId, commodityId)
+
+	if err := row.Scan(ruleRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.LegalityRule{}, solarSystem.ErrLegalityRuleNotFound
+		}
+		return solarSystem.LegalityRule{}, fmt.Errorf("error scanning legality rule: %w", err)
+	}
+
+	return convertLegalityRuleRowToLegalityRule(ruleRow), nil
+}
+
+// CreateLegalityRule - rules may only cover a live solar system and,
+// for a commodity rule, a live commodity.
+func (d *Database) CreateLegalityRule(ctx context.Context, rule solarSystem.LegalityRule) (solarSystem.LegalityRule, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return solarSystem.LegalityRule{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	var ruleRow LegalityRuleRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		INSERT INTO legality_rules (id, solar_system_id, commodity_id, category, status, tax_rate)
+		SELECT $1, $2, NULLIF($3, '')::uuid, NULLIF($4, ''), $5, $6
+		WHERE EXISTS (SELECT 1 FROM solar_systems WHERE id = $2 AND deleted_at IS NULL)
+		AND ($3 = '' OR EXISTS (SELECT 1 FROM commodities WHERE id = NULLIF($3, '')::uuid AND deleted_at IS NULL))
+		RETURNING id, solar_system_id, commodity_id, category, status, tax_rate
+	`, newUuid.String(), rule.SolarSystemID, rule.CommodityID, string(rule.Category), string(rule.Status), rule.TaxRate)
+
+	if err := row.Scan(ruleRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.LegalityRule{}, solarSystem.ErrLegalityReferenceNotFound
+		}
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.LegalityRule{}, solarSystem.ErrLegalityRuleConflict
+		}
+		return solarSystem.LegalityRule{}, fmt.Errorf("error creating legality rule: %w", err)
+	}
+
+	return convertLegalityRuleRowToLegalityRule(ruleRow), nil
+}
+
+func (d *Database) UpdateLegalityRule(ctx context.Context, solarSystemId string, id string, update solarSystem.LegalityRuleUpdate) (solarSystem.LegalityRule, error) {
+	var ruleRow LegalityRuleRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		UPDATE legality_rules
+		SET status = $3, tax_rate = $4, updated_at = now()
+		WHERE id = $1
+		AND solar_system_id = $2
+		RETURNING id, solar_system_id, commodity_id, category, status, tax_rate
+	`, id, solarSystemId, string(update.Status), update.TaxRate)
+
+	if err := row.Scan(ruleRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.LegalityRule{}, solarSystem.ErrLegalityRuleNotFound
+		}
+		return solarSystem.LegalityRule{}, fmt.Errorf("error updating legality rule: %w", err)
+	}
+
+	return convertLegalityRuleRowToLegalityRule(ruleRow), nil
+}
+
+// RemoveLegalityRule - hard deletes the rule; rules aren't soft
+// deleted like the entities they govern.
+func (d *Database) RemoveLegalityRule(ctx context.Context, solarSystemId string, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		DELETE FROM legality_rules
+		WHERE id = $1
+		AND solar_system_id = $2
+	`, id, solarSystemId)
+	if err != nil {
+		return fmt.Errorf("error deleting legality rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return solarSystem.ErrLegalityRuleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+)
+
+func (d *Database) CreateOutboxEvent(ctx context.Context, event events.Event) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		INSERT INTO outbox_events (type, entity_id, topics, data)
+		VALUES ($1, $2, $3, $4)
+	`, string(event.Type), event.EntityID, topicsOrEmpty(event.Topics), []byte(event.Data))
+
+	if err != nil {
+		return fmt.Errorf("error creating outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// FanOutOutboxEvents - creates a pending delivery of each undispatched
+// event for every webhook subscribed to it, then marks the events
+// dispatched. Locked rows are skipped so concurrent dispatchers
+// never fan out the same event twice.
+func (d *Database) FanOutOutboxEvents(ctx context.Context, limit int) (int64, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		WITH pending AS (
+			SELECT id, type
+			FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deliveries AS (
+			INSERT INTO webhook_deliveries (id, webhook_id, event_id)
+			SELECT gen_random_uuid(), webhook.id, pending.id
+			FROM pending
+			JOIN webhooks webhook
+			ON cardinality(webhook.event_types) = 0 OR pending.type = ANY(webhook.event_types)
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE outbox_events
+		SET dispatched_at = now()
+		WHERE id IN (SELECT id FROM pending)
+	`, limit)
+
+	if err != nil {
+		return 0, fmt.Errorf("error fanning out outbox events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+type OutboxEventRow struct {
+	ID        int64
+	Type      string
+	EntityID  sql.NullString
+	Topics    []string
+	Data      []byte
+	CreatedAt time.Time
+}
+
+func convertOutboxEventRowToEvent(row OutboxEventRow) events.Event {
+	return events.Event{
+		ID:        row.ID,
+		Type:      events.Type(row.Type),
+		EntityID:  row.EntityID.String,
+		Topics:    row.Topics,
+		Data:      row.Data,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func (d *Database) GetLatestOutboxEventId(ctx context.Context) (int64, error) {
+	var id int64
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT COALESCE(MAX(id), 0)
+		FROM outbox_events
+	`)
+
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("error scanning latest outbox event id: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetOutboxEventsAfter - returns up to limit events with an id above
+// afterId, in id order. A non-empty topics only matches events
+// published to at least one of them.
+func (d *Database) GetOutboxEventsAfter(ctx context.Context, afterId int64, topics []string, limit int) ([]events.Event, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, type, entity_id, topics, data, created_at
+		FROM outbox_events
+		WHERE id > $1
+		AND (cardinality($2::text[]) = 0 OR topics && $2)
+		ORDER BY id
+		LIMIT $3
+	`, afterId, topicsOrEmpty(topics), limit)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting outbox events: %w", err)
+	}
+
+	defer rows.Close()
+
+	outboxEvents := []events.Event{}
+	for rows.Next() {
+		var row OutboxEventRow
+		err := rows.Scan(&row.ID, &row.Type, &row.EntityID, &row.Topics, &row.Data, &row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning outbox event row: %w", err)
+		}
+
+		outboxEvents = append(outboxEvents, convertOutboxEventRowToEvent(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return outboxEvents, nil
+}
+
+// topicsOrEmpty - a nil slice is sent as NULL, which the
+// topic filters would not treat as an empty array.
+func topicsOrEmpty(topics []string) []string {
+	if topics == nil {
+		return []string{}
+	}
+
+	return topics
+}
@@ -0,0 +1,565 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type RecipeRow struct {
+	ID           string
+	Name         string
+	CycleSeconds int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// fields - the scan targets of the columns every recipe query
+// selects, in order: id, name, cycle_seconds, created_at, updated_at.
+func (row *RecipeRow) fields() []any {
+	return []any{&row.ID, &row.Name, &row.CycleSeconds, &row.CreatedAt, &row.UpdatedAt}
+}
+
+func convertRecipeRowToRecipe(row RecipeRow) production.Recipe {
+	return production.Recipe{
+		ID:        row.ID,
+		Name:      row.Name,
+		CycleTime: time.Duration(row.CycleSeconds) * time.Second,
+		Inputs:    []production.Component{},
+		Outputs:   []production.Component{},
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+type FacilityRow struct {
+	ID            string
+	SolarSystemID string
+	StationID     sql.NullString
+	RecipeID      string
+	RecipeName    string
+	Name          string
+	Enabled       bool
+	Status        string
+	StalledReason string
+	LastCycleAt   sql.NullTime
+	NextCycleAt   time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// fields - the scan targets of the columns every facility query
+// selects, in order: id, solar_system_id, station_id, recipe_id,
+// the recipe's name, name, enabled, status, stalled_reason,
+// last_cycle_at, next_cycle_at, created_at, updated_at.
+func (row *FacilityRow) fields() []any {
+	return []any{&row.ID, &row.SolarSystemID, &row.StationID, &row.RecipeID, &row.RecipeName, &row.Name, &row.Enabled,
+		&row.Status, &row.StalledReason, &row.LastCycleAt, &row.NextCycleAt, &row.CreatedAt, &row.UpdatedAt}
+}
+
+func convertFacilityRowToFacility(row FacilityRow) production.Facility {
+	return production.Facility{
+		ID:            row.ID,
+		SolarSystemID: row.SolarSystemID,
+		StationID:     row.StationID.String,
+		RecipeID:      row.RecipeID,
+		RecipeName:    row.RecipeName,
+		Name:          row.Name,
+		Enabled:       row.Enabled,
+		Status:        production.FacilityStatus(row.Status),
+		StalledReason: row.StalledReason,
+		LastCycleAt:   nullTimeToPointer(row.LastCycleAt),
+		NextCycleAt:   row.NextCycleAt,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+}
+
+const facilityColumns = `facility.id, facility.solar_system_id, facility.station_id, facility.recipe_id, recipe.name, facility.name, facility.enabled,
+	facility.status, facility.stalled_reason, facility.last_cycle_at, facility.next_cycle_at, facility.created_at, facility.updated_at`
+
+func (d *Database) GetRecipesByPagination(ctx context.Context, pagination data.Pagination, filter production.RecipeFilter) ([]production.Recipe, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "name",
+			FormattedFieldName: "name",
+		},
+		{
+			FieldName:          "cycleseconds",
+			FormattedFieldName: "cycle_seconds",
+		},
+	}, "created_at")
+	direction := pagination.GetOrderByDirection()
+
+	return d.queryRecipes(ctx, `
+		SELECT id, name, cycle_seconds, created_at, updated_at
+		FROM recipes recipe
+		WHERE ($3 = '' OR EXISTS (
+			SELECT 1 FROM recipe_components component
+			WHERE component.recipe_id = recipe.id AND component.direction = 'output' AND component.commodity_id = NULLIF($3, '')::uuid
+		))
+		AND ($4 = '' OR EXISTS (
+			SELECT 1 FROM recipe_components component
+			WHERE component.recipe_id = recipe.id AND component.direction = 'input' AND component.commodity_id = NULLIF($4, '')::uuid
+		))
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset, filter.Produces, filter.Consumes)
+}
+
+// GetAllRecipes - every recipe, sorted by name, for expanding a bill
+// of materials.
+func (d *Database) GetAllRecipes(ctx context.Context) ([]production.Recipe, error) {
+	return d.queryRecipes(ctx, `
+		SELECT id, name, cycle_seconds, created_at, updated_at
+		FROM recipes
+		ORDER BY name
+	`)
+}
+
+func (d *Database) GetRecipeById(ctx context.Context, id string) (production.Recipe, error) {
+	recipes, err := d.queryRecipes(ctx, `
+		SELECT id, name, cycle_seconds, created_at, updated_at
+		FROM recipes
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return production.Recipe{}, err
+	}
+
+	if len(recipes) == 0 {
+		return production.Recipe{}, production.ErrRecipeNotFound
+	}
+
+	return recipes[0], nil
+}
+
+// queryRecipes - runs a query selecting recipe rows and fills in
+// their inputs and outputs with a second query.
+func (d *Database) queryRecipes(ctx context.Context, query string, args ...any) ([]production.Recipe, error) {
+	rows, err := d.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recipes: %w", err)
+	}
+
+	recipes := []production.Recipe{}
+	ids := []string{}
+	for rows.Next() {
+		var row RecipeRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning recipe row: %w", err)
+		}
+
+		recipes = append(recipes, convertRecipeRowToRecipe(row))
+		ids = append(ids, row.ID)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return recipes, nil
+	}
+
+	componentRows, err := d.conn(ctx).Query(ctx, `
+		SELECT component.recipe_id, component.commodity_id, commodity.name, component.direction, component.quantity
+		FROM recipe_components component
+		JOIN commodities commodity ON component.commodity_id = commodity.id
+		WHERE component.recipe_id = ANY($1::uuid[])
+		ORDER BY commodity.name
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recipe components: %w", err)
+	}
+
+	defer componentRows.Close()
+
+	byId := map[string]*production.Recipe{}
+	for i := range recipes {
+		byId[recipes[i].ID] = &recipes[i]
+	}
+
+	for componentRows.Next() {
+		var recipeId, direction string
+		var component production.Component
+		if err := componentRows.Scan(&recipeId, &component.CommodityID, &component.CommodityName, &direction, &component.Quantity); err != nil {
+			return nil, fmt.Errorf("error scanning recipe component row: %w", err)
+		}
+
+		recipe := byId[recipeId]
+		if direction == "input" {
+			recipe.Inputs = append(recipe.Inputs, component)
+		} else {
+			recipe.Outputs = append(recipe.Outputs, component)
+		}
+	}
+
+	if err := componentRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return recipes, nil
+}
+
+func (d *Database) CreateRecipe(ctx context.Context, recipe production.Recipe) (production.Recipe, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return production.Recipe{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	_, err = d.conn(ctx).Exec(ctx, `
+		INSERT INTO recipes (id, name, cycle_seconds)
+		VALUES ($1, $2, $3)
+	`, newUuid.String(), recipe.Name, int(recipe.CycleTime/time.Second))
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return production.Recipe{}, production.ErrRecipeConflict
+		}
+		return production.Recipe{}, fmt.Errorf("error creating recipe: %w", err)
+	}
+
+	if err := d.insertRecipeComponents(ctx, newUuid.String(), recipe); err != nil {
+		return production.Recipe{}, err
+	}
+
+	return d.GetRecipeById(ctx, newUuid.String())
+}
+
+// UpdateRecipe - replaces the recipe's inputs and outputs along with
+// its name and cycle time.
+func (d *Database) UpdateRecipe(ctx context.Context, recipe production.Recipe) (production.Recipe, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE recipes
+		SET name = $2, cycle_seconds = $3, updated_at = now()
+		WHERE id = $1
+	`, recipe.ID, recipe.Name, int(recipe.CycleTime/time.Second))
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return production.Recipe{}, production.ErrRecipeConflict
+		}
+		return production.Recipe{}, fmt.Errorf("error updating recipe: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return production.Recipe{}, production.ErrRecipeNotFound
+	}
+
+	if _, err := d.conn(ctx).Exec(ctx, `DELETE FROM recipe_components WHERE recipe_id = $1`, recipe.ID); err != nil {
+		return production.Recipe{}, fmt.Errorf("error deleting recipe components: %w", err)
+	}
+
+	if err := d.insertRecipeComponents(ctx, recipe.ID, recipe); err != nil {
+		return production.Recipe{}, err
+	}
+
+	return d.GetRecipeById(ctx, recipe.ID)
+}
+
+// insertRecipeComponents - components may only reference commodities
+// that have not been soft deleted.
+func (d *Database) insertRecipeComponents(ctx context.Context, recipeId string, recipe production.Recipe) error {
+	insert := func(direction string, component production.Component) error {
+		result, err := d.conn(ctx).Exec(ctx, `
+			INSERT INTO recipe_components (recipe_id, commodity_id, direction, quantity)
+			SELECT $1, id, $3, $4
+			FROM commodities
+			WHERE id = $2
+			AND deleted_at IS NULL
+		`, recipeId, component.CommodityID, direction, component.Quantity)
+		if err != nil {
+			return fmt.Errorf("error creating recipe component: %w", err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("%w: %s", production.ErrRecipeReferenceNotFound, component.CommodityID)
+		}
+
+		return nil
+	}
+
+	for _, input := range recipe.Inputs {
+		if err := insert("input", input); err != nil {
+			return err
+		}
+	}
+
+	for _, output := range recipe.Outputs {
+		if err := insert("output", output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveRecipe - hard deletes the recipe and its components, unless a
+// facility, even one of a deleted solar system, still runs it.
+func (d *Database) RemoveRecipe(ctx context.Context, id string) error {
+	var inUse bool
+	if err := d.conn(ctx).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM facilities WHERE recipe_id = $1)`, id).Scan(&inUse); err != nil {
+		return fmt.Errorf("error checking recipe facilities: %w", err)
+	}
+
+	if inUse {
+		return production.ErrRecipeInUse
+	}
+
+	result, err := d.conn(ctx).Exec(ctx, `DELETE FROM recipes WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting recipe: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return production.ErrRecipeNotFound
+	}
+
+	return nil
+}
+
+func (d *Database) GetFacilitiesBySolarSystemId(ctx context.Context, solarSystemId string) ([]production.Facility, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT `+facilityColumns+`
+		FROM facilities facility
+		JOIN recipes recipe ON facility.recipe_id = recipe.id
+		WHERE facility.solar_system_id = $1
+		ORDER BY facility.name
+	`, solarSystemId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting facilities: %w", err)
+	}
+
+	return scanFacilities(rows)
+}
+
+func (d *Database) GetFacilityById(ctx context.Context, id string) (production.Facility, error) {
+	var facilityRow FacilityRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT `+facilityColumns+`
+		FROM facilities facility
+		JOIN recipes recipe ON facility.recipe_id = recipe.id
+		WHERE facility.id = $1
+	`, id)
+
+	if err := row.Scan(facilityRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return production.Facility{}, production.ErrFacilityNotFound
+		}
+		return production.Facility{}, fmt.Errorf("error scanning facility: %w", err)
+	}
+
+	return convertFacilityRowToFacility(facilityRow), nil
+}
+
+// CreateFacility - facilities may only be built in a live solar
+// system, at a live station of it, and run an existing recipe. Their
+// first cycle is due a cycle of the recipe after they are created.
+func (d *Database) CreateFacility(ctx context.Context, facility production.Facility) (production.Facility, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return production.Facility{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	result, err := d.conn(ctx).Exec(ctx, `
+		INSERT INTO facilities (id, solar_system_id, station_id, recipe_id, name, enabled, status, next_cycle_at)
+		SELECT $1, $2, NULLIF($3, '')::uuid, recipe.id, $5, $6, 'idle', now() + make_interval(secs => recipe.cycle_seconds)
+		FROM recipes recipe
+		WHERE recipe.id = $4
+		AND EXISTS (SELECT 1 FROM solar_systems WHERE id = $2 AND deleted_at IS NULL)
+		AND ($3 = '' OR EXISTS (SELECT 1 FROM stations WHERE id = NULLIF($3, '')::uuid AND solar_system_id = $2 AND deleted_at IS NULL))
+	`, newUuid.String(), facility.SolarSystemID, facility.StationID, facility.RecipeID, facility.Name, facility.Enabled)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return production.Facility{}, production.ErrFacilityConflict
+		}
+		return production.Facility{}, fmt.Errorf("error creating facility: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return production.Facility{}, production.ErrFacilityReferenceNotFound
+	}
+
+	return d.GetFacilityById(ctx, newUuid.String())
+}
+
+// UpdateFacility - a facility changing recipe, or being enabled or
+// disabled, goes back to idle and its next cycle is due a full cycle
+// of its recipe later.
+func (d *Database) UpdateFacility(ctx context.Context, solarSystemId string, id string, update production.FacilityUpdate) (production.Facility, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE facilities facility
+		SET name = $3,
+			recipe_id = recipe.id,
+			enabled = $5,
+			status = CASE WHEN facility.recipe_id <> recipe.id OR facility.enabled <> $5 THEN 'idle' ELSE facility.status END,
+			stalled_reason = CASE WHEN facility.recipe_id <> recipe.id OR facility.enabled <> $5 THEN '' ELSE facility.stalled_reason END,
+			next_cycle_at = CASE WHEN facility.recipe_id <> recipe.id OR facility.enabled <> $5
+				THEN now() + make_interval(secs => recipe.cycle_seconds)
+				ELSE facility.next_cycle_at END,
+			updated_at = now()
+		FROM recipes recipe
+		WHERE recipe.id = $4
+		AND facility.id = $1
+		AND facility.solar_system_id = $2
+	`, id, solarSystemId, update.Name, update.RecipeID, update.Enabled)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return production.Facility{}, production.ErrFacilityConflict
+		}
+		return production.Facility{}, fmt.Errorf("error updating facility: %w", err)
+	}
+
+	// the facility itself was found by the service
+	if result.RowsAffected() == 0 {
+		return production.Facility{}, production.ErrFacilityReferenceNotFound
+	}
+
+	return d.GetFacilityById(ctx, id)
+}
+
+func (d *Database) RemoveFacility(ctx context.Context, solarSystemId string, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		DELETE FROM facilities
+		WHERE id = $1
+		AND solar_system_id = $2
+	`, id, solarSystemId)
+	if err != nil {
+		return fmt.Errorf("error deleting facility: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return production.ErrFacilityNotFound
+	}
+
+	return nil
+}
+
+// ClaimDueFacilities - locks up to limit enabled facilities whose next
+// cycle is due, skipping those another server is running, until the
+// transaction ends. Facilities of a deleted solar system or station
+// are never due.
+func (d *Database) ClaimDueFacilities(ctx context.Context, limit int) ([]production.Facility, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT `+facilityColumns+`
+		FROM facilities facility
+		JOIN recipes recipe ON facility.recipe_id = recipe.id
+		JOIN solar_systems solar_system ON facility.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON facility.station_id = station.id
+		WHERE facility.enabled
+		AND facility.next_cycle_at <= now()
+		AND solar_system.deleted_at IS NULL
+		AND station.deleted_at IS NULL
+		ORDER BY facility.next_cycle_at
+		LIMIT $1
+		FOR UPDATE OF facility SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error claiming due facilities: %w", err)
+	}
+
+	return scanFacilities(rows)
+}
+
+// GetFacilityStock - locks the live markets trading the commodities
+// where the facility is, its station or else its solar system, and
+// returns their stock keyed by commodity id.
+func (d *Database) GetFacilityStock(ctx context.Context, facility production.Facility, commodityIds []string) (map[string]int, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT commodity_id, stock
+		FROM solar_system_commodity_markets
+		WHERE solar_system_id = $1
+		AND station_id IS NOT DISTINCT FROM NULLIF($2, '')::uuid
+		AND commodity_id = ANY($3::uuid[])
+		AND deleted_at IS NULL
+		FOR UPDATE
+	`, facility.SolarSystemID, facility.StationID, commodityIds)
+	if err != nil {
+		return nil, fmt.Errorf("error getting facility stock: %w", err)
+	}
+
+	defer rows.Close()
+
+	stock := map[string]int{}
+	for rows.Next() {
+		var commodityId string
+		var held int
+		if err := rows.Scan(&commodityId, &held); err != nil {
+			return nil, fmt.Errorf("error scanning stock row: %w", err)
+		}
+		stock[commodityId] = held
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return stock, nil
+}
+
+func (d *Database) SetFacilityStock(ctx context.Context, facility production.Facility, stock map[string]int) error {
+	for commodityId, held := range stock {
+		_, err := d.conn(ctx).Exec(ctx, `
+			UPDATE solar_system_commodity_markets
+			SET stock = $4, updated_at = now()
+			WHERE solar_system_id = $1
+			AND station_id IS NOT DISTINCT FROM NULLIF($2, '')::uuid
+			AND commodity_id = $3
+			AND deleted_at IS NULL
+		`, facility.SolarSystemID, facility.StationID, commodityId, held)
+		if err != nil {
+			return fmt.Errorf("error updating stock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CompleteFacilityCycle - records how the facility's cycle went and
+// schedules its next a cycle from now, whether it ran or stalled.
+func (d *Database) CompleteFacilityCycle(ctx context.Context, id string, status production.FacilityStatus, stalledReason string, cycleTime time.Duration) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE facilities
+		SET status = $2,
+			stalled_reason = $3,
+			last_cycle_at = CASE WHEN $2 = 'producing' THEN now() ELSE last_cycle_at END,
+			next_cycle_at = now() + make_interval(secs => $4)
+		WHERE id = $1
+	`, id, string(status), stalledReason, cycleTime.Seconds())
+	if err != nil {
+		return fmt.Errorf("error completing facility cycle: %w", err)
+	}
+
+	return nil
+}
+
+func scanFacilities(rows pgx.Rows) ([]production.Facility, error) {
+	defer rows.Close()
+
+	facilities := []production.Facility{}
+	for rows.Next() {
+		var row FacilityRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			return nil, fmt.Errorf("error scanning facility row: %w", err)
+		}
+
+		facilities = append(facilities, convertFacilityRowToFacility(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return facilities, nil
+}
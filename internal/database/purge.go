@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/jobs"
+)
+
+// purgedSolarSystems - the solar systems soft deleted before the
+// cutoff in $1 that no ship is docked in or travelling to.
+const purgedSolarSystems = `
+	SELECT solar_system.id FROM solar_systems solar_system
+	WHERE solar_system.deleted_at < $1
+	AND NOT EXISTS (
+		SELECT 1 FROM ships ship
+		WHERE ship.solar_system_id = solar_system.id
+		OR ship.destination_id = solar_system.id
+	)`
+
+// purgedCommodities - the commodities soft deleted before the cutoff
+// in $1 that no recipe takes or makes and no ship carries.
+const purgedCommodities = `
+	SELECT commodity.id FROM commodities commodity
+	WHERE commodity.deleted_at < $1
+	AND NOT EXISTS (
+		SELECT 1 FROM recipe_components component
+		WHERE component.commodity_id = commodity.id
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM ship_cargo cargo
+		WHERE cargo.commodity_id = commodity.id
+	)`
+
+// PurgeDeleted - hard deletes every row soft deleted before the
+// cutoff. Markets go first, including any still pointing at a
+// purged solar system, station or commodity, then stations and bodies,
+// so no foreign key is left dangling. A solar system a ship is docked
+// in or travelling to is kept until the ship moves on, as the purge
+// never removes ships. Likewise a commodity a recipe still takes or
+// makes, or a ship carries, is kept until the recipe is removed or the
+// ship's hold is emptied of it.
+func (d *Database) PurgeDeleted(ctx context.Context, before time.Time) (jobs.PurgeResult, error) {
+	var result jobs.PurgeResult
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		markets, err := d.conn(ctx).Exec(ctx, `
+			DELETE FROM solar_system_commodity_markets
+			WHERE deleted_at < $1
+			OR commodity_id IN (`+purgedCommodities+`)
+			OR solar_system_id IN (`+purgedSolarSystems+`)
+			OR station_id IN (SELECT id FROM stations WHERE deleted_at < $1)
+		`, before)
+		if err != nil {
+			return fmt.Errorf("error purging commodity markets: %w", err)
+		}
+		result.CommodityMarkets = markets.RowsAffected()
+
+		stations, err := d.conn(ctx).Exec(ctx, `
+			DELETE FROM stations
+			WHERE deleted_at < $1
+			OR solar_system_id IN (`+purgedSolarSystems+`)
+		`, before)
+		if err != nil {
+			return fmt.Errorf("error purging stations: %w", err)
+		}
+		result.Stations = stations.RowsAffected()
+
+		// purging a body unsets it on deleted stations and takes the
+		// bodies orbiting it along, so a body is kept while any body
+		// below it is not due to be purged itself
+		bodies, err := d.conn(ctx).Exec(ctx, `
+			WITH RECURSIVE kept AS (
+				SELECT body.parent_id AS id
+				FROM celestial_bodies body
+				WHERE body.parent_id IS NOT NULL
+				AND (body.deleted_at < $1 OR body.solar_system_id IN (`+purgedSolarSystems+`)) IS NOT TRUE
+				UNION
+				SELECT body.parent_id
+				FROM celestial_bodies body
+				JOIN kept ON body.id = kept.id
+				WHERE body.parent_id IS NOT NULL
+			)
+			DELETE FROM celestial_bodies
+			WHERE (deleted_at < $1 OR solar_system_id IN (`+purgedSolarSystems+`))
+			AND id NOT IN (SELECT id FROM kept)
+		`, before)
+		if err != nil {
+			return fmt.Errorf("error purging celestial bodies: %w", err)
+		}
+		result.CelestialBodies = bodies.RowsAffected()
+
+		commodities, err := d.conn(ctx).Exec(ctx, `
+			DELETE FROM commodities
+			WHERE id IN (`+purgedCommodities+`)
+		`, before)
+		if err != nil {
+			return fmt.Errorf("error purging commodities: %w", err)
+		}
+		result.Commodities = commodities.RowsAffected()
+
+		solarSystems, err := d.conn(ctx).Exec(ctx, `
+			DELETE FROM solar_systems
+			WHERE id IN (`+purgedSolarSystems+`)
+		`, before)
+		if err != nil {
+			return fmt.Errorf("error purging solar systems: %w", err)
+		}
+		result.SolarSystems = solarSystems.RowsAffected()
+
+		return nil
+	})
+	if err != nil {
+		return jobs.PurgeResult{}, err
+	}
+
+	return result, nil
+}
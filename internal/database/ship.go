@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ShipRow struct {
+	ID              string
+	Name            string
+	SolarSystemID   string
+	SolarSystemName string
+	Speed           float64
+	DestinationID   sql.NullString
+	DestinationName sql.NullString
+	DepartedAt      sql.NullTime
+	ArrivesAt       sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// fields - the scan targets of shipColumns, in order.
+func (row *ShipRow) fields() []any {
+	return []any{&row.ID, &row.Name, &row.SolarSystemID, &row.SolarSystemName, &row.Speed, &row.DestinationID, &row.DestinationName,
+		&row.DepartedAt, &row.ArrivesAt, &row.CreatedAt, &row.UpdatedAt}
+}
+
+func convertShipRowToShip(row ShipRow) ship.Ship {
+	return ship.Ship{
+		ID:              row.ID,
+		Name:            row.Name,
+		SolarSystemID:   row.SolarSystemID,
+		SolarSystemName: row.SolarSystemName,
+		Speed:           row.Speed,
+		DestinationID:   row.DestinationID.String,
+		DestinationName: row.DestinationName.String,
+		DepartedAt:      nullTimeToPointer(row.DepartedAt),
+		ArrivesAt:       nullTimeToPointer(row.ArrivesAt),
+		Cargo:           []ship.Cargo{},
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+const shipColumns = `ship.id, ship.name, ship.solar_system_id, location.name, ship.speed, ship.destination_id, destination.name,
+	ship.departed_at, ship.arrives_at, ship.created_at, ship.updated_at`
+
+const shipTables = `ships ship
+	JOIN solar_systems location ON ship.solar_system_id = location.id
+	LEFT JOIN solar_systems destination ON ship.destination_id = destination.id`
+
+func (d *Database) GetShipsByPagination(ctx context.Context, pagination data.Pagination) ([]ship.Ship, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "name",
+			FormattedFieldName: "ship.name",
+		},
+		{
+			FieldName:          "arrivesat",
+			FormattedFieldName: "ship.arrives_at",
+		},
+	}, "ship.created_at")
+	direction := pagination.GetOrderByDirection()
+
+	return d.queryShips(ctx, `
+		SELECT `+shipColumns+`
+		FROM `+shipTables+`
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset)
+}
+
+func (d *Database) GetShipById(ctx context.Context, id string) (ship.Ship, error) {
+	ships, err := d.queryShips(ctx, `
+		SELECT `+shipColumns+`
+		FROM `+shipTables+`
+		WHERE ship.id = $1
+	`, id)
+	if err != nil {
+		return ship.Ship{}, err
+	}
+
+	if len(ships) == 0 {
+		return ship.Ship{}, ship.ErrShipNotFound
+	}
+
+	return ships[0], nil
+}
+
+// LockShip - locks the ship until the transaction ends, so it can't
+// depart, arrive or trade twice at once, and returns it.
+func (d *Database) LockShip(ctx context.Context, id string) (ship.Ship, error) {
+	ships, err := d.queryShips(ctx, `
+		SELECT `+shipColumns+`
+		FROM `+shipTables+`
+		WHERE ship.id = $1
+		FOR UPDATE OF ship
+	`, id)
+	if err != nil {
+		return ship.Ship{}, err
+	}
+
+	if len(ships) == 0 {
+		return ship.Ship{}, ship.ErrShipNotFound
+	}
+
+	return ships[0], nil
+}
+
+// queryShips - runs a query selecting shipColumns and loads the cargo
+// of every ship it returns.
+func (d *Database) queryShips(ctx context.Context, query string, args ...any) ([]ship.Ship, error) {
+	rows, err := d.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ships: %w", err)
+	}
+
+	ships := []ship.Ship{}
+	ids := []string{}
+	for rows.Next() {
+		var row ShipRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning ship row: %w", err)
+		}
+
+		ships = append(ships, convertShipRowToShip(row))
+		ids = append(ids, row.ID)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return ships, nil
+	}
+
+	cargoRows, err := d.conn(ctx).Query(ctx, `
+		SELECT cargo.ship_id, cargo.commodity_id, commodity.name, cargo.quantity
+		FROM ship_cargo cargo
+		JOIN commodities commodity ON cargo.commodity_id = commodity.id
+		WHERE cargo.ship_id = ANY($1::uuid[])
+		ORDER BY commodity.name
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ship cargo: %w", err)
+	}
+
+	defer cargoRows.Close()
+
+	byId := map[string]*ship.Ship{}
+	for i := range ships {
+		byId[ships[i].ID] = &ships[i]
+	}
+
+	for cargoRows.Next() {
+		var shipId string
+		var cargo ship.Cargo
+		if err := cargoRows.Scan(&shipId, &cargo.CommodityID, &cargo.CommodityName, &cargo.Quantity); err != nil {
+			return nil, fmt.Errorf("error scanning ship cargo row: %w", err)
+		}
+
+		byId[shipId].Cargo = append(byId[shipId].Cargo, cargo)
+	}
+
+	if err := cargoRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return ships, nil
+}
+
+// CreateShip - ships may only be placed in a live solar system.
+func (d *Database) CreateShip(ctx context.Context, newShip ship.Ship) (ship.Ship, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return ship.Ship{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	result, err := d.conn(ctx).Exec(ctx, `
+		INSERT INTO ships (id, name, solar_system_id, speed)
+		SELECT $1, $2, solar_system.id, $4
+		FROM solar_systems solar_system
+		WHERE solar_system.id = $3
+		AND solar_system.deleted_at IS NULL
+	`, newUuid.String(), newShip.Name, newShip.SolarSystemID, newShip.Speed)
+	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return ship.Ship{}, ship.ErrShipConflict
+		}
+		return ship.Ship{}, fmt.Errorf("error creating ship: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ship.Ship{}, ship.ErrShipReferenceNotFound
+	}
+
+	return d.GetShipById(ctx, newUuid.String())
+}
+
+func (d *Database) RemoveShip(ctx context.Context, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		DELETE FROM ships
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting ship: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ship.ErrShipNotFound
+	}
+
+	return nil
+}
+
+// DepartShip - puts the ship in transit to the destination, arriving
+// travelTime from now.
+func (d *Database) DepartShip(ctx context.Context, id string, destinationId string, travelTime time.Duration) (ship.Ship, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE ships
+		SET destination_id = $2,
+			departed_at = now(),
+			arrives_at = now() + make_interval(secs => $3),
+			updated_at = now()
+		WHERE id = $1
+		AND destination_id IS NULL
+	`, id, destinationId, travelTime.Seconds())
+	if err != nil {
+		return ship.Ship{}, fmt.Errorf("error departing ship: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ship.Ship{}, ship.ErrShipInTransit
+	}
+
+	return d.GetShipById(ctx, id)
+}
+
+// ClaimArrivals - locks up to limit ships whose arrival time has
+// passed, skipping those another server is docking, until the
+// transaction ends.
+func (d *Database) ClaimArrivals(ctx context.Context, limit int) ([]ship.Ship, error) {
+	return d.queryShips(ctx, `
+		SELECT `+shipColumns+`
+		FROM `+shipTables+`
+		WHERE ship.arrives_at <= now()
+		ORDER BY ship.arrives_at
+		LIMIT $1
+		FOR UPDATE OF ship SKIP LOCKED
+	`, limit)
+}
+
+// CompleteArrival - docks the ship in its destination.
+func (d *Database) CompleteArrival(ctx context.Context, id string) (ship.Ship, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE ships
+		SET solar_system_id = destination_id,
+			destination_id = NULL,
+			departed_at = NULL,
+			arrives_at = NULL,
+			updated_at = now()
+		WHERE id = $1
+		AND destination_id IS NOT NULL
+	`, id)
+	if err != nil {
+		return ship.Ship{}, fmt.Errorf("error completing ship arrival: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ship.Ship{}, ship.ErrShipNotFound
+	}
+
+	return d.GetShipById(ctx, id)
+}
+
+// AdjustMarketStock - adds delta units to the live market's stock,
+// refusing to take it below zero.
+func (d *Database) AdjustMarketStock(ctx context.Context, commodityMarketId string, delta int) error {
+	var stock int
+	row := d.conn(ctx).QueryRow(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET stock = stock + $2, updated_at = now()
+		WHERE id = $1
+		AND deleted_at IS NULL
+		AND stock + $2 >= 0
+		RETURNING stock
+	`, commodityMarketId, delta)
+
+	if err := row.Scan(&stock); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ship.ErrInsufficientStock
+		}
+		return fmt.Errorf("error adjusting market stock: %w", err)
+	}
+
+	return nil
+}
+
+// SetShipCargo - sets the units of the commodity the ship carries,
+// dropping the commodity from its hold at zero.
+func (d *Database) SetShipCargo(ctx context.Context, shipId string, commodityId string, quantity int) error {
+	if quantity == 0 {
+		_, err := d.conn(ctx).Exec(ctx, `
+			DELETE FROM ship_cargo
+			WHERE ship_id = $1
+			AND commodity_id = $2
+		`, shipId, commodityId)
+		if err != nil {
+			return fmt.Errorf("error removing ship cargo: %w", err)
+		}
+
+		return nil
+	}
+
+	_, err := d.conn(ctx).Exec(ctx, `
+		INSERT INTO ship_cargo (ship_id, commodity_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (ship_id, commodity_id) DO UPDATE SET quantity = EXCLUDED.quantity
+	`, shipId, commodityId, quantity)
+	if err != nil {
+		return fmt.Errorf("error setting ship cargo: %w", err)
+	}
+
+	return nil
+}
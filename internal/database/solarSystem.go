@@ -3,22 +3,43 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/FairleyC/space-sim-service/internal/data"
 	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type SolarSystemRow struct {
-	ID   string
-	Name sql.NullString
+	ID        string
+	Name      sql.NullString
+	X         float64
+	Y         float64
+	Z         float64
+	StarClass string
+	Region    string
+	Sector    string
+	DeletedAt sql.NullTime
+}
+
+// fields - the scan targets of the columns every solar system
+// query selects, in order: id, name, x, y, z, star_class, region,
+// sector, deleted_at.
+func (row *SolarSystemRow) fields() []any {
+	return []any{&row.ID, &row.Name, &row.X, &row.Y, &row.Z, &row.StarClass, &row.Region, &row.Sector, &row.DeletedAt}
 }
 
 func convertSolarSystemRowToSolarSystem(row SolarSystemRow) solarSystem.SolarSystem {
 	return solarSystem.SolarSystem{
-		ID:   row.ID,
-		Name: row.Name.String,
+		ID:          row.ID,
+		Name:        row.Name.String,
+		Coordinates: solarSystem.Coordinates{X: row.X, Y: row.Y, Z: row.Z},
+		StarClass:   solarSystem.StarClass(row.StarClass),
+		Region:      row.Region,
+		Sector:      row.Sector,
+		DeletedAt:   nullTimeToPointer(row.DeletedAt),
 	}
 }
 
@@ -26,23 +47,32 @@ func convertSolarSystemRowToSolarSystemWithCommodityMarkets(row SolarSystemRow,
 	return solarSystem.SolarSystemWithCommodityMarkets{
 		ID:               row.ID,
 		Name:             row.Name.String,
+		Coordinates:      solarSystem.Coordinates{X: row.X, Y: row.Y, Z: row.Z},
+		StarClass:        solarSystem.StarClass(row.StarClass),
+		Region:           row.Region,
+		Sector:           row.Sector,
+		DeletedAt:        nullTimeToPointer(row.DeletedAt),
 		CommodityMarkets: commodityMarkets,
 	}
 }
-func (d *Database) GetSolarSystemById(ctx context.Context, id string) (solarSystem.SolarSystemWithCommodityMarkets, error) {
+func (d *Database) GetSolarSystemById(ctx context.Context, id string, includeDeleted bool) (solarSystem.SolarSystemWithCommodityMarkets, error) {
 
 	var solarSystemRow SolarSystemRow
-	row := d.Pool.QueryRow(ctx, `
-		SELECT id, name
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at
 		FROM solar_systems
 		WHERE id = $1
-	`, id)
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, id, includeDeleted)
 
-	err := row.Scan(&solarSystemRow.ID, &solarSystemRow.Name)
+	err := row.Scan(solarSystemRow.fields()...)
 	if err != nil {
-		return solarSystem.SolarSystemWithCommodityMarkets{}, solarSystem.ErrSolarSystemNotFound
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.SolarSystemWithCommodityMarkets{}, solarSystem.ErrSolarSystemNotFound
+		}
+		return solarSystem.SolarSystemWithCommodityMarkets{}, fmt.Errorf("error scanning solar system: %w", err)
 	}
-	commodityMarkets, err := d.GetCommodityMarketsBySolarSystemId(ctx, id)
+	commodityMarkets, err := d.GetCommodityMarketsBySolarSystemId(ctx, id, includeDeleted)
 	if err != nil {
 		return solarSystem.SolarSystemWithCommodityMarkets{}, fmt.Errorf("error getting commodity markets: %w", err)
 	}
@@ -50,7 +80,7 @@ func (d *Database) GetSolarSystemById(ctx context.Context, id string) (solarSyst
 	return convertSolarSystemRowToSolarSystemWithCommodityMarkets(solarSystemRow, commodityMarkets), nil
 }
 
-func (d *Database) GetSolarSystemsByPagination(ctx context.Context, pagination data.Pagination) ([]solarSystem.SolarSystem, error) {
+func (d *Database) GetSolarSystemsByPagination(ctx context.Context, pagination data.Pagination, filter solarSystem.Filter) ([]solarSystem.SolarSystem, error) {
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 	orderBy := pagination.GetOrderByField([]data.AllowedField{
@@ -58,16 +88,29 @@ func (d *Database) GetSolarSystemsByPagination(ctx context.Context, pagination d
 			FieldName:          "name",
 			FormattedFieldName: "name",
 		},
-	}, "createdat")
+		{
+			FieldName:          "starclass",
+			FormattedFieldName: "star_class",
+		},
+		{
+			FieldName:          "region",
+			FormattedFieldName: "region",
+		},
+		{
+			FieldName:          "sector",
+			FormattedFieldName: "sector",
+		},
+	}, "created_at")
 	direction := pagination.GetOrderByDirection()
 
-	rows, err := d.Pool.Query(ctx, `
-		SELECT id, name
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at
 		FROM solar_systems
+		WHERE ($3::boolean OR deleted_at IS NULL)
 		ORDER BY `+orderBy+` `+direction+`
 		LIMIT $1
 		OFFSET $2
-	`, limit, offset)
+	`, limit, offset, filter.IncludeDeleted)
 
 	if err != nil {
 		return nil, fmt.Errorf("error getting solar systems by pagination: %w", err)
@@ -78,7 +121,41 @@ func (d *Database) GetSolarSystemsByPagination(ctx context.Context, pagination d
 	solarSystems := []solarSystem.SolarSystem{}
 	for rows.Next() {
 		var solarSystemRow SolarSystemRow
-		err := rows.Scan(&solarSystemRow.ID, &solarSystemRow.Name)
+		err := rows.Scan(solarSystemRow.fields()...)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning solar system row: %w", err)
+		}
+
+		solarSystems = append(solarSystems, convertSolarSystemRowToSolarSystem(solarSystemRow))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return solarSystems, nil
+}
+
+// GetSolarSystemsByIds - fetches a batch of solar systems in one query.
+// Ids that don't match a solar system are left out of the result.
+func (d *Database) GetSolarSystemsByIds(ctx context.Context, ids []string, includeDeleted bool) ([]solarSystem.SolarSystem, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at
+		FROM solar_systems
+		WHERE id = ANY($1::uuid[])
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, ids, includeDeleted)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting solar systems by ids: %w", err)
+	}
+
+	defer rows.Close()
+
+	solarSystems := []solarSystem.SolarSystem{}
+	for rows.Next() {
+		var solarSystemRow SolarSystemRow
+		err := rows.Scan(solarSystemRow.fields()...)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning solar system row: %w", err)
 		}
@@ -93,6 +170,28 @@ func (d *Database) GetSolarSystemsByPagination(ctx context.Context, pagination d
 	return solarSystems, nil
 }
 
+// GetSolarSystemByName - the live solar system with the name, which
+// the active name index keeps unique.
+func (d *Database) GetSolarSystemByName(ctx context.Context, name string) (solarSystem.SolarSystem, error) {
+	var solarSystemRow SolarSystemRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at
+		FROM solar_systems
+		WHERE name = $1
+		AND deleted_at IS NULL
+	`, name)
+
+	err := row.Scan(solarSystemRow.fields()...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.SolarSystem{}, solarSystem.ErrSolarSystemNotFound
+		}
+		return solarSystem.SolarSystem{}, fmt.Errorf("error scanning solar system: %w", err)
+	}
+
+	return convertSolarSystemRowToSolarSystem(solarSystemRow), nil
+}
+
 func (d *Database) CreateSolarSystem(ctx context.Context, newSolarSystem solarSystem.SolarSystem) (solarSystem.SolarSystem, error) {
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
@@ -105,47 +204,219 @@ func (d *Database) CreateSolarSystem(ctx context.Context, newSolarSystem solarSy
 		Name: sql.NullString{String: newSolarSystem.Name, Valid: true},
 	}
 
-	rows, err := d.Pool.Query(ctx, `
-		INSERT INTO solar_systems (id, name)
-		VALUES ($1, $2)
-		RETURNING id, name
-	`, newRow.ID, newRow.Name)
+	_, err = d.conn(ctx).Exec(ctx, `
+		INSERT INTO solar_systems (id, name, x, y, z, star_class, region, sector)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, newRow.ID, newRow.Name, newSolarSystem.Coordinates.X, newSolarSystem.Coordinates.Y, newSolarSystem.Coordinates.Z, string(newSolarSystem.StarClass), newSolarSystem.Region, newSolarSystem.Sector)
 
 	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.SolarSystem{}, solarSystem.ErrSolarSystemConflict
+		}
 		return solarSystem.SolarSystem{}, fmt.Errorf("error creating solar system: %w", err)
 	}
 
-	defer rows.Close()
-
 	return newSolarSystem, nil
 }
 
-func (d *Database) RemoveSolarSystem(ctx context.Context, id string) error {
-	tx, err := d.Pool.Begin(ctx)
+// UpdateSolarSystem - overwrites everything but the name of the live
+// solar system, which stays its natural key.
+func (d *Database) UpdateSolarSystem(ctx context.Context, updatedSolarSystem solarSystem.SolarSystem) (solarSystem.SolarSystem, error) {
+	var solarSystemRow SolarSystemRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		UPDATE solar_systems
+		SET x = $2, y = $3, z = $4, star_class = $5, region = $6, sector = $7
+		WHERE id = $1
+		AND deleted_at IS NULL
+		RETURNING id, name, x, y, z, star_class, region, sector, deleted_at
+	`, updatedSolarSystem.ID, updatedSolarSystem.Coordinates.X, updatedSolarSystem.Coordinates.Y, updatedSolarSystem.Coordinates.Z, string(updatedSolarSystem.StarClass), updatedSolarSystem.Region, updatedSolarSystem.Sector)
+
+	err := row.Scan(solarSystemRow.fields()...)
 	if err != nil {
-		return fmt.Errorf("error beginning transaction: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.SolarSystem{}, solarSystem.ErrSolarSystemNotFound
+		}
+		return solarSystem.SolarSystem{}, fmt.Errorf("error updating solar system: %w", err)
 	}
 
-	defer func() {
+	return convertSolarSystemRowToSolarSystem(solarSystemRow), nil
+}
+
+// GetSolarSystemsNearby - the live solar systems within radius of the
+// centre, nearest first. The cube around the centre narrows the search
+// on the position index before the exact distance is checked.
+func (d *Database) GetSolarSystemsNearby(ctx context.Context, centre solarSystem.Coordinates, radius float64, pagination data.Pagination) ([]solarSystem.NearbySolarSystem, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at, cube_distance(cube(ARRAY[x, y, z]), cube(ARRAY[$3, $4, $5]::float8[]))
+		FROM solar_systems
+		WHERE deleted_at IS NULL
+		AND cube(ARRAY[x, y, z]) <@ cube_enlarge(cube(ARRAY[$3, $4, $5]::float8[]), $6, 3)
+		AND cube_distance(cube(ARRAY[x, y, z]), cube(ARRAY[$3, $4, $5]::float8[])) <= $6
+		ORDER BY cube(ARRAY[x, y, z]) <-> cube(ARRAY[$3, $4, $5]::float8[]), name
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset, centre.X, centre.Y, centre.Z, radius)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting solar systems nearby: %w", err)
+	}
+
+	return scanNearbySolarSystems(rows)
+}
+
+// GetSolarSystemNeighbors - the k live solar systems nearest to the
+// solar system, walking the position index in order of distance.
+func (d *Database) GetSolarSystemNeighbors(ctx context.Context, id string, k int) ([]solarSystem.NearbySolarSystem, error) {
+	var position solarSystem.Coordinates
+	err := d.conn(ctx).QueryRow(ctx, `
+		SELECT x, y, z
+		FROM solar_systems
+		WHERE id = $1
+		AND deleted_at IS NULL
+	`, id).Scan(&position.X, &position.Y, &position.Z)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, solarSystem.ErrSolarSystemNotFound
+		}
+		return nil, fmt.Errorf("error scanning solar system: %w", err)
+	}
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, name, x, y, z, star_class, region, sector, deleted_at, cube_distance(cube(ARRAY[x, y, z]), cube(ARRAY[$2, $3, $4]::float8[]))
+		FROM solar_systems
+		WHERE deleted_at IS NULL
+		AND id <> $1
+		ORDER BY cube(ARRAY[x, y, z]) <-> cube(ARRAY[$2, $3, $4]::float8[]), name
+		LIMIT $5
+	`, id, position.X, position.Y, position.Z, k)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting solar system neighbors: %w", err)
+	}
+
+	return scanNearbySolarSystems(rows)
+}
+
+// scanNearbySolarSystems - reads rows of the solar system columns
+// followed by the distance.
+func scanNearbySolarSystems(rows pgx.Rows) ([]solarSystem.NearbySolarSystem, error) {
+	defer rows.Close()
+
+	nearby := []solarSystem.NearbySolarSystem{}
+	for rows.Next() {
+		var solarSystemRow SolarSystemRow
+		var distance float64
+		err := rows.Scan(append(solarSystemRow.fields(), &distance)...)
 		if err != nil {
-			tx.Rollback(ctx)
-		} else {
-			tx.Commit(ctx)
+			return nil, fmt.Errorf("error scanning solar system row: %w", err)
 		}
-	}()
 
-	errRemovingCommodityMarkets := d.RemoveAllCommodityMarketsBySolarSystemId(ctx, id)
-	if errRemovingCommodityMarkets != nil {
-		return fmt.Errorf("error removing commodity markets: %w", errRemovingCommodityMarkets)
+		nearby = append(nearby, solarSystem.NearbySolarSystem{
+			SolarSystem: convertSolarSystemRowToSolarSystem(solarSystemRow),
+			Distance:    distance,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
-	_, errDeleteSolarSystem := d.Pool.Exec(ctx, `
-		DELETE FROM solar_systems
+	return nearby, nil
+}
+
+// RemoveSolarSystem - soft deletes the solar system. It is hidden
+// from reads until restored, and hard deleted by the purge job.
+func (d *Database) RemoveSolarSystem(ctx context.Context, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_systems
+		SET deleted_at = now()
 		WHERE id = $1
+		AND deleted_at IS NULL
 	`, id)
-	if errDeleteSolarSystem != nil {
+	if err != nil {
 		return fmt.Errorf("error deleting solar system: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return solarSystem.ErrSolarSystemNotFound
+	}
+
 	return nil
 }
+
+// RestoreSolarSystem - clears the solar system's soft delete along
+// with that of the bodies, stations and markets deleted in the same
+// transaction, as long as a market's commodity has not been deleted
+// since.
+func (d *Database) RestoreSolarSystem(ctx context.Context, id string) (solarSystem.SolarSystemWithCommodityMarkets, error) {
+	var restoredSolarSystem solarSystem.SolarSystemWithCommodityMarkets
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		deletedSolarSystem, err := d.GetSolarSystemById(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if deletedSolarSystem.DeletedAt == nil {
+			restoredSolarSystem, err = d.GetSolarSystemById(ctx, id, false)
+			return err
+		}
+
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE solar_systems
+			SET deleted_at = NULL
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return solarSystem.ErrSolarSystemConflict
+			}
+			return fmt.Errorf("error restoring solar system: %w", err)
+		}
+
+		// nothing can be added to a deleted solar system, so its bodies
+		// and stations come back without colliding with newer ones
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE celestial_bodies
+			SET deleted_at = NULL
+			WHERE solar_system_id = $1
+			AND deleted_at = $2
+		`, id, *deletedSolarSystem.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("error restoring celestial bodies: %w", err)
+		}
+
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE stations
+			SET deleted_at = NULL
+			WHERE solar_system_id = $1
+			AND deleted_at = $2
+		`, id, *deletedSolarSystem.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("error restoring stations: %w", err)
+		}
+
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE solar_system_commodity_markets market
+			SET deleted_at = NULL
+			FROM commodities commodity
+			WHERE market.commodity_id = commodity.id
+			AND commodity.deleted_at IS NULL
+			AND market.solar_system_id = $1
+			AND market.deleted_at = $2
+		`, id, *deletedSolarSystem.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("error restoring commodity markets: %w", err)
+		}
+
+		restoredSolarSystem, err = d.GetSolarSystemById(ctx, id, false)
+		return err
+	})
+	if err != nil {
+		return solarSystem.SolarSystemWithCommodityMarkets{}, err
+	}
+
+	return restoredSolarSystem, nil
+}
@@ -2,50 +2,80 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
 	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueViolation - the Postgres error code raised when a
+// write collides with a unique constraint or index.
+const uniqueViolation = "23505"
+
 type SolarSystemCommodityMarketRow struct {
 	ID             string
 	BasePrice      float64
 	DemandQuantity int
+	Stock          int
 	CommodityID    string
 	SolarSystemID  string
+	StationID      sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      sql.NullTime
 }
 
 type SolarSystemCommodityMarketRowWithCommodityName struct {
 	SolarSystemCommodityMarketRow
-	CommodityName string
+	CommodityName       string
+	CommodityUnitMass   float64
+	CommodityUnitVolume float64
+	SolarSystemName     string
+	StationName         sql.NullString
 }
 
 func convertSolarSystemCommodityMarketRowWithCommodityNameToSolarSystemCommodityMarket(row SolarSystemCommodityMarketRowWithCommodityName) solarSystem.CommodityMarket {
 	return solarSystem.CommodityMarket{
-		ID:             row.ID,
-		BasePrice:      row.BasePrice,
-		DemandQuantity: row.DemandQuantity,
-		CommodityName:  row.CommodityName,
+		ID:                  row.ID,
+		SolarSystemID:       row.SolarSystemID,
+		StationID:           row.StationID.String,
+		StationName:         row.StationName.String,
+		CommodityID:         row.CommodityID,
+		BasePrice:           row.BasePrice,
+		DemandQuantity:      row.DemandQuantity,
+		Stock:               row.Stock,
+		CommodityName:       row.CommodityName,
+		CommodityUnitMass:   row.CommodityUnitMass,
+		CommodityUnitVolume: row.CommodityUnitVolume,
+		SolarSystemName:     row.SolarSystemName,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
+		DeletedAt:           nullTimeToPointer(row.DeletedAt),
 	}
 }
 
-func convertSolarSystemCommodityMarketRowToSolarSystemCommodityMarket(row SolarSystemCommodityMarketRow, commodityName string) solarSystem.CommodityMarket {
-	return solarSystem.CommodityMarket{
-		ID:             row.ID,
-		BasePrice:      row.BasePrice,
-		DemandQuantity: row.DemandQuantity,
-		CommodityName:  commodityName,
-	}
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
 }
 
-func (d *Database) GetCommodityMarketsBySolarSystemId(ctx context.Context, solarSystemId string) ([]solarSystem.CommodityMarket, error) {
-	rows, err := d.Pool.Query(ctx, `
-		SELECT market.id, market.base_price, market.demand_quantity, market.commodity_id, market.solar_system_id, commodity.name
+func (d *Database) GetCommodityMarketsBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT market.id, market.base_price, market.demand_quantity, market.stock, market.commodity_id, market.solar_system_id, market.station_id, market.created_at, market.updated_at, market.deleted_at, commodity.name, commodity.unit_mass, commodity.unit_volume, solar_system.name, station.name
 		FROM solar_system_commodity_markets market
 		JOIN commodities commodity ON market.commodity_id = commodity.id
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON market.station_id = station.id
 		WHERE market.solar_system_id = $1
-	`, solarSystemId)
+		AND ($2::boolean OR market.deleted_at IS NULL)
+	`, solarSystemId, includeDeleted)
 
 	if err != nil {
 		return []solarSystem.CommodityMarket{}, err
@@ -56,7 +86,7 @@ func (d *Database) GetCommodityMarketsBySolarSystemId(ctx context.Context, solar
 	commodityMarkets := []solarSystem.CommodityMarket{}
 	for rows.Next() {
 		var row SolarSystemCommodityMarketRowWithCommodityName
-		err := rows.Scan(&row.ID, &row.BasePrice, &row.DemandQuantity, &row.CommodityID, &row.SolarSystemID, &row.CommodityName)
+		err := rows.Scan(&row.ID, &row.BasePrice, &row.DemandQuantity, &row.Stock, &row.CommodityID, &row.SolarSystemID, &row.StationID, &row.CreatedAt, &row.UpdatedAt, &row.DeletedAt, &row.CommodityName, &row.CommodityUnitMass, &row.CommodityUnitVolume, &row.SolarSystemName, &row.StationName)
 		if err != nil {
 			return []solarSystem.CommodityMarket{}, err
 		}
@@ -67,39 +97,209 @@ func (d *Database) GetCommodityMarketsBySolarSystemId(ctx context.Context, solar
 	return commodityMarkets, nil
 }
 
-func (d *Database) GetCommodityMarketById(ctx context.Context, id string) (solarSystem.CommodityMarket, error) {
+// GetCommodityMarketsBySolarSystemIds - fetches the markets of a batch
+// of solar systems in one query.
+func (d *Database) GetCommodityMarketsBySolarSystemIds(ctx context.Context, solarSystemIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	return d.getCommodityMarketsByColumn(ctx, "solar_system_id", solarSystemIds, includeDeleted)
+}
+
+// GetCommodityMarketsByCommodityIds - fetches the markets trading a
+// batch of commodities in one query.
+func (d *Database) GetCommodityMarketsByCommodityIds(ctx context.Context, commodityIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	return d.getCommodityMarketsByColumn(ctx, "commodity_id", commodityIds, includeDeleted)
+}
+
+// getCommodityMarketsByColumn - column is never user input, only
+// one of the market's reference columns.
+func (d *Database) getCommodityMarketsByColumn(ctx context.Context, column string, ids []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT market.id, market.base_price, market.demand_quantity, market.stock, market.commodity_id, market.solar_system_id, market.station_id, market.created_at, market.updated_at, market.deleted_at, commodity.name, commodity.unit_mass, commodity.unit_volume, solar_system.name, station.name
+		FROM solar_system_commodity_markets market
+		JOIN commodities commodity ON market.commodity_id = commodity.id
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON market.station_id = station.id
+		WHERE market.`+column+` = ANY($1::uuid[])
+		AND ($2::boolean OR market.deleted_at IS NULL)
+		ORDER BY market.created_at
+	`, ids, includeDeleted)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting commodity markets by %s: %w", column, err)
+	}
+
+	defer rows.Close()
+
+	commodityMarkets := []solarSystem.CommodityMarket{}
+	for rows.Next() {
+		var row SolarSystemCommodityMarketRowWithCommodityName
+		err := rows.Scan(&row.ID, &row.BasePrice, &row.DemandQuantity, &row.Stock, &row.CommodityID, &row.SolarSystemID, &row.StationID, &row.CreatedAt, &row.UpdatedAt, &row.DeletedAt, &row.CommodityName, &row.CommodityUnitMass, &row.CommodityUnitVolume, &row.SolarSystemName, &row.StationName)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning commodity market row: %w", err)
+		}
+
+		commodityMarkets = append(commodityMarkets, convertSolarSystemCommodityMarketRowWithCommodityNameToSolarSystemCommodityMarket(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return commodityMarkets, nil
+}
+
+// GetCommodityMarketsByPagination - the markets of every solar system
+// that match the filter, sortable by price and demand.
+func (d *Database) GetCommodityMarketsByPagination(ctx context.Context, pagination data.Pagination, filter solarSystem.MarketFilter) ([]solarSystem.CommodityMarket, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "baseprice",
+			FormattedFieldName: "market.base_price",
+		},
+		{
+			FieldName:          "demandquantity",
+			FormattedFieldName: "market.demand_quantity",
+		},
+		{
+			FieldName:          "commodityname",
+			FormattedFieldName: "commodity.name",
+		},
+		{
+			FieldName:          "solarsystemname",
+			FormattedFieldName: "solar_system.name",
+		},
+		{
+			FieldName:          "createdat",
+			FormattedFieldName: "market.created_at",
+		},
+	}, "market.created_at")
+	direction := pagination.GetOrderByDirection()
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT market.id, market.base_price, market.demand_quantity, market.stock, market.commodity_id, market.solar_system_id, market.station_id, market.created_at, market.updated_at, market.deleted_at, commodity.name, commodity.unit_mass, commodity.unit_volume, solar_system.name, station.name
+		FROM solar_system_commodity_markets market
+		JOIN commodities commodity ON market.commodity_id = commodity.id
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON market.station_id = station.id
+		WHERE ($3 = '' OR market.commodity_id = NULLIF($3, '')::uuid)
+		AND ($4 = '' OR market.solar_system_id = NULLIF($4, '')::uuid)
+		AND ($5::float8 IS NULL OR market.base_price >= $5)
+		AND ($6::float8 IS NULL OR market.base_price <= $6)
+		AND ($7::integer IS NULL OR market.demand_quantity >= $7)
+		AND ($8::boolean OR market.deleted_at IS NULL)
+		AND ($9 = '' OR market.station_id = NULLIF($9, '')::uuid)
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset, filter.CommodityID, filter.SolarSystemID, filter.MinPrice, filter.MaxPrice, filter.MinDemand, filter.IncludeDeleted, filter.StationID)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting commodity markets by pagination: %w", err)
+	}
+
+	defer rows.Close()
+
+	commodityMarkets := []solarSystem.CommodityMarket{}
+	for rows.Next() {
+		var row SolarSystemCommodityMarketRowWithCommodityName
+		err := rows.Scan(&row.ID, &row.BasePrice, &row.DemandQuantity, &row.Stock, &row.CommodityID, &row.SolarSystemID, &row.StationID, &row.CreatedAt, &row.UpdatedAt, &row.DeletedAt, &row.CommodityName, &row.CommodityUnitMass, &row.CommodityUnitVolume, &row.SolarSystemName, &row.StationName)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning commodity market row: %w", err)
+		}
+
+		commodityMarkets = append(commodityMarkets, convertSolarSystemCommodityMarketRowWithCommodityNameToSolarSystemCommodityMarket(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return commodityMarkets, nil
+}
+
+func (d *Database) GetCommodityMarketById(ctx context.Context, id string, includeDeleted bool) (solarSystem.CommodityMarket, error) {
 	var marketRow SolarSystemCommodityMarketRowWithCommodityName
-	row := d.Pool.QueryRow(ctx, `
-		SELECT market.id, market.base_price, market.demand_quantity, commodity.name
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT market.id, market.base_price, market.demand_quantity, market.stock, market.commodity_id, market.solar_system_id, market.station_id, market.created_at, market.updated_at, market.deleted_at, commodity.name, commodity.unit_mass, commodity.unit_volume, solar_system.name, station.name
 		FROM solar_system_commodity_markets market
 		JOIN commodities commodity ON market.commodity_id = commodity.id
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON market.station_id = station.id
 		WHERE market.id = $1
-	`, id)
+		AND ($2::boolean OR market.deleted_at IS NULL)
+	`, id, includeDeleted)
+
+	err := row.Scan(&marketRow.ID, &marketRow.BasePrice, &marketRow.DemandQuantity, &marketRow.Stock, &marketRow.CommodityID, &marketRow.SolarSystemID, &marketRow.StationID, &marketRow.CreatedAt, &marketRow.UpdatedAt, &marketRow.DeletedAt, &marketRow.CommodityName, &marketRow.CommodityUnitMass, &marketRow.CommodityUnitVolume, &marketRow.SolarSystemName, &marketRow.StationName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.CommodityMarket{}, solarSystem.ErrCommodityMarketNotFound
+		}
+		return solarSystem.CommodityMarket{}, fmt.Errorf("error scanning commodity market: %w", err)
+	}
+
+	return convertSolarSystemCommodityMarketRowWithCommodityNameToSolarSystemCommodityMarket(marketRow), nil
+}
+
+// GetCommodityMarketByReferences - the live system level market
+// trading the commodity in the solar system, of which there is at
+// most one.
+func (d *Database) GetCommodityMarketByReferences(ctx context.Context, solarSystemId string, commodityId string) (solarSystem.CommodityMarket, error) {
+	var marketRow SolarSystemCommodityMarketRowWithCommodityName
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT market.id, market.base_price, market.demand_quantity, market.stock, market.commodity_id, market.solar_system_id, market.station_id, market.created_at, market.updated_at, market.deleted_at, commodity.name, commodity.unit_mass, commodity.unit_volume, solar_system.name, station.name
+		FROM solar_system_commodity_markets market
+		JOIN commodities commodity ON market.commodity_id = commodity.id
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		LEFT JOIN stations station ON market.station_id = station.id
+		WHERE market.solar_system_id = $1
+		AND market.commodity_id = $2
+		AND market.station_id IS NULL
+		AND market.deleted_at IS NULL
+	`, solarSystemId, commodityId)
 
-	err := row.Scan(&marketRow.ID, &marketRow.BasePrice, &marketRow.DemandQuantity, &marketRow.CommodityName)
+	err := row.Scan(&marketRow.ID, &marketRow.BasePrice, &marketRow.DemandQuantity, &marketRow.Stock, &marketRow.CommodityID, &marketRow.SolarSystemID, &marketRow.StationID, &marketRow.CreatedAt, &marketRow.UpdatedAt, &marketRow.DeletedAt, &marketRow.CommodityName, &marketRow.CommodityUnitMass, &marketRow.CommodityUnitVolume, &marketRow.SolarSystemName, &marketRow.StationName)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.CommodityMarket{}, solarSystem.ErrCommodityMarketNotFound
+		}
 		return solarSystem.CommodityMarket{}, fmt.Errorf("error scanning commodity market: %w", err)
 	}
 
 	return convertSolarSystemCommodityMarketRowWithCommodityNameToSolarSystemCommodityMarket(marketRow), nil
 }
 
-func (d *Database) CreateCommodityMarket(ctx context.Context, solarSystemId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error) {
+// CreateCommodityMarket - opens a market at the station, or at the
+// solar system level when stationId is empty.
+func (d *Database) CreateCommodityMarket(ctx context.Context, solarSystemId string, stationId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error) {
 	newUuid, err := uuid.NewRandom()
 	if err != nil {
 		return solarSystem.CommodityMarket{}, fmt.Errorf("error generating uuid: %w", err)
 	}
 
-	_, err = d.Pool.Exec(ctx, `
-		INSERT INTO solar_system_commodity_markets (id, base_price, demand_quantity, commodity_id, solar_system_id)
-		VALUES ($1, $2, $3, $4, $5)
-	`, newUuid.String(), basePrice, demandQuantity, commodityId, solarSystemId)
+	// markets may only reference a solar system, station and commodity
+	// that have not been soft deleted, which the foreign keys can't
+	// enforce, and only a station of their own solar system
+	result, err := d.conn(ctx).Exec(ctx, `
+		INSERT INTO solar_system_commodity_markets (id, base_price, demand_quantity, commodity_id, solar_system_id, station_id)
+		SELECT $1, $2, $3, $4, $5, NULLIF($6, '')::uuid
+		WHERE EXISTS (SELECT 1 FROM commodities WHERE id = $4 AND deleted_at IS NULL)
+		AND EXISTS (SELECT 1 FROM solar_systems WHERE id = $5 AND deleted_at IS NULL)
+		AND ($6 = '' OR EXISTS (SELECT 1 FROM stations WHERE id = NULLIF($6, '')::uuid AND solar_system_id = $5 AND deleted_at IS NULL))
+	`, newUuid.String(), basePrice, demandQuantity, commodityId, solarSystemId, stationId)
 
 	if err != nil {
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.CommodityMarket{}, solarSystem.ErrCommodityMarketConflict
+		}
 		return solarSystem.CommodityMarket{}, fmt.Errorf("error creating commodity market: %w", err)
 	}
 
-	commodityMarket, err := d.GetCommodityMarketById(ctx, newUuid.String())
+	if result.RowsAffected() == 0 {
+		return solarSystem.CommodityMarket{}, solarSystem.ErrMarketReferenceNotFound
+	}
+
+	commodityMarket, err := d.GetCommodityMarketById(ctx, newUuid.String(), false)
 	if err != nil {
 		return solarSystem.CommodityMarket{}, fmt.Errorf("error getting commodity market by id: %w", err)
 	}
@@ -107,53 +307,148 @@ func (d *Database) CreateCommodityMarket(ctx context.Context, solarSystemId stri
 	return commodityMarket, nil
 }
 
-func (d *Database) UpdateCommodityMarket(ctx context.Context, commodityMarketId string, updatedCommodityMarket solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error) {
-	rows, err := d.Pool.Query(ctx, `
-		UPDATE solar_system_commodity_markets
-		SET base_price = $1, demand_quantity = $2
-		WHERE id = $3
-		RETURNING id, base_price, demand_quantity, commodity_id, solar_system_id
-	`, updatedCommodityMarket.BasePrice, updatedCommodityMarket.DemandQuantity, commodityMarketId)
-
+// UpsertCommodityMarket - creates the live system level market trading
+// the commodity in the solar system, or updates the one there is,
+// reporting whether it was created. Conflicts are resolved on the unique index of live
+// markets, so concurrent upserts of the same pair never collide.
+func (d *Database) UpsertCommodityMarket(ctx context.Context, solarSystemId string, commodityId string, basePrice float64, demandQuantity int) (solarSystem.CommodityMarket, bool, error) {
+	newUuid, err := uuid.NewRandom()
 	if err != nil {
-		return solarSystem.CommodityMarket{}, fmt.Errorf("error updating commodity market: %w", err)
+		return solarSystem.CommodityMarket{}, false, fmt.Errorf("error generating uuid: %w", err)
 	}
 
-	defer rows.Close()
+	var id string
+	var created bool
+	err = d.conn(ctx).QueryRow(ctx, `
+		INSERT INTO solar_system_commodity_markets AS market (id, base_price, demand_quantity, commodity_id, solar_system_id)
+		SELECT $1, $2, $3, $4, $5
+		WHERE EXISTS (SELECT 1 FROM commodities WHERE id = $4 AND deleted_at IS NULL)
+		AND EXISTS (SELECT 1 FROM solar_systems WHERE id = $5 AND deleted_at IS NULL)
+		ON CONFLICT (commodity_id, solar_system_id) WHERE deleted_at IS NULL AND station_id IS NULL
+		DO UPDATE SET base_price = EXCLUDED.base_price, demand_quantity = EXCLUDED.demand_quantity, updated_at = now()
+		RETURNING market.id, market.xmax = 0
+	`, newUuid.String(), basePrice, demandQuantity, commodityId, solarSystemId).Scan(&id, &created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.CommodityMarket{}, false, solarSystem.ErrMarketReferenceNotFound
+		}
+		return solarSystem.CommodityMarket{}, false, fmt.Errorf("error upserting commodity market: %w", err)
+	}
 
-	var row SolarSystemCommodityMarketRow
-	err = rows.Scan(&row.ID, &row.BasePrice, &row.DemandQuantity, &row.CommodityID, &row.SolarSystemID)
+	commodityMarket, err := d.GetCommodityMarketById(ctx, id, false)
 	if err != nil {
-		return solarSystem.CommodityMarket{}, fmt.Errorf("error scanning commodity market: %w", err)
+		return solarSystem.CommodityMarket{}, false, fmt.Errorf("error getting commodity market by id: %w", err)
 	}
 
-	commodity, err := d.GetCommodityById(ctx, row.CommodityID)
+	return commodityMarket, created, nil
+}
+
+// UpdateCommodityMarket - updates the market only if it belongs to the
+// solar system, reporting it as not found otherwise.
+func (d *Database) UpdateCommodityMarket(ctx context.Context, solarSystemId string, commodityMarketId string, updatedCommodityMarket solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error) {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET base_price = $1, demand_quantity = $2, updated_at = now()
+		WHERE id = $3
+		AND solar_system_id = $4
+		AND deleted_at IS NULL
+	`, updatedCommodityMarket.BasePrice, updatedCommodityMarket.DemandQuantity, commodityMarketId, solarSystemId)
 	if err != nil {
-		return solarSystem.CommodityMarket{}, fmt.Errorf("error getting commodity by id: %w", err)
+		return solarSystem.CommodityMarket{}, fmt.Errorf("error updating commodity market: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return solarSystem.CommodityMarket{}, solarSystem.ErrCommodityMarketNotFound
 	}
 
-	commodityMarket := convertSolarSystemCommodityMarketRowToSolarSystemCommodityMarket(row, commodity.Name)
+	commodityMarket, err := d.GetCommodityMarketById(ctx, commodityMarketId, false)
+	if err != nil {
+		return solarSystem.CommodityMarket{}, fmt.Errorf("error getting commodity market by id: %w", err)
+	}
 
 	return commodityMarket, nil
 }
 
-func (d *Database) RemoveCommodityMarket(ctx context.Context, id string) error {
-	_, err := d.Pool.Exec(ctx, `
-		DELETE FROM solar_system_commodity_markets
+// RemoveCommodityMarket - soft deletes the commodity market of the
+// solar system. It is hidden from reads until restored, and hard
+// deleted by the purge job.
+func (d *Database) RemoveCommodityMarket(ctx context.Context, solarSystemId string, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET deleted_at = now()
 		WHERE id = $1
-	`, id)
+		AND solar_system_id = $2
+		AND deleted_at IS NULL
+	`, id, solarSystemId)
 
 	if err != nil {
 		return fmt.Errorf("error deleting commodity market: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return solarSystem.ErrCommodityMarketNotFound
+	}
+
 	return nil
 }
 
+// RestoreCommodityMarket - clears the market's soft delete. A market
+// whose solar system, station or commodity is still deleted can't be
+// restored on its own, and neither can one that a newer market has
+// replaced.
+func (d *Database) RestoreCommodityMarket(ctx context.Context, solarSystemId string, id string) (solarSystem.CommodityMarket, error) {
+	var restoredCommodityMarket solarSystem.CommodityMarket
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		var referencesActive bool
+		row := d.conn(ctx).QueryRow(ctx, `
+			SELECT solar_system.deleted_at IS NULL AND commodity.deleted_at IS NULL AND station.deleted_at IS NULL
+			FROM solar_system_commodity_markets market
+			JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+			JOIN commodities commodity ON market.commodity_id = commodity.id
+			LEFT JOIN stations station ON market.station_id = station.id
+			WHERE market.id = $1
+			AND market.solar_system_id = $2
+		`, id, solarSystemId)
+
+		if err := row.Scan(&referencesActive); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return solarSystem.ErrCommodityMarketNotFound
+			}
+			return fmt.Errorf("error scanning commodity market: %w", err)
+		}
+
+		if !referencesActive {
+			return solarSystem.ErrMarketReferenceNotFound
+		}
+
+		_, err := d.conn(ctx).Exec(ctx, `
+			UPDATE solar_system_commodity_markets
+			SET deleted_at = NULL
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return solarSystem.ErrCommodityMarketConflict
+			}
+			return fmt.Errorf("error restoring commodity market: %w", err)
+		}
+
+		restoredCommodityMarket, err = d.GetCommodityMarketById(ctx, id, false)
+		return err
+	})
+	if err != nil {
+		return solarSystem.CommodityMarket{}, err
+	}
+
+	return restoredCommodityMarket, nil
+}
+
 func (d *Database) RemoveAllCommodityMarketsBySolarSystemId(ctx context.Context, solarSystemId string) error {
-	_, err := d.Pool.Exec(ctx, `
-		DELETE FROM solar_system_commodity_markets
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET deleted_at = now()
 		WHERE solar_system_id = $1
+		AND deleted_at IS NULL
 	`, solarSystemId)
 
 	if err != nil {
@@ -163,10 +458,61 @@ func (d *Database) RemoveAllCommodityMarketsBySolarSystemId(ctx context.Context,
 	return nil
 }
 
+func (d *Database) RemoveAllCommodityMarketsByStationId(ctx context.Context, stationId string) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET deleted_at = now()
+		WHERE station_id = $1
+		AND deleted_at IS NULL
+	`, stationId)
+
+	if err != nil {
+		return fmt.Errorf("error deleting all commodity markets by station id: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Database) GetDependentMarketsByCommodityId(ctx context.Context, commodityId string) ([]commodity.DependentMarket, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT market.id, market.solar_system_id, solar_system.name
+		FROM solar_system_commodity_markets market
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		WHERE market.commodity_id = $1
+		AND market.deleted_at IS NULL
+		ORDER BY solar_system.name
+	`, commodityId)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting commodity markets by commodity id: %w", err)
+	}
+
+	defer rows.Close()
+
+	markets := []commodity.DependentMarket{}
+	for rows.Next() {
+		var market commodity.DependentMarket
+		err := rows.Scan(&market.ID, &market.SolarSystemID, &market.SolarSystemName)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning commodity market row: %w", err)
+		}
+
+		markets = append(markets, market)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return markets, nil
+}
+
 func (d *Database) RemoveAllCommodityMarketsByCommodityId(ctx context.Context, commodityId string) error {
-	_, err := d.Pool.Exec(ctx, `
-		DELETE FROM solar_system_commodity_markets
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE solar_system_commodity_markets
+		SET deleted_at = now()
 		WHERE commodity_id = $1
+		AND deleted_at IS NULL
 	`, commodityId)
 
 	if err != nil {
@@ -0,0 +1,464 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CelestialBodyRow struct {
+	ID            string
+	SolarSystemID string
+	ParentID      sql.NullString
+	Name          string
+	Kind          string
+	DeletedAt     sql.NullTime
+}
+
+// fields - the scan targets of the columns every celestial body
+// query selects, in order: id, solar_system_id, parent_id, name,
+// kind, deleted_at.
+func (row *CelestialBodyRow) fields() []any {
+	return []any{&row.ID, &row.SolarSystemID, &row.ParentID, &row.Name, &row.Kind, &row.DeletedAt}
+}
+
+func convertCelestialBodyRowToCelestialBody(row CelestialBodyRow) solarSystem.CelestialBody {
+	return solarSystem.CelestialBody{
+		ID:            row.ID,
+		SolarSystemID: row.SolarSystemID,
+		ParentID:      row.ParentID.String,
+		Name:          row.Name,
+		Kind:          solarSystem.CelestialBodyKind(row.Kind),
+		DeletedAt:     nullTimeToPointer(row.DeletedAt),
+	}
+}
+
+type StationRow struct {
+	ID              string
+	SolarSystemID   string
+	CelestialBodyID sql.NullString
+	Name            string
+	Kind            string
+	DeletedAt       sql.NullTime
+}
+
+// fields - the scan targets of the columns every station query
+// selects, in order: id, solar_system_id, celestial_body_id, name,
+// kind, deleted_at.
+func (row *StationRow) fields() []any {
+	return []any{&row.ID, &row.SolarSystemID, &row.CelestialBodyID, &row.Name, &row.Kind, &row.DeletedAt}
+}
+
+func convertStationRowToStation(row StationRow) solarSystem.Station {
+	return solarSystem.Station{
+		ID:              row.ID,
+		SolarSystemID:   row.SolarSystemID,
+		CelestialBodyID: row.CelestialBodyID.String,
+		Name:            row.Name,
+		Kind:            solarSystem.StationKind(row.Kind),
+		DeletedAt:       nullTimeToPointer(row.DeletedAt),
+	}
+}
+
+func (d *Database) GetCelestialBodiesBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.CelestialBody, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, solar_system_id, parent_id, name, kind, deleted_at
+		FROM celestial_bodies
+		WHERE solar_system_id = $1
+		AND ($2::boolean OR deleted_at IS NULL)
+		ORDER BY name
+	`, solarSystemId, includeDeleted)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting celestial bodies: %w", err)
+	}
+
+	defer rows.Close()
+
+	bodies := []solarSystem.CelestialBody{}
+	for rows.Next() {
+		var row CelestialBodyRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			return nil, fmt.Errorf("error scanning celestial body row: %w", err)
+		}
+
+		bodies = append(bodies, convertCelestialBodyRowToCelestialBody(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return bodies, nil
+}
+
+func (d *Database) GetCelestialBodyById(ctx context.Context, id string, includeDeleted bool) (solarSystem.CelestialBody, error) {
+	var bodyRow CelestialBodyRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, solar_system_id, parent_id, name, kind, deleted_at
+		FROM celestial_bodies
+		WHERE id = $1
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, id, includeDeleted)
+
+	if err := row.Scan(bodyRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.CelestialBody{}, solarSystem.ErrCelestialBodyNotFound
+		}
+		return solarSystem.CelestialBody{}, fmt.Errorf("error scanning celestial body: %w", err)
+	}
+
+	return convertCelestialBodyRowToCelestialBody(bodyRow), nil
+}
+
+// CreateCelestialBody - bodies may only be added to a live solar
+// system, which the foreign key can't enforce.
+func (d *Database) CreateCelestialBody(ctx context.Context, body solarSystem.CelestialBody) (solarSystem.CelestialBody, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return solarSystem.CelestialBody{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	var bodyRow CelestialBodyRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		INSERT INTO celestial_bodies (id, solar_system_id, parent_id, name, kind)
+		SELECT $1, $2, NULLIF($3, '')::uuid, $4, $5
+		WHERE EXISTS (SELECT 1 FROM solar_systems WHERE id = $2 AND deleted_at IS NULL)
+		RETURNING id, solar_system_id, parent_id, name, kind, deleted_at
+	`, newUuid.String(), body.SolarSystemID, body.ParentID, body.Name, string(body.Kind))
+
+	if err := row.Scan(bodyRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.CelestialBody{}, solarSystem.ErrBodyReferenceNotFound
+		}
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.CelestialBody{}, solarSystem.ErrCelestialBodyConflict
+		}
+		return solarSystem.CelestialBody{}, fmt.Errorf("error creating celestial body: %w", err)
+	}
+
+	return convertCelestialBodyRowToCelestialBody(bodyRow), nil
+}
+
+// RemoveCelestialBody - soft deletes the body of the solar system,
+// unless live bodies orbit it or live stations are placed at it.
+func (d *Database) RemoveCelestialBody(ctx context.Context, solarSystemId string, id string) error {
+	var inUse bool
+	err := d.conn(ctx).QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM celestial_bodies WHERE parent_id = $1 AND deleted_at IS NULL)
+		OR EXISTS (SELECT 1 FROM stations WHERE celestial_body_id = $1 AND deleted_at IS NULL)
+	`, id).Scan(&inUse)
+	if err != nil {
+		return fmt.Errorf("error checking celestial body use: %w", err)
+	}
+
+	if inUse {
+		return solarSystem.ErrCelestialBodyInUse
+	}
+
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE celestial_bodies
+		SET deleted_at = now()
+		WHERE id = $1
+		AND solar_system_id = $2
+		AND deleted_at IS NULL
+	`, id, solarSystemId)
+	if err != nil {
+		return fmt.Errorf("error deleting celestial body: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return solarSystem.ErrCelestialBodyNotFound
+	}
+
+	return nil
+}
+
+// RestoreCelestialBody - clears the body's soft delete. A body whose
+// solar system or parent is still deleted can't be restored on its own.
+func (d *Database) RestoreCelestialBody(ctx context.Context, solarSystemId string, id string) (solarSystem.CelestialBody, error) {
+	var restoredBody solarSystem.CelestialBody
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		var referencesActive bool
+		row := d.conn(ctx).QueryRow(ctx, `
+			SELECT solar_system.deleted_at IS NULL AND parent.deleted_at IS NULL
+			FROM celestial_bodies body
+			JOIN solar_systems solar_system ON body.solar_system_id = solar_system.id
+			LEFT JOIN celestial_bodies parent ON body.parent_id = parent.id
+			WHERE body.id = $1
+			AND body.solar_system_id = $2
+		`, id, solarSystemId)
+
+		if err := row.Scan(&referencesActive); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return solarSystem.ErrCelestialBodyNotFound
+			}
+			return fmt.Errorf("error scanning celestial body: %w", err)
+		}
+
+		if !referencesActive {
+			return solarSystem.ErrBodyReferenceNotFound
+		}
+
+		_, err := d.conn(ctx).Exec(ctx, `
+			UPDATE celestial_bodies
+			SET deleted_at = NULL
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return solarSystem.ErrCelestialBodyConflict
+			}
+			return fmt.Errorf("error restoring celestial body: %w", err)
+		}
+
+		restoredBody, err = d.GetCelestialBodyById(ctx, id, false)
+		return err
+	})
+	if err != nil {
+		return solarSystem.CelestialBody{}, err
+	}
+
+	return restoredBody, nil
+}
+
+func (d *Database) RemoveAllCelestialBodiesBySolarSystemId(ctx context.Context, solarSystemId string) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE celestial_bodies
+		SET deleted_at = now()
+		WHERE solar_system_id = $1
+		AND deleted_at IS NULL
+	`, solarSystemId)
+
+	if err != nil {
+		return fmt.Errorf("error deleting all celestial bodies by solar system id: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Database) GetStationsBySolarSystemId(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.Station, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, solar_system_id, celestial_body_id, name, kind, deleted_at
+		FROM stations
+		WHERE solar_system_id = $1
+		AND ($2::boolean OR deleted_at IS NULL)
+		ORDER BY name
+	`, solarSystemId, includeDeleted)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting stations: %w", err)
+	}
+
+	defer rows.Close()
+
+	stations := []solarSystem.Station{}
+	for rows.Next() {
+		var row StationRow
+		if err := rows.Scan(row.fields()...); err != nil {
+			return nil, fmt.Errorf("error scanning station row: %w", err)
+		}
+
+		stations = append(stations, convertStationRowToStation(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return stations, nil
+}
+
+func (d *Database) GetStationById(ctx context.Context, id string, includeDeleted bool) (solarSystem.StationWithCommodityMarkets, error) {
+	var stationRow StationRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, solar_system_id, celestial_body_id, name, kind, deleted_at
+		FROM stations
+		WHERE id = $1
+		AND ($2::boolean OR deleted_at IS NULL)
+	`, id, includeDeleted)
+
+	if err := row.Scan(stationRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.StationWithCommodityMarkets{}, solarSystem.ErrStationNotFound
+		}
+		return solarSystem.StationWithCommodityMarkets{}, fmt.Errorf("error scanning station: %w", err)
+	}
+
+	commodityMarkets, err := d.getCommodityMarketsByColumn(ctx, "station_id", []string{id}, includeDeleted)
+	if err != nil {
+		return solarSystem.StationWithCommodityMarkets{}, fmt.Errorf("error getting commodity markets: %w", err)
+	}
+
+	return solarSystem.StationWithCommodityMarkets{
+		Station:          convertStationRowToStation(stationRow),
+		CommodityMarkets: commodityMarkets,
+	}, nil
+}
+
+// CreateStation - stations may only be added to a live solar system,
+// which the foreign key can't enforce.
+func (d *Database) CreateStation(ctx context.Context, station solarSystem.Station) (solarSystem.Station, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return solarSystem.Station{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	var stationRow StationRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		INSERT INTO stations (id, solar_system_id, celestial_body_id, name, kind)
+		SELECT $1, $2, NULLIF($3, '')::uuid, $4, $5
+		WHERE EXISTS (SELECT 1 FROM solar_systems WHERE id = $2 AND deleted_at IS NULL)
+		RETURNING id, solar_system_id, celestial_body_id, name, kind, deleted_at
+	`, newUuid.String(), station.SolarSystemID, station.CelestialBodyID, station.Name, string(station.Kind))
+
+	if err := row.Scan(stationRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.Station{}, solarSystem.ErrBodyReferenceNotFound
+		}
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.Station{}, solarSystem.ErrStationConflict
+		}
+		return solarSystem.Station{}, fmt.Errorf("error creating station: %w", err)
+	}
+
+	return convertStationRowToStation(stationRow), nil
+}
+
+// UpdateStation - updates the station only if it belongs to the
+// solar system, reporting it as not found otherwise.
+func (d *Database) UpdateStation(ctx context.Context, solarSystemId string, id string, update solarSystem.StationUpdate) (solarSystem.Station, error) {
+	var stationRow StationRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		UPDATE stations
+		SET name = $3, kind = $4, celestial_body_id = NULLIF($5, '')::uuid, updated_at = now()
+		WHERE id = $1
+		AND solar_system_id = $2
+		AND deleted_at IS NULL
+		RETURNING id, solar_system_id, celestial_body_id, name, kind, deleted_at
+	`, id, solarSystemId, update.Name, string(update.Kind), update.CelestialBodyID)
+
+	if err := row.Scan(stationRow.fields()...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return solarSystem.Station{}, solarSystem.ErrStationNotFound
+		}
+		if isPgError(err, uniqueViolation) {
+			return solarSystem.Station{}, solarSystem.ErrStationConflict
+		}
+		return solarSystem.Station{}, fmt.Errorf("error updating station: %w", err)
+	}
+
+	return convertStationRowToStation(stationRow), nil
+}
+
+func (d *Database) RemoveStation(ctx context.Context, solarSystemId string, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		UPDATE stations
+		SET deleted_at = now()
+		WHERE id = $1
+		AND solar_system_id = $2
+		AND deleted_at IS NULL
+	`, id, solarSystemId)
+	if err != nil {
+		return fmt.Errorf("error deleting station: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return solarSystem.ErrStationNotFound
+	}
+
+	return nil
+}
+
+// RestoreStation - clears the station's soft delete along with that of
+// the markets deleted in the same transaction, as long as their
+// commodity has not been deleted since. A station whose solar system
+// or celestial body is still deleted can't be restored on its own.
+func (d *Database) RestoreStation(ctx context.Context, solarSystemId string, id string) (solarSystem.StationWithCommodityMarkets, error) {
+	var restoredStation solarSystem.StationWithCommodityMarkets
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		var referencesActive bool
+		var deletedAt sql.NullTime
+		row := d.conn(ctx).QueryRow(ctx, `
+			SELECT solar_system.deleted_at IS NULL AND body.deleted_at IS NULL, station.deleted_at
+			FROM stations station
+			JOIN solar_systems solar_system ON station.solar_system_id = solar_system.id
+			LEFT JOIN celestial_bodies body ON station.celestial_body_id = body.id
+			WHERE station.id = $1
+			AND station.solar_system_id = $2
+		`, id, solarSystemId)
+
+		if err := row.Scan(&referencesActive, &deletedAt); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return solarSystem.ErrStationNotFound
+			}
+			return fmt.Errorf("error scanning station: %w", err)
+		}
+
+		if !deletedAt.Valid {
+			var err error
+			restoredStation, err = d.GetStationById(ctx, id, false)
+			return err
+		}
+
+		if !referencesActive {
+			return solarSystem.ErrBodyReferenceNotFound
+		}
+
+		_, err := d.conn(ctx).Exec(ctx, `
+			UPDATE stations
+			SET deleted_at = NULL
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return solarSystem.ErrStationConflict
+			}
+			return fmt.Errorf("error restoring station: %w", err)
+		}
+
+		_, err = d.conn(ctx).Exec(ctx, `
+			UPDATE solar_system_commodity_markets market
+			SET deleted_at = NULL
+			FROM commodities commodity
+			WHERE market.commodity_id = commodity.id
+			AND commodity.deleted_at IS NULL
+			AND market.station_id = $1
+			AND market.deleted_at = $2
+		`, id, deletedAt.Time)
+		if err != nil {
+			if isPgError(err, uniqueViolation) {
+				return solarSystem.ErrCommodityMarketConflict
+			}
+			return fmt.Errorf("error restoring commodity markets: %w", err)
+		}
+
+		restoredStation, err = d.GetStationById(ctx, id, false)
+		return err
+	})
+	if err != nil {
+		return solarSystem.StationWithCommodityMarkets{}, err
+	}
+
+	return restoredStation, nil
+}
+
+func (d *Database) RemoveAllStationsBySolarSystemId(ctx context.Context, solarSystemId string) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE stations
+		SET deleted_at = now()
+		WHERE solar_system_id = $1
+		AND deleted_at IS NULL
+	`, solarSystemId)
+
+	if err != nil {
+		return fmt.Errorf("error deleting all stations by solar system id: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+	"github.com/jackc/pgx/v5"
+)
+
+// The export queries hand each row to write as it is scanned rather
+// than collecting them, so exporting a large universe holds one row
+// in memory at a time. Rows without a name have no natural key to be
+// imported by, and are left out.
+
+func (d *Database) StreamCommodityRecords(ctx context.Context, write func(universe.Record) error) error {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT name, COALESCE(unit_mass, 0), COALESCE(unit_volume, 0), category, tags
+		FROM commodities
+		WHERE deleted_at IS NULL
+		AND name IS NOT NULL
+		ORDER BY name
+	`)
+	if err != nil {
+		return fmt.Errorf("error getting commodities: %w", err)
+	}
+
+	return streamRecords(rows, write, func(record *universe.Record) []any {
+		record.Kind = universe.KindCommodity
+		return []any{&record.Name, &record.UnitMass, &record.UnitVolume, &record.Category, &record.Tags}
+	})
+}
+
+func (d *Database) StreamSolarSystemRecords(ctx context.Context, write func(universe.Record) error) error {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT name, x, y, z, star_class, region, sector
+		FROM solar_systems
+		WHERE deleted_at IS NULL
+		AND name IS NOT NULL
+		ORDER BY name
+	`)
+	if err != nil {
+		return fmt.Errorf("error getting solar systems: %w", err)
+	}
+
+	return streamRecords(rows, write, func(record *universe.Record) []any {
+		record.Kind = universe.KindSolarSystem
+		return []any{&record.Name, &record.Coordinates.X, &record.Coordinates.Y, &record.Coordinates.Z, &record.StarClass, &record.Region, &record.Sector}
+	})
+}
+
+func (d *Database) StreamMarketRecords(ctx context.Context, write func(universe.Record) error) error {
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT solar_system.name, commodity.name, COALESCE(market.base_price, 0), COALESCE(market.demand_quantity, 0)
+		FROM solar_system_commodity_markets market
+		JOIN solar_systems solar_system ON market.solar_system_id = solar_system.id
+		JOIN commodities commodity ON market.commodity_id = commodity.id
+		WHERE market.deleted_at IS NULL
+		AND market.station_id IS NULL
+		AND solar_system.deleted_at IS NULL
+		AND commodity.deleted_at IS NULL
+		AND solar_system.name IS NOT NULL
+		AND commodity.name IS NOT NULL
+		ORDER BY solar_system.name, commodity.name
+	`)
+	if err != nil {
+		return fmt.Errorf("error getting commodity markets: %w", err)
+	}
+
+	return streamRecords(rows, write, func(record *universe.Record) []any {
+		record.Kind = universe.KindMarket
+		return []any{&record.SolarSystem, &record.Commodity, &record.BasePrice, &record.DemandQuantity}
+	})
+}
+
+// streamRecords - scans each row into a fresh record through the
+// destinations fields returns, and hands it to write.
+func streamRecords(rows pgx.Rows, write func(universe.Record) error, fields func(*universe.Record) []any) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var record universe.Record
+		if err := rows.Scan(fields(&record)...); err != nil {
+			return fmt.Errorf("error scanning record: %w", err)
+		}
+
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return nil
+}
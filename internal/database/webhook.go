@@ -0,0 +1,379 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type WebhookRow struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+func convertWebhookRowToWebhook(row WebhookRow) webhook.Webhook {
+	eventTypes := []events.Type{}
+	for _, eventType := range row.EventTypes {
+		eventTypes = append(eventTypes, events.Type(eventType))
+	}
+
+	return webhook.Webhook{
+		ID:         row.ID,
+		URL:        row.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+type WebhookDeliveryRow struct {
+	ID                 string
+	WebhookID          string
+	EventID            int64
+	EventType          string
+	Status             string
+	Attempts           int
+	LastError          sql.NullString
+	LastResponseStatus sql.NullInt32
+	NextAttemptAt      sql.NullTime
+	DeliveredAt        sql.NullTime
+	CreatedAt          time.Time
+}
+
+func convertWebhookDeliveryRowToDelivery(row WebhookDeliveryRow) webhook.Delivery {
+	return webhook.Delivery{
+		ID:                 row.ID,
+		WebhookID:          row.WebhookID,
+		EventID:            row.EventID,
+		EventType:          events.Type(row.EventType),
+		Status:             webhook.DeliveryStatus(row.Status),
+		Attempts:           row.Attempts,
+		LastError:          row.LastError.String,
+		LastResponseStatus: int(row.LastResponseStatus.Int32),
+		NextAttemptAt:      nullTimeToPointer(row.NextAttemptAt),
+		DeliveredAt:        nullTimeToPointer(row.DeliveredAt),
+		CreatedAt:          row.CreatedAt,
+	}
+}
+
+func (d *Database) GetWebhookById(ctx context.Context, id string) (webhook.Webhook, error) {
+	var webhookRow WebhookRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT id, url, event_types, created_at
+		FROM webhooks
+		WHERE id = $1
+	`, id)
+
+	err := row.Scan(&webhookRow.ID, &webhookRow.URL, &webhookRow.EventTypes, &webhookRow.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhook.Webhook{}, webhook.ErrWebhookNotFound
+		}
+		return webhook.Webhook{}, fmt.Errorf("error scanning webhook: %w", err)
+	}
+
+	return convertWebhookRowToWebhook(webhookRow), nil
+}
+
+func (d *Database) GetWebhooksByPagination(ctx context.Context, pagination data.Pagination) ([]webhook.Webhook, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "url",
+			FormattedFieldName: "url",
+		},
+		{
+			FieldName:          "createdat",
+			FormattedFieldName: "created_at",
+		},
+	}, "created_at")
+	direction := pagination.GetOrderByDirection()
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT id, url, event_types, created_at
+		FROM webhooks
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhooks by pagination: %w", err)
+	}
+
+	defer rows.Close()
+
+	webhooks := []webhook.Webhook{}
+	for rows.Next() {
+		var row WebhookRow
+		err := rows.Scan(&row.ID, &row.URL, &row.EventTypes, &row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook row: %w", err)
+		}
+
+		webhooks = append(webhooks, convertWebhookRowToWebhook(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (d *Database) CreateWebhook(ctx context.Context, newWebhook webhook.Webhook, secret string) (webhook.Webhook, error) {
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return webhook.Webhook{}, fmt.Errorf("error generating uuid: %w", err)
+	}
+
+	eventTypes := []string{}
+	for _, eventType := range newWebhook.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	_, err = d.conn(ctx).Exec(ctx, `
+		INSERT INTO webhooks (id, url, secret, event_types)
+		VALUES ($1, $2, $3, $4)
+	`, newUuid.String(), newWebhook.URL, secret, eventTypes)
+
+	if err != nil {
+		return webhook.Webhook{}, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return d.GetWebhookById(ctx, newUuid.String())
+}
+
+// RemoveWebhook - deletes the webhook along with its delivery history.
+func (d *Database) RemoveWebhook(ctx context.Context, id string) error {
+	result, err := d.conn(ctx).Exec(ctx, `
+		DELETE FROM webhooks
+		WHERE id = $1
+	`, id)
+
+	if err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (d *Database) GetDeliveriesByWebhookId(ctx context.Context, webhookId string, filter webhook.DeliveryFilter, pagination data.Pagination) ([]webhook.Delivery, error) {
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+	orderBy := pagination.GetOrderByField([]data.AllowedField{
+		{
+			FieldName:          "createdat",
+			FormattedFieldName: "delivery.created_at",
+		},
+		{
+			FieldName:          "attempts",
+			FormattedFieldName: "delivery.attempts",
+		},
+	}, "delivery.created_at")
+
+	// the most recent deliveries are the interesting ones
+	direction := "desc"
+	if pagination.OrderBy != "" {
+		direction = pagination.GetOrderByDirection()
+	}
+
+	rows, err := d.conn(ctx).Query(ctx, `
+		SELECT delivery.id, delivery.webhook_id, delivery.event_id, event.type, delivery.status, delivery.attempts,
+			delivery.last_error, delivery.last_response_status, delivery.next_attempt_at, delivery.delivered_at, delivery.created_at
+		FROM webhook_deliveries delivery
+		JOIN outbox_events event ON delivery.event_id = event.id
+		WHERE delivery.webhook_id = $3
+		AND ($4 = '' OR delivery.status = $4)
+		ORDER BY `+orderBy+` `+direction+`
+		LIMIT $1
+		OFFSET $2
+	`, limit, offset, webhookId, string(filter.Status))
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook deliveries: %w", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := []webhook.Delivery{}
+	for rows.Next() {
+		var row WebhookDeliveryRow
+		err := rows.Scan(&row.ID, &row.WebhookID, &row.EventID, &row.EventType, &row.Status, &row.Attempts,
+			&row.LastError, &row.LastResponseStatus, &row.NextAttemptAt, &row.DeliveredAt, &row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery row: %w", err)
+		}
+
+		deliveries = append(deliveries, convertWebhookDeliveryRowToDelivery(row))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (d *Database) GetDeliveryById(ctx context.Context, webhookId string, deliveryId string) (webhook.Delivery, error) {
+	var deliveryRow WebhookDeliveryRow
+	row := d.conn(ctx).QueryRow(ctx, `
+		SELECT delivery.id, delivery.webhook_id, delivery.event_id, event.type, delivery.status, delivery.attempts,
+			delivery.last_error, delivery.last_response_status, delivery.next_attempt_at, delivery.delivered_at, delivery.created_at
+		FROM webhook_deliveries delivery
+		JOIN outbox_events event ON delivery.event_id = event.id
+		WHERE delivery.id = $1
+		AND delivery.webhook_id = $2
+	`, deliveryId, webhookId)
+
+	err := row.Scan(&deliveryRow.ID, &deliveryRow.WebhookID, &deliveryRow.EventID, &deliveryRow.EventType, &deliveryRow.Status, &deliveryRow.Attempts,
+		&deliveryRow.LastError, &deliveryRow.LastResponseStatus, &deliveryRow.NextAttemptAt, &deliveryRow.DeliveredAt, &deliveryRow.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webhook.Delivery{}, webhook.ErrDeliveryNotFound
+		}
+		return webhook.Delivery{}, fmt.Errorf("error scanning webhook delivery: %w", err)
+	}
+
+	return convertWebhookDeliveryRowToDelivery(deliveryRow), nil
+}
+
+// RetryDelivery - resets a dead delivery to pending with no attempts,
+// due immediately.
+func (d *Database) RetryDelivery(ctx context.Context, webhookId string, deliveryId string) (webhook.Delivery, error) {
+	var retriedDelivery webhook.Delivery
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		delivery, err := d.GetDeliveryById(ctx, webhookId, deliveryId)
+		if err != nil {
+			return err
+		}
+
+		if delivery.Status != webhook.DeliveryStatusDead {
+			return fmt.Errorf("%w: delivery is %s", webhook.ErrDeliveryNotDead, delivery.Status)
+		}
+
+		result, err := d.conn(ctx).Exec(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'pending', attempts = 0, next_attempt_at = now()
+			WHERE id = $1
+			AND status = 'dead'
+		`, deliveryId)
+		if err != nil {
+			return fmt.Errorf("error retrying webhook delivery: %w", err)
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("%w: delivery was retried concurrently", webhook.ErrDeliveryNotDead)
+		}
+
+		retriedDelivery, err = d.GetDeliveryById(ctx, webhookId, deliveryId)
+		return err
+	})
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return retriedDelivery, nil
+}
+
+// ClaimDueDeliveries - returns pending deliveries whose next attempt is
+// due, pushing that attempt back by the lease so that no other dispatcher
+// claims them while they are being sent.
+func (d *Database) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhook.PendingDelivery, error) {
+	rows, err := d.conn(ctx).Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending'
+			AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries delivery
+		SET next_attempt_at = now() + make_interval(secs => $2)
+		FROM due, webhooks webhook, outbox_events event
+		WHERE delivery.id = due.id
+		AND webhook.id = delivery.webhook_id
+		AND event.id = delivery.event_id
+		RETURNING delivery.id, delivery.webhook_id, delivery.status, delivery.attempts, delivery.created_at,
+			webhook.url, webhook.secret, event.id, event.type, event.entity_id, event.topics, event.data, event.created_at
+	`, limit, lease.Seconds())
+
+	if err != nil {
+		return nil, fmt.Errorf("error claiming webhook deliveries: %w", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := []webhook.PendingDelivery{}
+	for rows.Next() {
+		var row WebhookDeliveryRow
+		var delivery webhook.PendingDelivery
+		var entityID sql.NullString
+		err := rows.Scan(&row.ID, &row.WebhookID, &row.Status, &row.Attempts, &row.CreatedAt,
+			&delivery.URL, &delivery.Secret, &row.EventID, &row.EventType, &entityID, &delivery.Event.Topics, &delivery.Event.Data, &delivery.Event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery row: %w", err)
+		}
+
+		delivery.Delivery = convertWebhookDeliveryRowToDelivery(row)
+		delivery.Event.ID = row.EventID
+		delivery.Event.Type = events.Type(row.EventType)
+		delivery.Event.EntityID = entityID.String
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (d *Database) CompleteDelivery(ctx context.Context, id string, responseStatus int) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_response_status = $2, last_error = NULL,
+			next_attempt_at = NULL, delivered_at = now()
+		WHERE id = $1
+	`, id, responseStatus)
+
+	if err != nil {
+		return fmt.Errorf("error completing webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FailDelivery - records a failed attempt. The delivery is retried at
+// nextAttemptAt, or marked dead when nextAttemptAt is nil.
+func (d *Database) FailDelivery(ctx context.Context, id string, responseStatus int, lastError string, nextAttemptAt *time.Time) error {
+	_, err := d.conn(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, last_response_status = NULLIF($2, 0), last_error = $3,
+			status = CASE WHEN $4::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			next_attempt_at = $4
+		WHERE id = $1
+	`, id, responseStatus, lastError, nextAttemptAt)
+
+	if err != nil {
+		return fmt.Errorf("error failing webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+const (
+	DefaultArrivalInterval  = 5 * time.Second
+	DefaultArrivalBatchSize = 500
+)
+
+type ArrivalCompleter interface {
+	CompleteArrivals(context.Context, int) (int, error)
+}
+
+// ArrivalScheduler - docks the ships whose journeys are over in their
+// destinations, acting as the system principal.
+type ArrivalScheduler struct {
+	Completer ArrivalCompleter
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewArrivalSchedulerFromEnv - reads the run interval and the most
+// arrivals completed at a time from SHIP_ARRIVAL_INTERVAL and
+// SHIP_ARRIVAL_BATCH_SIZE, e.g. "5s" and "500". A ship arrives up to
+// an interval after its arrival time.
+func NewArrivalSchedulerFromEnv(completer ArrivalCompleter) (*ArrivalScheduler, error) {
+	scheduler := &ArrivalScheduler{
+		Completer: completer,
+		Interval:  DefaultArrivalInterval,
+		BatchSize: DefaultArrivalBatchSize,
+	}
+
+	if value := os.Getenv("SHIP_ARRIVAL_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("error parsing SHIP_ARRIVAL_INTERVAL: must be a positive duration")
+		}
+		scheduler.Interval = interval
+	}
+
+	if value := os.Getenv("SHIP_ARRIVAL_BATCH_SIZE"); value != "" {
+		batchSize, err := strconv.Atoi(value)
+		if err != nil || batchSize < 1 {
+			return nil, fmt.Errorf("error parsing SHIP_ARRIVAL_BATCH_SIZE: must be a positive integer")
+		}
+		scheduler.BatchSize = batchSize
+	}
+
+	return scheduler, nil
+}
+
+func (a *ArrivalScheduler) Run(ctx context.Context) error {
+	arrived, err := a.Completer.CompleteArrivals(auth.WithPrincipal(ctx, auth.SystemPrincipal), a.BatchSize)
+	if err != nil {
+		return fmt.Errorf("error completing ship arrivals: %w", err)
+	}
+
+	if arrived > 0 {
+		log.Printf("%d ships arrived", arrived)
+	}
+
+	return nil
+}
+
+func (a *ArrivalScheduler) Start(ctx context.Context) {
+	RunPeriodically(ctx, "arrival", a.Interval, a.Run)
+}
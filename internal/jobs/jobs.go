@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunPeriodically - calls fn once every interval until the context
+// is cancelled. Errors are logged rather than stopping the job, so
+// a transient failure is simply retried on the next tick.
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, fn func(context.Context) error) {
+	log.Printf("Starting job %s, running every %s", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			log.Printf("Error running job %s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Stopping job %s", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
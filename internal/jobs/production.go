@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+)
+
+const (
+	DefaultProductionInterval  = 10 * time.Second
+	DefaultProductionBatchSize = 500
+)
+
+type ProductionRunner interface {
+	RunDueCycles(context.Context, int) (production.CycleResult, error)
+}
+
+// ProductionScheduler - runs the cycles of the facilities that are due,
+// acting as the system principal.
+type ProductionScheduler struct {
+	Runner    ProductionRunner
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewProductionSchedulerFromEnv - reads the run interval and the most
+// cycles run at a time from PRODUCTION_INTERVAL and
+// PRODUCTION_BATCH_SIZE, e.g. "10s" and "500".
+func NewProductionSchedulerFromEnv(runner ProductionRunner) (*ProductionScheduler, error) {
+	scheduler := &ProductionScheduler{
+		Runner:    runner,
+		Interval:  DefaultProductionInterval,
+		BatchSize: DefaultProductionBatchSize,
+	}
+
+	if value := os.Getenv("PRODUCTION_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("error parsing PRODUCTION_INTERVAL: must be a positive duration")
+		}
+		scheduler.Interval = interval
+	}
+
+	if value := os.Getenv("PRODUCTION_BATCH_SIZE"); value != "" {
+		batchSize, err := strconv.Atoi(value)
+		if err != nil || batchSize < 1 {
+			return nil, fmt.Errorf("error parsing PRODUCTION_BATCH_SIZE: must be a positive integer")
+		}
+		scheduler.BatchSize = batchSize
+	}
+
+	return scheduler, nil
+}
+
+func (p *ProductionScheduler) Run(ctx context.Context) error {
+	result, err := p.Runner.RunDueCycles(auth.WithPrincipal(ctx, auth.SystemPrincipal), p.BatchSize)
+	if err != nil {
+		return fmt.Errorf("error running production cycles: %w", err)
+	}
+
+	if result.Produced+result.Stalled > 0 {
+		log.Printf("Ran %d production cycles, %d facilities stalled", result.Produced, result.Stalled)
+	}
+
+	return nil
+}
+
+func (p *ProductionScheduler) Start(ctx context.Context) {
+	RunPeriodically(ctx, "production", p.Interval, p.Run)
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+	DefaultPurgeInterval  = time.Hour
+)
+
+type PurgeResult struct {
+	Commodities      int64
+	SolarSystems     int64
+	CommodityMarkets int64
+	Stations         int64
+	CelestialBodies  int64
+}
+
+type PurgeStore interface {
+	PurgeDeleted(context.Context, time.Time) (PurgeResult, error)
+}
+
+// Purger - hard deletes rows that were soft deleted longer
+// ago than the retention window.
+type Purger struct {
+	Store     PurgeStore
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+// NewPurgerFromEnv - reads the retention window and run interval
+// from PURGE_RETENTION and PURGE_INTERVAL, e.g. "720h" and "1h".
+func NewPurgerFromEnv(store PurgeStore) (*Purger, error) {
+	purger := &Purger{
+		Store:     store,
+		Retention: DefaultPurgeRetention,
+		Interval:  DefaultPurgeInterval,
+	}
+
+	if value := os.Getenv("PURGE_RETENTION"); value != "" {
+		retention, err := time.ParseDuration(value)
+		if err != nil || retention <= 0 {
+			return nil, fmt.Errorf("error parsing PURGE_RETENTION: must be a positive duration")
+		}
+		purger.Retention = retention
+	}
+
+	if value := os.Getenv("PURGE_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("error parsing PURGE_INTERVAL: must be a positive duration")
+		}
+		purger.Interval = interval
+	}
+
+	return purger, nil
+}
+
+func (p *Purger) Run(ctx context.Context) error {
+	result, err := p.Store.PurgeDeleted(ctx, time.Now().Add(-p.Retention))
+	if err != nil {
+		return fmt.Errorf("error purging deleted rows: %w", err)
+	}
+
+	if result.Commodities+result.SolarSystems+result.CommodityMarkets+result.Stations+result.CelestialBodies > 0 {
+		log.Printf("Purged %d commodities, %d solar systems, %d commodity markets, %d stations and %d celestial bodies",
+			result.Commodities, result.SolarSystems, result.CommodityMarkets, result.Stations, result.CelestialBodies)
+	}
+
+	return nil
+}
+
+func (p *Purger) Start(ctx context.Context) {
+	RunPeriodically(ctx, "purge", p.Interval, p.Run)
+}
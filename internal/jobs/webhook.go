@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
+)
+
+const (
+	DefaultWebhookInterval    = 5 * time.Second
+	DefaultWebhookTimeout     = 10 * time.Second
+	DefaultWebhookMaxAttempts = 8
+	DefaultWebhookBatchSize   = 100
+	DefaultWebhookBaseBackoff = 30 * time.Second
+	DefaultWebhookMaxBackoff  = 6 * time.Hour
+)
+
+type WebhookStore interface {
+	FanOutOutboxEvents(context.Context, int) (int64, error)
+	ClaimDueDeliveries(context.Context, int, time.Duration) ([]webhook.PendingDelivery, error)
+	CompleteDelivery(context.Context, string, int) error
+	FailDelivery(context.Context, string, int, string, *time.Time) error
+}
+
+// WebhookDispatcher - turns outbox events into deliveries for the
+// webhooks subscribed to them and sends the deliveries that are due,
+// backing off exponentially between failed attempts.
+type WebhookDispatcher struct {
+	Store       WebhookStore
+	Client      *http.Client
+	Interval    time.Duration
+	MaxAttempts int
+	BatchSize   int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// NewWebhookDispatcherFromEnv - reads the run interval, request timeout
+// and attempt limit from WEBHOOK_INTERVAL, WEBHOOK_TIMEOUT and
+// WEBHOOK_MAX_ATTEMPTS, e.g. "5s", "10s" and "8".
+func NewWebhookDispatcherFromEnv(store WebhookStore) (*WebhookDispatcher, error) {
+	dispatcher := &WebhookDispatcher{
+		Store:       store,
+		Client:      &http.Client{Timeout: DefaultWebhookTimeout},
+		Interval:    DefaultWebhookInterval,
+		MaxAttempts: DefaultWebhookMaxAttempts,
+		BatchSize:   DefaultWebhookBatchSize,
+		BaseBackoff: DefaultWebhookBaseBackoff,
+		MaxBackoff:  DefaultWebhookMaxBackoff,
+	}
+
+	if value := os.Getenv("WEBHOOK_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("error parsing WEBHOOK_INTERVAL: must be a positive duration")
+		}
+		dispatcher.Interval = interval
+	}
+
+	if value := os.Getenv("WEBHOOK_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("error parsing WEBHOOK_TIMEOUT: must be a positive duration")
+		}
+		dispatcher.Client.Timeout = timeout
+	}
+
+	if value := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil || maxAttempts < 1 {
+			return nil, fmt.Errorf("error parsing WEBHOOK_MAX_ATTEMPTS: must be a positive integer")
+		}
+		dispatcher.MaxAttempts = maxAttempts
+	}
+
+	return dispatcher, nil
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) error {
+	if _, err := d.Store.FanOutOutboxEvents(ctx, d.BatchSize); err != nil {
+		return err
+	}
+
+	// a claimed delivery isn't due again until every request in
+	// the batch could have timed out
+	lease := d.Client.Timeout*time.Duration(d.BatchSize) + d.Interval
+	deliveries, err := d.Store.ClaimDueDeliveries(ctx, d.BatchSize, lease)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	RunPeriodically(ctx, "webhooks", d.Interval, d.Run)
+}
+
+// deliver - sends one delivery and records the outcome. Only failing
+// to record the outcome is returned as an error.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery webhook.PendingDelivery) error {
+	responseStatus, err := d.send(ctx, delivery)
+	if err == nil {
+		return d.Store.CompleteDelivery(ctx, delivery.ID, responseStatus)
+	}
+
+	attempts := delivery.Attempts + 1
+	var nextAttemptAt *time.Time
+	if attempts < d.MaxAttempts {
+		next := time.Now().Add(d.backoff(attempts))
+		nextAttemptAt = &next
+	} else {
+		log.Printf("Webhook delivery %s is dead after %d attempts: %v", delivery.ID, attempts, err)
+	}
+
+	return d.Store.FailDelivery(ctx, delivery.ID, responseStatus, err.Error(), nextAttemptAt)
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery webhook.PendingDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding event: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.DeliveryHeader, delivery.ID)
+	request.Header.Set(webhook.EventHeader, string(delivery.Event.Type))
+	request.Header.Set(webhook.TimestampHeader, timestamp)
+	request.Header.Set(webhook.SignatureHeader, webhook.Sign(delivery.Secret, timestamp, body))
+
+	response, err := d.Client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// backoff - doubles the wait after each failed attempt, up to MaxBackoff.
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	wait := d.BaseBackoff
+	for i := 1; i < attempts && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	return min(wait, d.MaxBackoff)
+}
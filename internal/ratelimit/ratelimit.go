@@ -0,0 +1,227 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidLimit = errors.New("invalid rate limit")
+)
+
+// Limit - a token bucket refilled at Rate tokens per
+// second, holding at most Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result - the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// Store - holds the token buckets. The in-process MemoryStore
+// is used by default; a shared backend can be plugged in by
+// implementing this interface so limits hold across instances.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Group - a set of routes sharing a limit, matched by path prefix.
+// Every API version of a route belongs to the same group, so moving
+// between versions doesn't grant a client a fresh bucket.
+type Group struct {
+	Name         string
+	PathPrefixes []string
+	Limit        Limit
+}
+
+type Config struct {
+	Default Limit
+	Groups  []Group
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Default: Limit{Rate: 10, Burst: 20},
+		Groups: []Group{
+			{Name: "auth", PathPrefixes: []string{"/api/v1/auth", "/api/v2/auth"}, Limit: Limit{Rate: 1, Burst: 5}},
+			{Name: "commodities", PathPrefixes: []string{"/api/v1/commodities", "/api/v2/commodities"}, Limit: Limit{Rate: 10, Burst: 20}},
+			{Name: "solarSystems", PathPrefixes: []string{"/api/v1/solarSystems", "/api/v2/solarSystems"}, Limit: Limit{Rate: 10, Burst: 20}},
+			{Name: "commodityMarkets", PathPrefixes: []string{"/api/v1/commodityMarkets", "/api/v2/commodityMarkets"}, Limit: Limit{Rate: 10, Burst: 20}},
+			// imports and exports read or write the whole universe
+			{Name: "universe", PathPrefixes: []string{"/api/v1/import", "/api/v2/import", "/api/v1/export", "/api/v2/export", "/api/v1/admin/generate", "/api/v2/admin/generate"}, Limit: Limit{Rate: 0.1, Burst: 2}},
+			// a single query can touch every table, so it is limited harder
+			{Name: "graphql", PathPrefixes: []string{"/graphql"}, Limit: Limit{Rate: 5, Burst: 10}},
+		},
+	}
+}
+
+// ConfigFromEnv - overrides the default limits with the
+// RATE_LIMIT_DEFAULT and RATE_LIMIT_<GROUP> environment
+// variables, each written as "rate:burst", e.g. "5:10".
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("RATE_LIMIT_DEFAULT"); value != "" {
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("error parsing RATE_LIMIT_DEFAULT: %w", err)
+		}
+		config.Default = limit
+	}
+
+	for i, group := range config.Groups {
+		name := "RATE_LIMIT_" + strings.ToUpper(group.Name)
+		if value := os.Getenv(name); value != "" {
+			limit, err := ParseLimit(value)
+			if err != nil {
+				return Config{}, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			config.Groups[i].Limit = limit
+		}
+	}
+
+	return config, nil
+}
+
+func ParseLimit(value string) (Limit, error) {
+	rateValue, burstValue, found := strings.Cut(value, ":")
+	if !found {
+		return Limit{}, fmt.Errorf("%w: %q is not rate:burst", ErrInvalidLimit, value)
+	}
+
+	rate, err := strconv.ParseFloat(rateValue, 64)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("%w: rate %q", ErrInvalidLimit, rateValue)
+	}
+
+	burst, err := strconv.Atoi(burstValue)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("%w: burst %q", ErrInvalidLimit, burstValue)
+	}
+
+	return Limit{Rate: rate, Burst: burst}, nil
+}
+
+// Limiter - resolves the group for a request path and takes
+// a token from the caller's bucket for that group.
+type Limiter struct {
+	Store  Store
+	Config Config
+}
+
+func NewLimiter(store Store, config Config) *Limiter {
+	return &Limiter{
+		Store:  store,
+		Config: config,
+	}
+}
+
+func (l *Limiter) Allow(ctx context.Context, clientKey string, path string) (Result, error) {
+	name, limit := l.groupFor(path)
+	return l.Store.Take(ctx, name+":"+clientKey, limit)
+}
+
+func (l *Limiter) groupFor(path string) (string, Limit) {
+	for _, group := range l.Config.Groups {
+		for _, prefix := range group.PathPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				return group.Name, group.Limit
+			}
+		}
+	}
+
+	return "default", l.Config.Default
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// MemoryStore - keeps buckets in process memory, dropping
+// buckets that have refilled completely to bound its size.
+type MemoryStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastSweepAt time.Time
+	now         func() time.Time
+}
+
+const sweepInterval = time.Minute
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (m *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return Result{}, ErrInvalidLimit
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now, limit: limit}
+		m.buckets[key] = b
+	}
+
+	b.limit = limit
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.updatedAt = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.ResetAfter = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return result, nil
+}
+
+// sweep - removes buckets that would be full by now, as they
+// are indistinguishable from a bucket that was never created.
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweepAt) < sweepInterval {
+		return
+	}
+	m.lastSweepAt = now
+
+	for key, b := range m.buckets {
+		if b.tokens+now.Sub(b.updatedAt).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
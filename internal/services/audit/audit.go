@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid audit filter")
+)
+
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+const (
+	EntityCommodity       = "commodity"
+	EntitySolarSystem     = "solarSystem"
+	EntityCommodityMarket = "commodityMarket"
+	EntityCelestialBody   = "celestialBody"
+	EntityStation         = "station"
+	EntityLegalityRule    = "legalityRule"
+	EntityRecipe          = "recipe"
+	EntityFacility        = "facility"
+	EntityShip            = "ship"
+)
+
+// Entry - a single recorded mutation. Before is empty for
+// creations and After is empty for deletions.
+type Entry struct {
+	ID         string
+	ActorID    string
+	ActorName  string
+	Action     Action
+	EntityType string
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
+
+// Filter - narrows the entries returned by a query. Empty
+// fields and nil times match every entry.
+type Filter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	From       *time.Time
+	To         *time.Time
+}
+
+type Store interface {
+	CreateAuditEntry(context.Context, Entry) error
+	GetAuditEntriesByPagination(context.Context, Filter, data.Pagination) ([]Entry, error)
+}
+
+type Service struct {
+	Store Store
+}
+
+func NewService(store Store) *Service {
+	return &Service{Store: store}
+}
+
+// Record - stores an entry attributed to the principal on the
+// context. Called inside a store transaction, the entry is only
+// kept if the mutation it describes is committed.
+func (s *Service) Record(ctx context.Context, action Action, entityType string, entityID string, before any, after any) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return auth.ErrUnauthenticated
+	}
+
+	beforeJson, err := marshalSnapshot(before)
+	if err != nil {
+		return fmt.Errorf("error encoding before snapshot: %w", err)
+	}
+
+	afterJson, err := marshalSnapshot(after)
+	if err != nil {
+		return fmt.Errorf("error encoding after snapshot: %w", err)
+	}
+
+	err = s.Store.CreateAuditEntry(ctx, Entry{
+		ActorID:    principal.ID,
+		ActorName:  principal.Name,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeJson,
+		After:      afterJson,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) FindEntries(ctx context.Context, filter Filter, pagination data.Pagination) ([]Entry, error) {
+	if err := auth.Authorize(ctx, auth.ResourceAudit, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, fmt.Errorf("%w: from is after to", ErrInvalidFilter)
+	}
+
+	entries, err := s.Store.GetAuditEntriesByPagination(ctx, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("error getting audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func marshalSnapshot(snapshot any) (json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(snapshot)
+}
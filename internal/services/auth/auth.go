@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrApiKeyNotFound     = errors.New("api key not found")
+	ErrJwtNotConfigured   = errors.New("jwt authentication is not configured")
+)
+
+const (
+	MethodApiKey       = "api_key"
+	MethodJwt          = "jwt"
+	MethodBootstrapKey = "bootstrap_key"
+	MethodSystem       = "system"
+
+	ApiKeyPrefix = "ssk_"
+)
+
+// Principal - the authenticated caller of a request,
+// available to every layer through the context.
+type Principal struct {
+	ID     string
+	Name   string
+	Method string
+	Role   Role
+}
+
+type ApiKey struct {
+	ID        string
+	Name      string
+	Prefix    string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// CreatedApiKey - is only ever returned once, when the
+// key is created, as the plain text key is never stored.
+type CreatedApiKey struct {
+	ApiKey
+	Key string
+}
+
+// Config - holds the secrets used to verify credentials.
+// Any of the fields may be left empty to disable that method.
+type Config struct {
+	JwtHS256Secret    []byte
+	JwtRS256PublicKey *rsa.PublicKey
+	BootstrapApiKey   string
+}
+
+// ConfigFromEnv - builds the auth configuration from the
+// JWT_HS256_SECRET, JWT_RS256_PUBLIC_KEY and
+// AUTH_BOOTSTRAP_API_KEY environment variables.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		JwtHS256Secret:  []byte(os.Getenv("JWT_HS256_SECRET")),
+		BootstrapApiKey: os.Getenv("AUTH_BOOTSTRAP_API_KEY"),
+	}
+
+	if publicKey := os.Getenv("JWT_RS256_PUBLIC_KEY"); publicKey != "" {
+		key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(publicKey))
+		if err != nil {
+			return Config{}, fmt.Errorf("error parsing JWT_RS256_PUBLIC_KEY: %w", err)
+		}
+		config.JwtRS256PublicKey = key
+	}
+
+	return config, nil
+}
+
+type Store interface {
+	GetApiKeyByHash(context.Context, string) (ApiKey, error)
+	GetApiKeysByPagination(context.Context, data.Pagination) ([]ApiKey, error)
+	CreateApiKey(context.Context, ApiKey, string) (ApiKey, error)
+	RemoveApiKey(context.Context, string) error
+}
+
+type Service struct {
+	Store  Store
+	Config Config
+}
+
+func NewService(store Store, config Config) *Service {
+	return &Service{
+		Store:  store,
+		Config: config,
+	}
+}
+
+type principalKey struct{}
+
+// WithPrincipal - returns a copy of the context carrying the principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext - returns the principal acting on the request, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+func (s *Service) AuthenticateApiKey(ctx context.Context, key string) (Principal, error) {
+	if key == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if s.Config.BootstrapApiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(s.Config.BootstrapApiKey)) == 1 {
+		return Principal{
+			ID:     "bootstrap",
+			Name:   "bootstrap",
+			Method: MethodBootstrapKey,
+			Role:   RoleAdmin,
+		}, nil
+	}
+
+	apiKey, err := s.Store.GetApiKeyByHash(ctx, hashApiKey(key))
+	if err != nil {
+		if errors.Is(err, ErrApiKeyNotFound) {
+			return Principal{}, ErrInvalidCredentials
+		}
+		return Principal{}, fmt.Errorf("error getting api key: %w", err)
+	}
+
+	return Principal{
+		ID:     apiKey.ID,
+		Name:   apiKey.Name,
+		Method: MethodApiKey,
+		Role:   apiKey.Role,
+	}, nil
+}
+
+func (s *Service) AuthenticateBearerToken(ctx context.Context, token string) (Principal, error) {
+	if len(s.Config.JwtHS256Secret) == 0 && s.Config.JwtRS256PublicKey == nil {
+		return Principal{}, ErrJwtNotConfigured
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.jwtKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Principal{}, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+
+	name, _ := claims["name"].(string)
+	if name == "" {
+		name = subject
+	}
+
+	// tokens without a role claim are still valid, but may only read
+	role := RoleReadOnly
+	if claimedRole, ok := claims["role"].(string); ok && claimedRole != "" {
+		role, err = ParseRole(claimedRole)
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+		}
+	}
+
+	return Principal{
+		ID:     subject,
+		Name:   name,
+		Method: MethodJwt,
+		Role:   role,
+	}, nil
+}
+
+// jwtKey - picks the verification key matching the token's
+// signing method, refusing methods that are not configured.
+func (s *Service) jwtKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(s.Config.JwtHS256Secret) == 0 {
+			return nil, fmt.Errorf("HS256 tokens are not accepted")
+		}
+		return s.Config.JwtHS256Secret, nil
+	case *jwt.SigningMethodRSA:
+		if s.Config.JwtRS256PublicKey == nil {
+			return nil, fmt.Errorf("RS256 tokens are not accepted")
+		}
+		return s.Config.JwtRS256PublicKey, nil
+	}
+
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+func (s *Service) FindAllApiKeys(ctx context.Context, pagination data.Pagination) ([]ApiKey, error) {
+	if err := Authorize(ctx, ResourceApiKey, OperationRead); err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := s.Store.GetApiKeysByPagination(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api keys by pagination: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (s *Service) CreateApiKey(ctx context.Context, name string, role Role) (CreatedApiKey, error) {
+	if err := Authorize(ctx, ResourceApiKey, OperationCreate); err != nil {
+		return CreatedApiKey{}, err
+	}
+
+	if _, err := ParseRole(string(role)); err != nil {
+		return CreatedApiKey{}, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return CreatedApiKey{}, fmt.Errorf("error generating api key: %w", err)
+	}
+
+	key := ApiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey, err := s.Store.CreateApiKey(ctx, ApiKey{
+		Name:   name,
+		Prefix: key[:len(ApiKeyPrefix)+6],
+		Role:   role,
+	}, hashApiKey(key))
+	if err != nil {
+		return CreatedApiKey{}, fmt.Errorf("error creating api key: %w", err)
+	}
+
+	return CreatedApiKey{
+		ApiKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *Service) RemoveApiKey(ctx context.Context, id string) error {
+	if err := Authorize(ctx, ResourceApiKey, OperationDelete); err != nil {
+		return err
+	}
+
+	err := s.Store.RemoveApiKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error removing api key: %w", err)
+	}
+
+	return nil
+}
+
+// IsApiKey - reports whether the credential has the shape
+// of a key issued by this service.
+func IsApiKey(credential string) bool {
+	return strings.HasPrefix(credential, ApiKeyPrefix)
+}
+
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
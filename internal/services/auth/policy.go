@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrForbidden   = errors.New("forbidden")
+	ErrInvalidRole = errors.New("invalid role")
+)
+
+type Role string
+
+const (
+	RoleAdmin       Role = "admin"
+	RoleMarketMaker Role = "market-maker"
+	RoleTrader      Role = "trader"
+	RoleReadOnly    Role = "read-only"
+)
+
+type Resource string
+
+const (
+	ResourceCommodity       Resource = "commodity"
+	ResourceSolarSystem     Resource = "solarSystem"
+	ResourceCommodityMarket Resource = "commodityMarket"
+	ResourceCelestialBody   Resource = "celestialBody"
+	ResourceStation         Resource = "station"
+	ResourceLegalityRule    Resource = "legalityRule"
+	ResourceRecipe          Resource = "recipe"
+	ResourceFacility        Resource = "facility"
+	ResourceShip            Resource = "ship"
+	ResourceApiKey          Resource = "apiKey"
+	ResourceAudit           Resource = "audit"
+	ResourceWebhook         Resource = "webhook"
+	ResourceMetrics         Resource = "metrics"
+)
+
+type Operation string
+
+const (
+	OperationRead   Operation = "read"
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+	// OperationReadDeleted - reading rows that have been soft deleted.
+	OperationReadDeleted Operation = "readDeleted"
+	OperationRestore     Operation = "restore"
+)
+
+// Permission - a single operation on a resource, written
+// as "resource:operation" when reported to callers.
+type Permission struct {
+	Resource  Resource
+	Operation Operation
+}
+
+func (p Permission) String() string {
+	return fmt.Sprintf("%s:%s", p.Resource, p.Operation)
+}
+
+// Policy - declares which operations each role may perform
+// per resource. Anything not listed here is denied.
+var Policy = map[Role]map[Resource][]Operation{
+	RoleAdmin: {
+		ResourceCommodity:       {OperationRead, OperationCreate, OperationUpdate, OperationDelete, OperationReadDeleted, OperationRestore},
+		ResourceSolarSystem:     {OperationRead, OperationCreate, OperationUpdate, OperationDelete, OperationReadDeleted, OperationRestore},
+		ResourceCommodityMarket: {OperationRead, OperationCreate, OperationUpdate, OperationDelete, OperationReadDeleted, OperationRestore},
+		ResourceCelestialBody:   {OperationRead, OperationCreate, OperationUpdate, OperationDelete, OperationReadDeleted, OperationRestore},
+		ResourceStation:         {OperationRead, OperationCreate, OperationUpdate, OperationDelete, OperationReadDeleted, OperationRestore},
+		ResourceLegalityRule:    {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceRecipe:          {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceFacility:        {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceShip:            {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceApiKey:          {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceAudit:           {OperationRead},
+		ResourceWebhook:         {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceMetrics:         {OperationRead},
+	},
+	RoleMarketMaker: {
+		ResourceCommodity:       {OperationRead, OperationCreate},
+		ResourceSolarSystem:     {OperationRead},
+		ResourceCommodityMarket: {OperationRead, OperationCreate, OperationUpdate, OperationDelete},
+		ResourceCelestialBody:   {OperationRead},
+		ResourceStation:         {OperationRead},
+		ResourceLegalityRule:    {OperationRead},
+		ResourceRecipe:          {OperationRead},
+		ResourceFacility:        {OperationRead},
+		ResourceShip:            {OperationRead},
+	},
+	RoleTrader: {
+		ResourceCommodity:       {OperationRead},
+		ResourceSolarSystem:     {OperationRead},
+		ResourceCommodityMarket: {OperationRead},
+		ResourceCelestialBody:   {OperationRead},
+		ResourceStation:         {OperationRead},
+		ResourceLegalityRule:    {OperationRead},
+		ResourceRecipe:          {OperationRead},
+		ResourceFacility:        {OperationRead},
+		ResourceShip:            {OperationRead, OperationUpdate},
+	},
+	RoleReadOnly: {
+		ResourceCommodity:       {OperationRead},
+		ResourceSolarSystem:     {OperationRead},
+		ResourceCommodityMarket: {OperationRead},
+		ResourceCelestialBody:   {OperationRead},
+		ResourceStation:         {OperationRead},
+		ResourceLegalityRule:    {OperationRead},
+		ResourceRecipe:          {OperationRead},
+		ResourceFacility:        {OperationRead},
+		ResourceShip:            {OperationRead},
+	},
+}
+
+// SystemPrincipal - acts on behalf of the service itself,
+// for background jobs and tooling that run outside a request.
+var SystemPrincipal = Principal{
+	ID:     "system",
+	Name:   "system",
+	Method: MethodSystem,
+	Role:   RoleAdmin,
+}
+
+// ForbiddenError - reports the permission the caller was missing.
+type ForbiddenError struct {
+	Role       Role
+	Permission Permission
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("role %q is missing permission %s", e.Role, e.Permission)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+func ParseRole(role string) (Role, error) {
+	if _, ok := Policy[Role(role)]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	return Role(role), nil
+}
+
+// Can - reports whether the role may perform the operation on the resource.
+func (r Role) Can(resource Resource, operation Operation) bool {
+	for _, allowed := range Policy[r][resource] {
+		if allowed == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authorize - checks the principal on the context against the
+// policy, returning a ForbiddenError naming the missing permission.
+func Authorize(ctx context.Context, resource Resource, operation Operation) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if !principal.Role.Can(resource, operation) {
+		return &ForbiddenError{
+			Role:       principal.Role,
+			Permission: Permission{Resource: resource, Operation: operation},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package commodity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxTags and MaxTagLength - bound the free-form tags of a commodity.
+const (
+	MaxTags      = 20
+	MaxTagLength = 50
+)
+
+var (
+	ErrInvalidCategory = errors.New("invalid commodity category")
+	ErrInvalidTag      = errors.New("invalid commodity tag")
+)
+
+// Category - the broad kind of goods a commodity is. Empty when the
+// commodity is uncategorized.
+type Category string
+
+const (
+	CategoryNone         Category = ""
+	CategoryOre          Category = "ore"
+	CategoryRefinedMetal Category = "refinedMetal"
+	CategoryFuel         Category = "fuel"
+	CategoryFood         Category = "food"
+	CategoryMedical      Category = "medical"
+	CategoryIndustrial   Category = "industrial"
+	CategoryTechnology   Category = "technology"
+	CategoryLuxury       Category = "luxury"
+	CategoryWeapons      Category = "weapons"
+	CategoryContraband   Category = "contraband"
+)
+
+var Categories = []Category{
+	CategoryOre, CategoryRefinedMetal, CategoryFuel, CategoryFood, CategoryMedical,
+	CategoryIndustrial, CategoryTechnology, CategoryLuxury, CategoryWeapons, CategoryContraband,
+}
+
+func ParseCategory(category string) (Category, error) {
+	if category == string(CategoryNone) {
+		return CategoryNone, nil
+	}
+
+	for _, known := range Categories {
+		if category == string(known) {
+			return known, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidCategory, category)
+}
+
+// NormalizeTags - trims and lower cases the tags, dropping duplicates,
+// and sorts them so two lists of the same tags compare equal.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: tags must be between 1 and %d characters", ErrInvalidTag, MaxTagLength)
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("%w: a commodity has at most %d tags", ErrInvalidTag, MaxTags)
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// Normalize - checks the category and tags of a commodity before it
+// is written, returning it with its tags normalized.
+func (c Commodity) Normalize() (Commodity, error) {
+	if _, err := ParseCategory(string(c.Category)); err != nil {
+		return Commodity{}, err
+	}
+
+	tags, err := NormalizeTags(c.Tags)
+	if err != nil {
+		return Commodity{}, err
+	}
+
+	c.Tags = tags
+	return c, nil
+}
@@ -4,14 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
 )
 
 var (
 	ErrFetchingCommodity = errors.New("failed to fetch commodity by id")
 	ErrCommodityNotFound = errors.New("commodity not found")
-	ErrNotImplemented    = errors.New("not implemented")
+	ErrCommodityInUse    = errors.New("commodity is traded in commodity markets")
+	// ErrCommodityConflict - another live commodity already has the name.
+	ErrCommodityConflict  = errors.New("a commodity with this name already exists")
+	ErrInvalidRemovalMode = errors.New("invalid removal mode")
+	ErrNotImplemented     = errors.New("not implemented")
 )
 
 type Commodity struct {
@@ -19,32 +28,116 @@ type Commodity struct {
 	Name       string
 	UnitMass   float64
 	UnitVolume float64
+	Category   Category
+	// Tags - free-form labels, normalized by NormalizeTags.
+	Tags      []string
+	DeletedAt *time.Time
+}
+
+// Filter - narrows the commodities returned by a listing. An empty
+// Category or Tag matches every commodity.
+type Filter struct {
+	IncludeDeleted bool
+	Category       Category
+	Tag            string
+}
+
+// RemovalMode - decides what happens to the commodity markets
+// trading a commodity when the commodity is removed.
+type RemovalMode string
+
+const (
+	// RemovalModeRestrict - refuses to remove a commodity that is still traded.
+	RemovalModeRestrict RemovalMode = "restrict"
+	// RemovalModeCascade - removes the commodity together with its markets.
+	RemovalModeCascade RemovalMode = "cascade"
+)
+
+func ParseRemovalMode(mode string) (RemovalMode, error) {
+	switch RemovalMode(mode) {
+	case RemovalModeRestrict, RemovalModeCascade:
+		return RemovalMode(mode), nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidRemovalMode, mode)
+}
+
+// DependentMarket - a commodity market that references a commodity.
+type DependentMarket struct {
+	ID              string
+	SolarSystemID   string
+	SolarSystemName string
+}
+
+// InUseError - lists the markets preventing a commodity's removal.
+type InUseError struct {
+	Markets []DependentMarket
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("%s: %d dependent markets", ErrCommodityInUse, len(e.Markets))
+}
+
+func (e *InUseError) Is(target error) bool {
+	return target == ErrCommodityInUse
 }
 
 // Store - this interface defines all methods
-// our service needs to operate.
+// our service needs to operate. Every method called with
+// the context handed to a WithTx callback runs inside
+// that callback's transaction.
 type Store interface {
-	GetCommodityById(context.Context, string) (Commodity, error)
-	GetCommoditiesByPagination(context.Context, data.Pagination) ([]Commodity, error)
+	WithTx(context.Context, func(context.Context) error) error
+	GetCommodityById(context.Context, string, bool) (Commodity, error)
+	GetCommoditiesByPagination(context.Context, data.Pagination, Filter) ([]Commodity, error)
+	GetCommoditiesByIds(context.Context, []string, bool) ([]Commodity, error)
 	CreateCommodity(context.Context, Commodity) (Commodity, error)
 	RemoveCommodity(context.Context, string) error
+	RestoreCommodity(context.Context, string) (Commodity, []DependentMarket, error)
+	GetDependentMarketsByCommodityId(context.Context, string) ([]DependentMarket, error)
+	RemoveAllCommodityMarketsByCommodityId(context.Context, string) error
+}
+
+// Auditor - records every mutation made through the service.
+type Auditor interface {
+	Record(ctx context.Context, action audit.Action, entityType string, entityID string, before any, after any) error
+}
+
+// Publisher - writes the domain events raised by the service
+// to the outbox for delivery to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, eventType events.Type, entityID string, topics []string, data any) error
 }
 
 // Service - is the struct on which all our
 // logic will be built on top of
 type Service struct {
-	Store Store
+	Store     Store
+	Auditor   Auditor
+	Publisher Publisher
 }
 
 // NewService - returns a pointer to a new service
-func NewService(store Store) *Service {
+func NewService(store Store, auditor Auditor, publisher Publisher) *Service {
 	return &Service{
-		Store: store,
+		Store:     store,
+		Auditor:   auditor,
+		Publisher: publisher,
 	}
 }
 
-func (s *Service) FindCommodity(ctx context.Context, id string) (Commodity, error) {
-	commodity, err := s.Store.GetCommodityById(ctx, id)
+func (s *Service) FindCommodity(ctx context.Context, id string, includeDeleted bool) (Commodity, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationRead); err != nil {
+		return Commodity{}, err
+	}
+
+	if includeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationReadDeleted); err != nil {
+			return Commodity{}, err
+		}
+	}
+
+	commodity, err := s.Store.GetCommodityById(ctx, id, includeDeleted)
 	if err != nil {
 		return Commodity{}, err
 	}
@@ -52,8 +145,23 @@ func (s *Service) FindCommodity(ctx context.Context, id string) (Commodity, erro
 	return commodity, nil
 }
 
-func (s *Service) FindAllCommodity(ctx context.Context, pagination data.Pagination) ([]Commodity, error) {
-	commodities, err := s.Store.GetCommoditiesByPagination(ctx, pagination)
+func (s *Service) FindAllCommodity(ctx context.Context, pagination data.Pagination, filter Filter) ([]Commodity, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if filter.IncludeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationReadDeleted); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := ParseCategory(string(filter.Category)); err != nil {
+		return nil, err
+	}
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+
+	commodities, err := s.Store.GetCommoditiesByPagination(ctx, pagination, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error getting commodities by pagination: %w", err)
 	}
@@ -61,8 +169,51 @@ func (s *Service) FindAllCommodity(ctx context.Context, pagination data.Paginati
 	return commodities, nil
 }
 
+// FindCommoditiesByIds - fetches many commodities at once, for
+// callers resolving the commodities a set of markets trade.
+func (s *Service) FindCommoditiesByIds(ctx context.Context, ids []string, includeDeleted bool) ([]Commodity, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if includeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationReadDeleted); err != nil {
+			return nil, err
+		}
+	}
+
+	commodities, err := s.Store.GetCommoditiesByIds(ctx, ids, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("error getting commodities by ids: %w", err)
+	}
+
+	return commodities, nil
+}
+
 func (s *Service) CreateCommodity(ctx context.Context, commodity Commodity) (Commodity, error) {
-	createdCommodity, err := s.Store.CreateCommodity(ctx, commodity)
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationCreate); err != nil {
+		return Commodity{}, err
+	}
+
+	commodity, err := commodity.Normalize()
+	if err != nil {
+		return Commodity{}, err
+	}
+
+	var createdCommodity Commodity
+	err = s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		createdCommodity, err = s.Store.CreateCommodity(ctx, commodity)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityCommodity, createdCommodity.ID, nil, createdCommodity); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.CommodityCreated, createdCommodity.ID, commodityTopics(createdCommodity.ID), createdCommodity)
+	})
 	if err != nil {
 		return Commodity{}, fmt.Errorf("error creating commodity: %w", err)
 	}
@@ -70,11 +221,117 @@ func (s *Service) CreateCommodity(ctx context.Context, commodity Commodity) (Com
 	return createdCommodity, nil
 }
 
-func (s *Service) RemoveCommodity(ctx context.Context, id string) error {
-	err := s.Store.RemoveCommodity(ctx, id)
+// RemoveCommodity - soft deletes a commodity and, in cascade mode,
+// every market trading it, as a single unit of work. In restrict mode
+// an InUseError listing the dependent markets is returned instead.
+func (s *Service) RemoveCommodity(ctx context.Context, id string, mode RemovalMode) error {
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	if mode == RemovalModeCascade {
+		if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationDelete); err != nil {
+			return err
+		}
+	}
+
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		removedCommodity, err := s.Store.GetCommodityById(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		markets, err := s.Store.GetDependentMarketsByCommodityId(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error getting dependent markets: %w", err)
+		}
+
+		if len(markets) > 0 {
+			if mode != RemovalModeCascade {
+				return &InUseError{Markets: markets}
+			}
+
+			if err := s.Store.RemoveAllCommodityMarketsByCommodityId(ctx, id); err != nil {
+				return fmt.Errorf("error removing dependent markets: %w", err)
+			}
+
+			for _, market := range markets {
+				if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCommodityMarket, market.ID, market, nil); err != nil {
+					return err
+				}
+				if err := s.Publisher.Publish(ctx, events.MarketRemoved, market.ID, dependentMarketTopics(id, market), market); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.Store.RemoveCommodity(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCommodity, id, removedCommodity, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.CommodityRemoved, id, commodityTopics(id), removedCommodity)
+	})
 	if err != nil {
 		return fmt.Errorf("error removing commodity: %w", err)
 	}
 
 	return nil
 }
+
+// RestoreCommodity - undoes a soft delete, bringing back the
+// markets that were removed along with the commodity.
+func (s *Service) RestoreCommodity(ctx context.Context, id string) (Commodity, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodity, auth.OperationRestore); err != nil {
+		return Commodity{}, err
+	}
+
+	var restoredCommodity Commodity
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		deletedCommodity, err := s.Store.GetCommodityById(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		var restoredMarkets []DependentMarket
+		restoredCommodity, restoredMarkets, err = s.Store.RestoreCommodity(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, market := range restoredMarkets {
+			if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCommodityMarket, market.ID, nil, market); err != nil {
+				return err
+			}
+			if err := s.Publisher.Publish(ctx, events.MarketRestored, market.ID, dependentMarketTopics(id, market), market); err != nil {
+				return err
+			}
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCommodity, id, deletedCommodity, restoredCommodity); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.CommodityRestored, id, commodityTopics(id), restoredCommodity)
+	})
+	if err != nil {
+		return Commodity{}, fmt.Errorf("error restoring commodity: %w", err)
+	}
+
+	return restoredCommodity, nil
+}
+
+func commodityTopics(id string) []string {
+	return []string{events.Topic(events.TopicCommodity, id)}
+}
+
+func dependentMarketTopics(commodityId string, market DependentMarket) []string {
+	return []string{
+		events.Topic(events.TopicMarket, market.ID),
+		events.Topic(events.TopicSolarSystem, market.SolarSystemID),
+		events.Topic(events.TopicCommodity, commodityId),
+	}
+}
@@ -0,0 +1,149 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidType = errors.New("invalid event type")
+)
+
+type Type string
+
+const (
+	CommodityCreated  Type = "CommodityCreated"
+	CommodityUpdated  Type = "CommodityUpdated"
+	CommodityRemoved  Type = "CommodityRemoved"
+	CommodityRestored Type = "CommodityRestored"
+
+	SolarSystemCreated  Type = "SolarSystemCreated"
+	SolarSystemUpdated  Type = "SolarSystemUpdated"
+	SolarSystemRemoved  Type = "SolarSystemRemoved"
+	SolarSystemRestored Type = "SolarSystemRestored"
+
+	StationCreated  Type = "StationCreated"
+	StationUpdated  Type = "StationUpdated"
+	StationRemoved  Type = "StationRemoved"
+	StationRestored Type = "StationRestored"
+
+	LegalityRuleCreated Type = "LegalityRuleCreated"
+	LegalityRuleUpdated Type = "LegalityRuleUpdated"
+	LegalityRuleRemoved Type = "LegalityRuleRemoved"
+
+	RecipeCreated Type = "RecipeCreated"
+	RecipeUpdated Type = "RecipeUpdated"
+	RecipeRemoved Type = "RecipeRemoved"
+
+	FacilityCreated Type = "FacilityCreated"
+	FacilityUpdated Type = "FacilityUpdated"
+	FacilityRemoved Type = "FacilityRemoved"
+
+	ShipCreated Type = "ShipCreated"
+	ShipRemoved Type = "ShipRemoved"
+	// ShipDeparted and ShipArrived - published as a ship leaves for
+	// and reaches its destination.
+	ShipDeparted Type = "ShipDeparted"
+	ShipArrived  Type = "ShipArrived"
+	// ShipTraded - published when a ship buys from or sells to a
+	// market, changing its cargo and the market's stock.
+	ShipTraded Type = "ShipTraded"
+
+	MarketCreated  Type = "MarketCreated"
+	MarketUpdated  Type = "MarketUpdated"
+	MarketRemoved  Type = "MarketRemoved"
+	MarketRestored Type = "MarketRestored"
+	// MarketPriceChanged - published instead of MarketUpdated
+	// when an update changes the market's base price.
+	MarketPriceChanged Type = "MarketPriceChanged"
+)
+
+// Types - every event type the services publish.
+var Types = []Type{
+	CommodityCreated, CommodityUpdated, CommodityRemoved, CommodityRestored,
+	SolarSystemCreated, SolarSystemUpdated, SolarSystemRemoved, SolarSystemRestored,
+	StationCreated, StationUpdated, StationRemoved, StationRestored,
+	LegalityRuleCreated, LegalityRuleUpdated, LegalityRuleRemoved,
+	RecipeCreated, RecipeUpdated, RecipeRemoved,
+	FacilityCreated, FacilityUpdated, FacilityRemoved,
+	ShipCreated, ShipRemoved, ShipDeparted, ShipArrived, ShipTraded,
+	MarketCreated, MarketUpdated, MarketRemoved, MarketRestored, MarketPriceChanged,
+}
+
+func ParseType(eventType string) (Type, error) {
+	for _, t := range Types {
+		if string(t) == eventType {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidType, eventType)
+}
+
+// Topic kinds. A topic names one entity, e.g. "market:{id}", and an
+// event is published to the topics of every entity it concerns.
+const (
+	TopicMarket      = "market"
+	TopicSolarSystem = "solarSystem"
+	TopicCommodity   = "commodity"
+	TopicStation     = "station"
+	TopicRecipe      = "recipe"
+	TopicShip        = "ship"
+)
+
+func Topic(kind string, id string) string {
+	return kind + ":" + id
+}
+
+// Event - a domain event as stored in the outbox and sent
+// to subscribers. IDs increase in the order events were written.
+type Event struct {
+	ID        int64           `json:"id"`
+	Type      Type            `json:"type"`
+	EntityID  string          `json:"entityId"`
+	Topics    []string        `json:"topics"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type Store interface {
+	CreateOutboxEvent(context.Context, Event) error
+}
+
+// Encoder - maps the domain data an event is raised with to the type
+// it is published as, keeping the payload stable as the domain changes.
+type Encoder func(data any) any
+
+type Service struct {
+	Store  Store
+	Encode Encoder
+}
+
+func NewService(store Store, encode Encoder) *Service {
+	return &Service{Store: store, Encode: encode}
+}
+
+// Publish - writes an event to the outbox. Called inside a store
+// transaction, the event is only published if the mutation it
+// describes is committed.
+func (s *Service) Publish(ctx context.Context, eventType Type, entityID string, topics []string, data any) error {
+	dataJson, err := json.Marshal(s.Encode(data))
+	if err != nil {
+		return fmt.Errorf("error encoding event data: %w", err)
+	}
+
+	err = s.Store.CreateOutboxEvent(ctx, Event{
+		Type:     eventType,
+		EntityID: entityID,
+		Topics:   topics,
+		Data:     dataJson,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating outbox event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package production
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+var ErrInvalidQuantity = errors.New("invalid quantity")
+
+// BillOfMaterials - everything it takes to make Quantity of a
+// commodity through the recipes producing it.
+type BillOfMaterials struct {
+	CommodityID   string
+	CommodityName string
+	Quantity      float64
+	// Steps - each commodity made along the way, the commodity itself
+	// first, with the recipe making it and the cycles that takes.
+	Steps []ProductionStep
+	// RawMaterials - the commodities no recipe makes, which have to
+	// be bought or extracted.
+	RawMaterials []Material
+}
+
+type ProductionStep struct {
+	Material
+	RecipeID   string
+	RecipeName string
+	Cycles     float64
+}
+
+type Material struct {
+	CommodityID   string
+	CommodityName string
+	Quantity      float64
+}
+
+// FindBillOfMaterials - expands the commodity through the recipes
+// producing it down to raw materials. Where several recipes produce
+// a commodity the first by name is used, a recipe's other outputs
+// are treated as byproducts, and quantities are per unit rather than
+// rounded up to whole cycles. An input that leads back to a commodity
+// it goes into is counted as a raw material instead of expanding
+// forever. Each commodity is expanded once, however many recipes
+// share it, so the work grows with the recipes involved rather than
+// the paths through them.
+func (s *Service) FindBillOfMaterials(ctx context.Context, commodityId string, quantity float64) (BillOfMaterials, error) {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationRead); err != nil {
+		return BillOfMaterials{}, err
+	}
+
+	if !(quantity > 0) || math.IsInf(quantity, 0) {
+		return BillOfMaterials{}, fmt.Errorf("%w: the quantity must be a positive number", ErrInvalidQuantity)
+	}
+
+	target, err := s.Store.GetCommodityById(ctx, commodityId, false)
+	if err != nil {
+		return BillOfMaterials{}, err
+	}
+
+	recipes, err := s.Store.GetAllRecipes(ctx)
+	if err != nil {
+		return BillOfMaterials{}, fmt.Errorf("error getting recipes: %w", err)
+	}
+
+	// recipes come sorted by name, so the first producing a
+	// commodity is the one used
+	producers := map[string]Recipe{}
+	for _, recipe := range recipes {
+		for _, output := range recipe.Outputs {
+			if _, ok := producers[output.CommodityID]; !ok {
+				producers[output.CommodityID] = recipe
+			}
+		}
+	}
+
+	graph := &productionGraph{
+		producers: producers,
+		names:     map[string]string{target.ID: target.Name},
+		visiting:  map[string]bool{},
+		visited:   map[string]bool{},
+		backEdges: map[string]map[string]bool{},
+	}
+	graph.visit(target.ID)
+
+	bill := BillOfMaterials{
+		CommodityID:   target.ID,
+		CommodityName: target.Name,
+		Quantity:      quantity,
+		Steps:         []ProductionStep{},
+		RawMaterials:  []Material{},
+	}
+
+	// the reverse of the visit order is a topological order, so every
+	// commodity's demand is complete before it is passed to its inputs
+	demand := map[string]float64{target.ID: quantity}
+	raw := map[string]float64{}
+	for i := len(graph.order) - 1; i >= 0; i-- {
+		id := graph.order[i]
+		recipe, ok := producers[id]
+		if !ok {
+			raw[id] += demand[id]
+			continue
+		}
+
+		var produced int
+		for _, output := range recipe.Outputs {
+			if output.CommodityID == id {
+				produced = output.Quantity
+			}
+		}
+		cycles := demand[id] / float64(produced)
+
+		bill.Steps = append(bill.Steps, ProductionStep{
+			Material:   Material{CommodityID: id, CommodityName: graph.names[id], Quantity: demand[id]},
+			RecipeID:   recipe.ID,
+			RecipeName: recipe.Name,
+			Cycles:     cycles,
+		})
+
+		for _, input := range recipe.Inputs {
+			if graph.backEdges[id][input.CommodityID] {
+				raw[input.CommodityID] += float64(input.Quantity) * cycles
+			} else {
+				demand[input.CommodityID] += float64(input.Quantity) * cycles
+			}
+		}
+	}
+
+	for id, total := range raw {
+		bill.RawMaterials = append(bill.RawMaterials, Material{CommodityID: id, CommodityName: graph.names[id], Quantity: total})
+	}
+	sort.Slice(bill.RawMaterials, func(i, j int) bool {
+		return bill.RawMaterials[i].CommodityName < bill.RawMaterials[j].CommodityName
+	})
+
+	return bill, nil
+}
+
+// productionGraph - the commodities reachable from a bill of
+// materials' commodity through the inputs of the recipes producing
+// them. order lists each once, after every input it reaches, and
+// backEdges marks, by commodity, the inputs leading back to it.
+type productionGraph struct {
+	producers map[string]Recipe
+	names     map[string]string
+	visiting  map[string]bool
+	visited   map[string]bool
+	backEdges map[string]map[string]bool
+	order     []string
+}
+
+func (g *productionGraph) visit(id string) {
+	g.visiting[id] = true
+	for _, input := range g.producers[id].Inputs {
+		g.names[input.CommodityID] = input.CommodityName
+		if g.visiting[input.CommodityID] {
+			if g.backEdges[id] == nil {
+				g.backEdges[id] = map[string]bool{}
+			}
+			g.backEdges[id][input.CommodityID] = true
+			continue
+		}
+		if !g.visited[input.CommodityID] {
+			g.visit(input.CommodityID)
+		}
+	}
+	g.visiting[id] = false
+	g.visited[id] = true
+	g.order = append(g.order, id)
+}
@@ -0,0 +1,139 @@
+package production
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+)
+
+// recipeStore - serves the commodities and recipes a bill of
+// materials reads; every other Store method is left unimplemented.
+type recipeStore struct {
+	Store
+	recipes []Recipe
+}
+
+func (s recipeStore) GetCommodityById(ctx context.Context, id string, includeDeleted bool) (commodity.Commodity, error) {
+	return commodity.Commodity{ID: id, Name: id}, nil
+}
+
+func (s recipeStore) GetAllRecipes(ctx context.Context) ([]Recipe, error) {
+	return s.recipes, nil
+}
+
+func component(id string, quantity int) Component {
+	return Component{CommodityID: id, CommodityName: id, Quantity: quantity}
+}
+
+func findBillOfMaterials(t *testing.T, recipes []Recipe, commodityId string, quantity float64) BillOfMaterials {
+	t.Helper()
+
+	service := NewService(recipeStore{recipes: recipes}, nil, nil)
+	bill, err := service.FindBillOfMaterials(auth.WithPrincipal(context.Background(), auth.SystemPrincipal), commodityId, quantity)
+	if err != nil {
+		t.Fatalf("FindBillOfMaterials() error: %v", err)
+	}
+
+	return bill
+}
+
+func TestFindBillOfMaterialsSharedSubRecipe(t *testing.T) {
+	// widget takes a frame and a panel, which both take plate, which
+	// takes ore
+	recipes := []Recipe{
+		{ID: "frame", Name: "frame", Inputs: []Component{component("plate", 2)}, Outputs: []Component{component("frame", 1)}},
+		{ID: "panel", Name: "panel", Inputs: []Component{component("plate", 3), component("glass", 1)}, Outputs: []Component{component("panel", 2)}},
+		{ID: "plate", Name: "plate", Inputs: []Component{component("ore", 4)}, Outputs: []Component{component("plate", 1)}},
+		{ID: "widget", Name: "widget", Inputs: []Component{component("frame", 1), component("panel", 2)}, Outputs: []Component{component("widget", 1)}},
+	}
+
+	bill := findBillOfMaterials(t, recipes, "widget", 10)
+
+	steps := map[string]ProductionStep{}
+	for _, step := range bill.Steps {
+		if _, ok := steps[step.CommodityID]; ok {
+			t.Errorf("step %s listed twice", step.CommodityID)
+		}
+		steps[step.CommodityID] = step
+	}
+
+	wantSteps := map[string][2]float64{
+		// commodity: quantity, cycles
+		"widget": {10, 10},
+		"frame":  {10, 10},
+		"panel":  {20, 10},
+		"plate":  {20 + 30, 50},
+	}
+	if len(steps) != len(wantSteps) {
+		t.Errorf("got %d steps, want %d", len(steps), len(wantSteps))
+	}
+	for id, want := range wantSteps {
+		step := steps[id]
+		if step.Quantity != want[0] || step.Cycles != want[1] {
+			t.Errorf("step %s = %v units in %v cycles, want %v in %v", id, step.Quantity, step.Cycles, want[0], want[1])
+		}
+	}
+
+	if bill.Steps[0].CommodityID != "widget" {
+		t.Errorf("first step = %s, want widget", bill.Steps[0].CommodityID)
+	}
+
+	wantRaw := []Material{
+		{CommodityID: "glass", CommodityName: "glass", Quantity: 10},
+		{CommodityID: "ore", CommodityName: "ore", Quantity: 200},
+	}
+	if fmt.Sprint(bill.RawMaterials) != fmt.Sprint(wantRaw) {
+		t.Errorf("raw materials = %v, want %v", bill.RawMaterials, wantRaw)
+	}
+}
+
+func TestFindBillOfMaterialsDeepSharing(t *testing.T) {
+	// each level takes two of each commodity of the level below, so
+	// there are 2^depth paths from the top to the raw material
+	const depth = 60
+	recipes := []Recipe{}
+	for level := 0; level < depth; level++ {
+		below := func(i int) string { return fmt.Sprintf("level%d-%d", level+1, i) }
+		for i := 0; i < 2; i++ {
+			id := fmt.Sprintf("level%d-%d", level, i)
+			inputs := []Component{component(below(0), 1), component(below(1), 1)}
+			if level == depth-1 {
+				inputs = []Component{component("ore", 1)}
+			}
+			recipes = append(recipes, Recipe{ID: id, Name: id, Inputs: inputs, Outputs: []Component{component(id, 1)}})
+		}
+	}
+
+	bill := findBillOfMaterials(t, recipes, "level0-0", 1)
+
+	if len(bill.Steps) != 2*depth-1 {
+		t.Errorf("got %d steps, want %d", len(bill.Steps), 2*depth-1)
+	}
+	// level0-0 takes one of each at level 1, and from there every
+	// level takes twice as many of each as the level above
+	want := math.Pow(2, depth-1)
+	if len(bill.RawMaterials) != 1 || bill.RawMaterials[0].Quantity != want {
+		t.Errorf("raw materials = %v, want %v ore", bill.RawMaterials, want)
+	}
+}
+
+func TestFindBillOfMaterialsCycle(t *testing.T) {
+	// refining fuel takes some fuel back
+	recipes := []Recipe{
+		{ID: "refine", Name: "refine", Inputs: []Component{component("crude", 2), component("fuel", 1)}, Outputs: []Component{component("fuel", 3)}},
+	}
+
+	bill := findBillOfMaterials(t, recipes, "fuel", 6)
+
+	wantRaw := []Material{
+		{CommodityID: "crude", CommodityName: "crude", Quantity: 4},
+		{CommodityID: "fuel", CommodityName: "fuel", Quantity: 2},
+	}
+	if fmt.Sprint(bill.RawMaterials) != fmt.Sprint(wantRaw) {
+		t.Errorf("raw materials = %v, want %v", bill.RawMaterials, wantRaw)
+	}
+}
@@ -0,0 +1,333 @@
+package production
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+var (
+	ErrFacilityNotFound = errors.New("facility not found")
+	// ErrFacilityConflict - another facility of the solar system already has the name.
+	ErrFacilityConflict = errors.New("a facility with this name already exists in this solar system")
+	ErrInvalidFacility  = errors.New("invalid facility")
+	// ErrFacilityReferenceNotFound - the solar system, station or recipe
+	// of a facility does not exist or has been deleted.
+	ErrFacilityReferenceNotFound = errors.New("solar system, station or recipe not found")
+
+	// ErrMissingMarket and ErrInsufficientStock - why a cycle couldn't
+	// run, recorded as the facility's stalled reason.
+	ErrMissingMarket     = errors.New("no market trades the commodity")
+	ErrInsufficientStock = errors.New("not enough stock")
+)
+
+type FacilityStatus string
+
+const (
+	// FacilityIdle - the facility hasn't run a cycle since it was
+	// created, changed recipe or was enabled, or is disabled.
+	FacilityIdle FacilityStatus = "idle"
+	// FacilityProducing - the facility's last cycle ran.
+	FacilityProducing FacilityStatus = "producing"
+	// FacilityStalled - the facility's last cycle couldn't run, for
+	// the StalledReason.
+	FacilityStalled FacilityStatus = "stalled"
+)
+
+// Facility - runs a recipe in a solar system, against the markets of
+// its station, or the system level markets when StationID is empty.
+// Every cycle takes the recipe's inputs from the stock of those
+// markets and adds its outputs to it.
+type Facility struct {
+	ID            string
+	SolarSystemID string
+	StationID     string
+	RecipeID      string
+	RecipeName    string
+	Name          string
+	Enabled       bool
+	Status        FacilityStatus
+	StalledReason string
+	LastCycleAt   *time.Time
+	NextCycleAt   time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// FacilityUpdate - changing the recipe or enabling the facility
+// starts its next cycle afresh.
+type FacilityUpdate struct {
+	Name     string
+	RecipeID string
+	Enabled  bool
+}
+
+func (u FacilityUpdate) validate() error {
+	if u.Name == "" || len(u.Name) > 255 {
+		return fmt.Errorf("%w: the name must be between 1 and 255 characters", ErrInvalidFacility)
+	}
+
+	if u.RecipeID == "" {
+		return fmt.Errorf("%w: a facility runs a recipe", ErrInvalidFacility)
+	}
+
+	return nil
+}
+
+func (s *Service) FindFacilities(ctx context.Context, solarSystemId string) ([]Facility, error) {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	systems, err := s.Store.GetSolarSystemsByIds(ctx, []string{solarSystemId}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(systems) == 0 {
+		return nil, solarSystem.ErrSolarSystemNotFound
+	}
+
+	return s.Store.GetFacilitiesBySolarSystemId(ctx, solarSystemId)
+}
+
+// FindFacility - a facility of another solar system is reported as
+// not found.
+func (s *Service) FindFacility(ctx context.Context, solarSystemId string, id string) (Facility, error) {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationRead); err != nil {
+		return Facility{}, err
+	}
+
+	return s.getFacilityInSolarSystem(ctx, solarSystemId, id)
+}
+
+func (s *Service) CreateFacility(ctx context.Context, facility Facility) (Facility, error) {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationCreate); err != nil {
+		return Facility{}, err
+	}
+
+	facility.Name = strings.TrimSpace(facility.Name)
+	if err := (FacilityUpdate{Name: facility.Name, RecipeID: facility.RecipeID, Enabled: facility.Enabled}).validate(); err != nil {
+		return Facility{}, err
+	}
+
+	var newFacility Facility
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newFacility, err = s.Store.CreateFacility(ctx, facility)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityFacility, newFacility.ID, nil, newFacility); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.FacilityCreated, newFacility.ID, facilityTopics(newFacility), newFacility)
+	})
+	if err != nil {
+		return Facility{}, err
+	}
+
+	return newFacility, nil
+}
+
+func (s *Service) UpdateFacility(ctx context.Context, solarSystemId string, id string, update FacilityUpdate) (Facility, error) {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationUpdate); err != nil {
+		return Facility{}, err
+	}
+
+	update.Name = strings.TrimSpace(update.Name)
+	if err := update.validate(); err != nil {
+		return Facility{}, err
+	}
+
+	var updatedFacility Facility
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		facility, err := s.getFacilityInSolarSystem(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		updatedFacility, err = s.Store.UpdateFacility(ctx, solarSystemId, id, update)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityFacility, id, facility, updatedFacility); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.FacilityUpdated, id, facilityTopics(updatedFacility), updatedFacility)
+	})
+	if err != nil {
+		return Facility{}, err
+	}
+
+	return updatedFacility, nil
+}
+
+// RemoveFacility - deletes the facility outright. The stock it
+// produced stays in the markets.
+func (s *Service) RemoveFacility(ctx context.Context, solarSystemId string, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		facility, err := s.getFacilityInSolarSystem(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveFacility(ctx, solarSystemId, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityFacility, id, facility, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.FacilityRemoved, id, facilityTopics(facility), facility)
+	})
+}
+
+// CycleResult - what one run of the production job did.
+type CycleResult struct {
+	Produced int
+	Stalled  int
+}
+
+// RunDueCycles - runs one cycle of up to limit facilities whose next
+// cycle is due, each in its own transaction so the markets of one
+// aren't held locked while the others run. A facility whose cycle
+// can't run is marked stalled and tried again a cycle later. Cycles
+// only change stock, which is high volume, so they are neither
+// audited nor published.
+func (s *Service) RunDueCycles(ctx context.Context, limit int) (CycleResult, error) {
+	if err := auth.Authorize(ctx, auth.ResourceFacility, auth.OperationUpdate); err != nil {
+		return CycleResult{}, err
+	}
+
+	var result CycleResult
+	for range limit {
+		var status FacilityStatus
+		err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+			facilities, err := s.Store.ClaimDueFacilities(ctx, 1)
+			if err != nil || len(facilities) == 0 {
+				return err
+			}
+
+			status, err = s.runCycle(ctx, facilities[0])
+			return err
+		})
+		if err != nil {
+			return result, err
+		}
+
+		switch status {
+		case FacilityProducing:
+			result.Produced++
+		case FacilityStalled:
+			result.Stalled++
+		default:
+			// nothing was due
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) runCycle(ctx context.Context, facility Facility) (FacilityStatus, error) {
+	recipe, err := s.Store.GetRecipeById(ctx, facility.RecipeID)
+	if err != nil {
+		return "", err
+	}
+
+	commodityIds := []string{}
+	for _, component := range append(append([]Component{}, recipe.Inputs...), recipe.Outputs...) {
+		commodityIds = append(commodityIds, component.CommodityID)
+	}
+
+	stock, err := s.Store.GetFacilityStock(ctx, facility, commodityIds)
+	if err != nil {
+		return "", err
+	}
+
+	status, reason := FacilityProducing, ""
+	after, err := recipe.Cycle(stock)
+	if err != nil {
+		if !errors.Is(err, ErrMissingMarket) && !errors.Is(err, ErrInsufficientStock) {
+			return "", err
+		}
+		status, reason = FacilityStalled, err.Error()
+	} else if err := s.Store.SetFacilityStock(ctx, facility, after); err != nil {
+		return "", err
+	}
+
+	if err := s.Store.CompleteFacilityCycle(ctx, facility.ID, status, reason, recipe.CycleTime); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// Cycle - runs one cycle of the recipe against stock, the units held
+// by the markets it trades through keyed by commodity id, returning
+// the stock after the cycle. A commodity missing from stock has no
+// market to take it from or put it in.
+func (r Recipe) Cycle(stock map[string]int) (map[string]int, error) {
+	after := map[string]int{}
+	for _, input := range r.Inputs {
+		held, ok := stock[input.CommodityID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingMarket, input.CommodityName)
+		}
+		if held < input.Quantity {
+			return nil, fmt.Errorf("%w: %s needs %d, has %d", ErrInsufficientStock, input.CommodityName, input.Quantity, held)
+		}
+		after[input.CommodityID] = held - input.Quantity
+	}
+
+	for _, output := range r.Outputs {
+		held, ok := stock[output.CommodityID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingMarket, output.CommodityName)
+		}
+		after[output.CommodityID] = held + output.Quantity
+	}
+
+	return after, nil
+}
+
+func (s *Service) getFacilityInSolarSystem(ctx context.Context, solarSystemId string, id string) (Facility, error) {
+	facility, err := s.Store.GetFacilityById(ctx, id)
+	if err != nil {
+		return Facility{}, err
+	}
+
+	if facility.SolarSystemID != solarSystemId {
+		return Facility{}, ErrFacilityNotFound
+	}
+
+	return facility, nil
+}
+
+func facilityTopics(facility Facility) []string {
+	topics := []string{
+		events.Topic(events.TopicSolarSystem, facility.SolarSystemID),
+		events.Topic(events.TopicRecipe, facility.RecipeID),
+	}
+	if facility.StationID != "" {
+		topics = append(topics, events.Topic(events.TopicStation, facility.StationID))
+	}
+	return topics
+}
@@ -0,0 +1,273 @@
+package production
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+// Bounds on a recipe.
+const (
+	MaxComponents = 20
+	MinCycleTime  = time.Second
+	MaxCycleTime  = 30 * 24 * time.Hour
+)
+
+var (
+	ErrRecipeNotFound = errors.New("recipe not found")
+	// ErrRecipeConflict - another recipe already has the name.
+	ErrRecipeConflict = errors.New("a recipe with this name already exists")
+	ErrInvalidRecipe  = errors.New("invalid recipe")
+	// ErrRecipeReferenceNotFound - a commodity the recipe consumes or
+	// produces does not exist or has been deleted.
+	ErrRecipeReferenceNotFound = errors.New("commodity not found")
+	// ErrRecipeInUse - facilities still run the recipe.
+	ErrRecipeInUse = errors.New("recipe is run by facilities")
+)
+
+// Component - the quantity of a commodity one cycle of a recipe
+// consumes, as an input, or produces, as an output.
+type Component struct {
+	CommodityID   string
+	CommodityName string
+	Quantity      int
+}
+
+// Recipe - turns its inputs into its outputs once every CycleTime at
+// each facility running it. A recipe without inputs extracts its
+// outputs from nothing, e.g. a mine.
+type Recipe struct {
+	ID        string
+	Name      string
+	CycleTime time.Duration
+	Inputs    []Component
+	Outputs   []Component
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecipeFilter - narrows the recipes returned by a listing to those
+// producing or consuming a commodity. Unset fields don't narrow it.
+type RecipeFilter struct {
+	Produces string
+	Consumes string
+}
+
+func (r Recipe) validate() error {
+	if r.Name == "" || len(r.Name) > 255 {
+		return fmt.Errorf("%w: the name must be between 1 and 255 characters", ErrInvalidRecipe)
+	}
+
+	if r.CycleTime < MinCycleTime || r.CycleTime > MaxCycleTime || r.CycleTime%time.Second != 0 {
+		return fmt.Errorf("%w: the cycle time must be a whole number of seconds between %s and %s", ErrInvalidRecipe, MinCycleTime, MaxCycleTime)
+	}
+
+	if len(r.Outputs) == 0 {
+		return fmt.Errorf("%w: a recipe produces at least one commodity", ErrInvalidRecipe)
+	}
+
+	if len(r.Inputs)+len(r.Outputs) > MaxComponents {
+		return fmt.Errorf("%w: a recipe has at most %d inputs and outputs", ErrInvalidRecipe, MaxComponents)
+	}
+
+	seen := map[string]bool{}
+	for _, component := range append(append([]Component{}, r.Inputs...), r.Outputs...) {
+		if component.CommodityID == "" {
+			return fmt.Errorf("%w: every input and output names a commodityId", ErrInvalidRecipe)
+		}
+		if component.Quantity <= 0 {
+			return fmt.Errorf("%w: quantities must be positive", ErrInvalidRecipe)
+		}
+		if seen[component.CommodityID] {
+			return fmt.Errorf("%w: commodity %s is listed twice", ErrInvalidRecipe, component.CommodityID)
+		}
+		seen[component.CommodityID] = true
+	}
+
+	return nil
+}
+
+// Store - this interface defines all methods
+// our service needs to operate. Every method called with
+// the context handed to a WithTx callback runs inside
+// that callback's transaction.
+type Store interface {
+	WithTx(context.Context, func(context.Context) error) error
+	GetRecipesByPagination(context.Context, data.Pagination, RecipeFilter) ([]Recipe, error)
+	GetAllRecipes(context.Context) ([]Recipe, error)
+	GetRecipeById(context.Context, string) (Recipe, error)
+	CreateRecipe(context.Context, Recipe) (Recipe, error)
+	UpdateRecipe(context.Context, Recipe) (Recipe, error)
+	RemoveRecipe(context.Context, string) error
+	GetCommodityById(context.Context, string, bool) (commodity.Commodity, error)
+	GetSolarSystemsByIds(context.Context, []string, bool) ([]solarSystem.SolarSystem, error)
+	GetFacilitiesBySolarSystemId(context.Context, string) ([]Facility, error)
+	GetFacilityById(context.Context, string) (Facility, error)
+	CreateFacility(context.Context, Facility) (Facility, error)
+	UpdateFacility(context.Context, string, string, FacilityUpdate) (Facility, error)
+	RemoveFacility(context.Context, string, string) error
+	ClaimDueFacilities(context.Context, int) ([]Facility, error)
+	GetFacilityStock(context.Context, Facility, []string) (map[string]int, error)
+	SetFacilityStock(context.Context, Facility, map[string]int) error
+	CompleteFacilityCycle(context.Context, string, FacilityStatus, string, time.Duration) error
+}
+
+// Auditor - records every mutation made through the service.
+type Auditor interface {
+	Record(ctx context.Context, action audit.Action, entityType string, entityID string, before any, after any) error
+}
+
+// Publisher - writes the domain events raised by the service
+// to the outbox for delivery to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, eventType events.Type, entityID string, topics []string, data any) error
+}
+
+// Service - runs the industrial layer: the recipes that turn
+// commodities into others and the facilities running them.
+type Service struct {
+	Store     Store
+	Auditor   Auditor
+	Publisher Publisher
+}
+
+// NewService - returns a pointer to a new service
+func NewService(store Store, auditor Auditor, publisher Publisher) *Service {
+	return &Service{
+		Store:     store,
+		Auditor:   auditor,
+		Publisher: publisher,
+	}
+}
+
+func (s *Service) FindAllRecipes(ctx context.Context, pagination data.Pagination, filter RecipeFilter) ([]Recipe, error) {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	recipes, err := s.Store.GetRecipesByPagination(ctx, pagination, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recipes by pagination: %w", err)
+	}
+
+	return recipes, nil
+}
+
+func (s *Service) FindRecipe(ctx context.Context, id string) (Recipe, error) {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationRead); err != nil {
+		return Recipe{}, err
+	}
+
+	return s.Store.GetRecipeById(ctx, id)
+}
+
+func (s *Service) CreateRecipe(ctx context.Context, recipe Recipe) (Recipe, error) {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationCreate); err != nil {
+		return Recipe{}, err
+	}
+
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	if err := recipe.validate(); err != nil {
+		return Recipe{}, err
+	}
+
+	var newRecipe Recipe
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newRecipe, err = s.Store.CreateRecipe(ctx, recipe)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityRecipe, newRecipe.ID, nil, newRecipe); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.RecipeCreated, newRecipe.ID, recipeTopics(newRecipe), newRecipe)
+	})
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	return newRecipe, nil
+}
+
+// UpdateRecipe - replaces the recipe's name, cycle time, inputs and
+// outputs. Facilities running it pick the change up from their next
+// cycle.
+func (s *Service) UpdateRecipe(ctx context.Context, recipe Recipe) (Recipe, error) {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationUpdate); err != nil {
+		return Recipe{}, err
+	}
+
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	if err := recipe.validate(); err != nil {
+		return Recipe{}, err
+	}
+
+	var updatedRecipe Recipe
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := s.Store.GetRecipeById(ctx, recipe.ID)
+		if err != nil {
+			return err
+		}
+
+		updatedRecipe, err = s.Store.UpdateRecipe(ctx, recipe)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityRecipe, recipe.ID, existing, updatedRecipe); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.RecipeUpdated, recipe.ID, recipeTopics(updatedRecipe), updatedRecipe)
+	})
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	return updatedRecipe, nil
+}
+
+// RemoveRecipe - deletes the recipe outright, refusing while any
+// facility runs it.
+func (s *Service) RemoveRecipe(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceRecipe, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		recipe, err := s.Store.GetRecipeById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveRecipe(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityRecipe, id, recipe, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.RecipeRemoved, id, recipeTopics(recipe), recipe)
+	})
+}
+
+func recipeTopics(recipe Recipe) []string {
+	topics := []string{events.Topic(events.TopicRecipe, recipe.ID)}
+	for _, component := range append(append([]Component{}, recipe.Inputs...), recipe.Outputs...) {
+		topics = append(topics, events.Topic(events.TopicCommodity, component.CommodityID))
+	}
+	return topics
+}
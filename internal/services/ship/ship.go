@@ -0,0 +1,212 @@
+package ship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+var (
+	ErrShipNotFound = errors.New("ship not found")
+	// ErrShipConflict - another ship already has the name.
+	ErrShipConflict = errors.New("a ship with this name already exists")
+	ErrInvalidShip  = errors.New("invalid ship")
+	// ErrShipReferenceNotFound - the solar system a ship is placed in
+	// or sent to does not exist or has been deleted.
+	ErrShipReferenceNotFound = errors.New("solar system not found")
+	// ErrShipInTransit - the ship is between solar systems, so it can
+	// neither leave again nor trade until it arrives.
+	ErrShipInTransit = errors.New("ship is in transit")
+)
+
+// Ship - a vessel docked in a solar system, or in transit from it to
+// its destination. Speed is in light years per hour.
+type Ship struct {
+	ID              string
+	Name            string
+	SolarSystemID   string
+	SolarSystemName string
+	Speed           float64
+	DestinationID   string
+	DestinationName string
+	DepartedAt      *time.Time
+	ArrivesAt       *time.Time
+	Cargo           []Cargo
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// InTransit - the ship has left its solar system and not yet arrived
+// at its destination.
+func (s Ship) InTransit() bool {
+	return s.DestinationID != ""
+}
+
+// Cargo - the units of a commodity a ship carries.
+type Cargo struct {
+	CommodityID   string
+	CommodityName string
+	Quantity      int
+}
+
+func (s Ship) validate() error {
+	if s.Name == "" || len(s.Name) > 255 {
+		return fmt.Errorf("%w: the name must be between 1 and 255 characters", ErrInvalidShip)
+	}
+
+	if s.SolarSystemID == "" {
+		return fmt.Errorf("%w: a ship starts in a solar system", ErrInvalidShip)
+	}
+
+	if !(s.Speed > 0) || math.IsInf(s.Speed, 0) {
+		return fmt.Errorf("%w: the speed must be a positive number of light years per hour", ErrInvalidShip)
+	}
+
+	return nil
+}
+
+// Store - this interface defines all methods
+// our service needs to operate. Every method called with
+// the context handed to a WithTx callback runs inside
+// that callback's transaction.
+type Store interface {
+	WithTx(context.Context, func(context.Context) error) error
+	GetShipsByPagination(context.Context, data.Pagination) ([]Ship, error)
+	GetShipById(context.Context, string) (Ship, error)
+	LockShip(context.Context, string) (Ship, error)
+	CreateShip(context.Context, Ship) (Ship, error)
+	RemoveShip(context.Context, string) error
+	GetSolarSystemsByIds(context.Context, []string, bool) ([]solarSystem.SolarSystem, error)
+	GetCommodityMarketById(context.Context, string, bool) (solarSystem.CommodityMarket, error)
+	DepartShip(context.Context, string, string, time.Duration) (Ship, error)
+	ClaimArrivals(context.Context, int) ([]Ship, error)
+	CompleteArrival(context.Context, string) (Ship, error)
+	AdjustMarketStock(context.Context, string, int) error
+	SetShipCargo(context.Context, string, string, int) error
+}
+
+// Auditor - records every mutation made through the service.
+type Auditor interface {
+	Record(ctx context.Context, action audit.Action, entityType string, entityID string, before any, after any) error
+}
+
+// Publisher - writes the domain events raised by the service
+// to the outbox for delivery to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, eventType events.Type, entityID string, topics []string, data any) error
+}
+
+// Service - moves ships between solar systems and trades their cargo
+// with the markets of the system they are docked in.
+type Service struct {
+	Store     Store
+	Auditor   Auditor
+	Publisher Publisher
+}
+
+// NewService - returns a pointer to a new service
+func NewService(store Store, auditor Auditor, publisher Publisher) *Service {
+	return &Service{
+		Store:     store,
+		Auditor:   auditor,
+		Publisher: publisher,
+	}
+}
+
+func (s *Service) FindAllShips(ctx context.Context, pagination data.Pagination) ([]Ship, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	ships, err := s.Store.GetShipsByPagination(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ships by pagination: %w", err)
+	}
+
+	return ships, nil
+}
+
+func (s *Service) FindShip(ctx context.Context, id string) (Ship, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationRead); err != nil {
+		return Ship{}, err
+	}
+
+	return s.Store.GetShipById(ctx, id)
+}
+
+// CreateShip - places a new ship, with an empty hold, in a live solar
+// system.
+func (s *Service) CreateShip(ctx context.Context, ship Ship) (Ship, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationCreate); err != nil {
+		return Ship{}, err
+	}
+
+	ship.Name = strings.TrimSpace(ship.Name)
+	if err := ship.validate(); err != nil {
+		return Ship{}, err
+	}
+
+	var newShip Ship
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newShip, err = s.Store.CreateShip(ctx, ship)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityShip, newShip.ID, nil, newShip); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.ShipCreated, newShip.ID, shipTopics(newShip), newShip)
+	})
+	if err != nil {
+		return Ship{}, err
+	}
+
+	return newShip, nil
+}
+
+// RemoveShip - deletes the ship and its cargo outright, wherever it is.
+func (s *Service) RemoveShip(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		ship, err := s.Store.LockShip(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveShip(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityShip, id, ship, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.ShipRemoved, id, shipTopics(ship), ship)
+	})
+}
+
+func shipTopics(ship Ship) []string {
+	topics := []string{
+		events.Topic(events.TopicShip, ship.ID),
+		events.Topic(events.TopicSolarSystem, ship.SolarSystemID),
+	}
+	if ship.InTransit() {
+		topics = append(topics, events.Topic(events.TopicSolarSystem, ship.DestinationID))
+	}
+	return topics
+}
@@ -0,0 +1,112 @@
+package ship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+var (
+	ErrInvalidTrade = errors.New("invalid trade")
+	// ErrMarketNotInSolarSystem - ships only trade with the markets of
+	// the solar system they are docked in, at any of its stations.
+	ErrMarketNotInSolarSystem = errors.New("the market is not in the ship's solar system")
+	ErrInsufficientStock      = errors.New("the market does not hold enough stock")
+	ErrInsufficientCargo      = errors.New("the ship does not carry enough cargo")
+)
+
+// Trade - moves quantity units of the market's commodity from its
+// stock into the ship's hold, or from the hold back into the market
+// when quantity is negative. Ships in transit can't trade. Legality
+// rules don't apply, as a market opened before its commodity was
+// banned keeps trading.
+func (s *Service) Trade(ctx context.Context, id string, commodityMarketId string, quantity int) (Ship, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationUpdate); err != nil {
+		return Ship{}, err
+	}
+
+	if commodityMarketId == "" || quantity == 0 {
+		return Ship{}, fmt.Errorf("%w: a trade names a commodityMarketId and a quantity other than 0", ErrInvalidTrade)
+	}
+
+	var tradedShip Ship
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		ship, err := s.Store.LockShip(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ship.InTransit() {
+			return ErrShipInTransit
+		}
+
+		market, err := s.Store.GetCommodityMarketById(ctx, commodityMarketId, false)
+		if err != nil {
+			return err
+		}
+
+		if market.SolarSystemID != ship.SolarSystemID {
+			return ErrMarketNotInSolarSystem
+		}
+
+		var held int
+		for _, cargo := range ship.Cargo {
+			if cargo.CommodityID == market.CommodityID {
+				held = cargo.Quantity
+			}
+		}
+
+		if held+quantity < 0 {
+			return fmt.Errorf("%w: carries %d of %s", ErrInsufficientCargo, held, market.CommodityName)
+		}
+
+		if err := s.Store.AdjustMarketStock(ctx, market.ID, -quantity); err != nil {
+			return err
+		}
+
+		if err := s.Store.SetShipCargo(ctx, id, market.CommodityID, held+quantity); err != nil {
+			return err
+		}
+
+		tradedMarket, err := s.Store.GetCommodityMarketById(ctx, market.ID, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityCommodityMarket, market.ID, market, tradedMarket); err != nil {
+			return err
+		}
+
+		if err := s.Publisher.Publish(ctx, events.MarketUpdated, market.ID, solarSystem.MarketTopics(tradedMarket), tradedMarket); err != nil {
+			return err
+		}
+
+		tradedShip, err = s.Store.GetShipById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityShip, id, ship, tradedShip); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.ShipTraded, id, tradeTopics(tradedShip, market), tradedShip)
+	})
+	if err != nil {
+		return Ship{}, err
+	}
+
+	return tradedShip, nil
+}
+
+func tradeTopics(ship Ship, market solarSystem.CommodityMarket) []string {
+	return append(shipTopics(ship),
+		events.Topic(events.TopicMarket, market.ID),
+		events.Topic(events.TopicCommodity, market.CommodityID),
+	)
+}
@@ -0,0 +1,142 @@
+package ship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+// MaxTravelTime - the longest journey a ship may set out on.
+const MaxTravelTime = 365 * 24 * time.Hour
+
+var ErrInvalidTravel = errors.New("invalid travel")
+
+// TravelTime - how long a ship flying at speed light years per hour
+// takes to cover the distance between two solar systems.
+func TravelTime(from solarSystem.Coordinates, to solarSystem.Coordinates, speed float64) (time.Duration, error) {
+	hours := from.DistanceTo(to) / speed
+	if hours > MaxTravelTime.Hours() {
+		return 0, fmt.Errorf("%w: the journey would take longer than %s at the ship's speed", ErrInvalidTravel, MaxTravelTime)
+	}
+
+	return time.Duration(hours * float64(time.Hour)).Round(time.Second), nil
+}
+
+// Travel - sends a docked ship from its solar system to a live
+// destination. The ship is in transit from now until the arrival job
+// finds it has covered the distance at its speed.
+func (s *Service) Travel(ctx context.Context, id string, destinationId string) (Ship, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationUpdate); err != nil {
+		return Ship{}, err
+	}
+
+	if destinationId == "" {
+		return Ship{}, fmt.Errorf("%w: a destination solar system is required", ErrInvalidTravel)
+	}
+
+	var departedShip Ship
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		ship, err := s.Store.LockShip(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ship.InTransit() {
+			return ErrShipInTransit
+		}
+
+		if destinationId == ship.SolarSystemID {
+			return fmt.Errorf("%w: the ship is already in the destination solar system", ErrInvalidTravel)
+		}
+
+		// the ship may be docked in a system deleted since it arrived,
+		// and is still free to leave it
+		systems, err := s.Store.GetSolarSystemsByIds(ctx, []string{ship.SolarSystemID, destinationId}, true)
+		if err != nil {
+			return err
+		}
+
+		var origin, destination *solarSystem.SolarSystem
+		for i := range systems {
+			switch systems[i].ID {
+			case ship.SolarSystemID:
+				origin = &systems[i]
+			case destinationId:
+				destination = &systems[i]
+			}
+		}
+
+		if origin == nil || destination == nil || destination.DeletedAt != nil {
+			return ErrShipReferenceNotFound
+		}
+
+		travelTime, err := TravelTime(origin.Coordinates, destination.Coordinates, ship.Speed)
+		if err != nil {
+			return err
+		}
+
+		departedShip, err = s.Store.DepartShip(ctx, id, destinationId, travelTime)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityShip, id, ship, departedShip); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.ShipDeparted, id, shipTopics(departedShip), departedShip)
+	})
+	if err != nil {
+		return Ship{}, err
+	}
+
+	return departedShip, nil
+}
+
+// CompleteArrivals - docks up to limit ships whose arrival time has
+// passed in their destination, returning how many arrived. A ship
+// whose destination was deleted while it travelled still arrives.
+func (s *Service) CompleteArrivals(ctx context.Context, limit int) (int, error) {
+	if err := auth.Authorize(ctx, auth.ResourceShip, auth.OperationUpdate); err != nil {
+		return 0, err
+	}
+
+	var arrived int
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		ships, err := s.Store.ClaimArrivals(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, ship := range ships {
+			arrivedShip, err := s.Store.CompleteArrival(ctx, ship.ID)
+			if err != nil {
+				return err
+			}
+
+			if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityShip, ship.ID, ship, arrivedShip); err != nil {
+				return err
+			}
+
+			// published to the system the ship left as well as the one
+			// it arrived in
+			if err := s.Publisher.Publish(ctx, events.ShipArrived, ship.ID, shipTopics(ship), arrivedShip); err != nil {
+				return err
+			}
+		}
+
+		arrived = len(ships)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return arrived, nil
+}
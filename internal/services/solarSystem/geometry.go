@@ -0,0 +1,123 @@
+package solarSystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+const (
+	DefaultNeighbors = 5
+	MaxNeighbors     = 100
+)
+
+var (
+	ErrInvalidStarClass    = errors.New("invalid star class")
+	ErrInvalidCoordinates  = errors.New("coordinates must be finite numbers")
+	ErrInvalidSpatialQuery = errors.New("invalid spatial query")
+)
+
+// Coordinates - a position in the galaxy, in light years from its
+// centre, with the galactic plane at Z = 0.
+type Coordinates struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+func (c Coordinates) DistanceTo(other Coordinates) float64 {
+	return math.Sqrt((c.X-other.X)*(c.X-other.X) + (c.Y-other.Y)*(c.Y-other.Y) + (c.Z-other.Z)*(c.Z-other.Z))
+}
+
+func (c Coordinates) validate() error {
+	for _, value := range []float64{c.X, c.Y, c.Z} {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return ErrInvalidCoordinates
+		}
+	}
+	return nil
+}
+
+// StarClass - the Morgan-Keenan spectral class of a solar system's
+// star, from the hottest, O, to the coolest, M. Empty when unknown.
+type StarClass string
+
+const (
+	StarClassUnknown StarClass = ""
+	StarClassO       StarClass = "O"
+	StarClassB       StarClass = "B"
+	StarClassA       StarClass = "A"
+	StarClassF       StarClass = "F"
+	StarClassG       StarClass = "G"
+	StarClassK       StarClass = "K"
+	StarClassM       StarClass = "M"
+)
+
+var StarClasses = []StarClass{StarClassO, StarClassB, StarClassA, StarClassF, StarClassG, StarClassK, StarClassM}
+
+func ParseStarClass(class string) (StarClass, error) {
+	if class == string(StarClassUnknown) {
+		return StarClassUnknown, nil
+	}
+
+	for _, known := range StarClasses {
+		if class == string(known) {
+			return known, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidStarClass, class)
+}
+
+// Validate - checks the fields of a solar system that can't be
+// checked by the database before it is written.
+func (s SolarSystem) Validate() error {
+	if _, err := ParseStarClass(string(s.StarClass)); err != nil {
+		return err
+	}
+
+	return s.Coordinates.validate()
+}
+
+// NearbySolarSystem - a solar system and how far it is, in light
+// years, from the point or solar system it was found around.
+type NearbySolarSystem struct {
+	SolarSystem
+	Distance float64
+}
+
+// FindNearbySolarSystems - the live solar systems within radius of the
+// centre, nearest first.
+func (s *Service) FindNearbySolarSystems(ctx context.Context, centre Coordinates, radius float64, pagination data.Pagination) ([]NearbySolarSystem, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if err := centre.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpatialQuery, err)
+	}
+
+	if math.IsNaN(radius) || math.IsInf(radius, 0) || radius <= 0 {
+		return nil, fmt.Errorf("%w: radius must be a positive number", ErrInvalidSpatialQuery)
+	}
+
+	return s.Store.GetSolarSystemsNearby(ctx, centre, radius, pagination)
+}
+
+// FindNeighbors - the k live solar systems nearest to the solar
+// system, nearest first.
+func (s *Service) FindNeighbors(ctx context.Context, id string, k int) ([]NearbySolarSystem, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if k < 1 || k > MaxNeighbors {
+		return nil, fmt.Errorf("%w: k must be between 1 and %d", ErrInvalidSpatialQuery, MaxNeighbors)
+	}
+
+	return s.Store.GetSolarSystemNeighbors(ctx, id, k)
+}
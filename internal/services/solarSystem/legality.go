@@ -0,0 +1,233 @@
+package solarSystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+)
+
+var (
+	ErrLegalityRuleNotFound = errors.New("legality rule not found")
+	// ErrLegalityRuleConflict - the solar system already has a rule for the commodity or category.
+	ErrLegalityRuleConflict = errors.New("a legality rule for this commodity or category already exists in this solar system")
+	ErrInvalidLegalityRule  = errors.New("invalid legality rule")
+	// ErrLegalityReferenceNotFound - the solar system or commodity a
+	// rule covers does not exist or has been deleted.
+	ErrLegalityReferenceNotFound = errors.New("solar system or commodity not found")
+	// ErrCommodityBanned - a market can't be opened for a commodity
+	// the solar system bans.
+	ErrCommodityBanned = errors.New("commodity is banned in this solar system")
+)
+
+type LegalityStatus string
+
+const (
+	LegalityBanned LegalityStatus = "banned"
+	// LegalityTaxed - trading the commodity pays TaxRate of the price.
+	LegalityTaxed LegalityStatus = "taxed"
+	// LegalityLicensed - trading the commodity needs a license.
+	LegalityLicensed LegalityStatus = "licensed"
+)
+
+var LegalityStatuses = []LegalityStatus{LegalityBanned, LegalityTaxed, LegalityLicensed}
+
+// LegalityRule - how a solar system treats a single commodity, named
+// by CommodityID, or every commodity of a Category. A commodity's own
+// rule wins over its category's, and a commodity without either is
+// legal.
+type LegalityRule struct {
+	ID            string
+	SolarSystemID string
+	CommodityID   string
+	Category      commodity.Category
+	Status        LegalityStatus
+	// TaxRate - the fraction of the price paid in tax, between 0 and
+	// 1, for taxed commodities only.
+	TaxRate float64
+}
+
+type LegalityRuleUpdate struct {
+	Status  LegalityStatus
+	TaxRate float64
+}
+
+func (u LegalityRuleUpdate) validate() error {
+	switch u.Status {
+	case LegalityTaxed:
+		if !(u.TaxRate > 0 && u.TaxRate <= 1) {
+			return fmt.Errorf("%w: a taxed commodity needs a tax rate above 0 and at most 1", ErrInvalidLegalityRule)
+		}
+		return nil
+	case LegalityBanned, LegalityLicensed:
+		if u.TaxRate != 0 {
+			return fmt.Errorf("%w: only a taxed commodity has a tax rate", ErrInvalidLegalityRule)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown status %q", ErrInvalidLegalityRule, u.Status)
+}
+
+func (r LegalityRule) validate() error {
+	if (r.CommodityID == "") == (r.Category == commodity.CategoryNone) {
+		return fmt.Errorf("%w: a rule covers either a commodity or a category", ErrInvalidLegalityRule)
+	}
+
+	if _, err := commodity.ParseCategory(string(r.Category)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLegalityRule, err)
+	}
+
+	return LegalityRuleUpdate{Status: r.Status, TaxRate: r.TaxRate}.validate()
+}
+
+func (s *Service) FindLegalityRules(ctx context.Context, solarSystemId string) ([]LegalityRule, error) {
+	if err := auth.Authorize(ctx, auth.ResourceLegalityRule, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSolarSystem(ctx, solarSystemId, false); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetLegalityRulesBySolarSystemId(ctx, solarSystemId)
+}
+
+func (s *Service) CreateLegalityRule(ctx context.Context, rule LegalityRule) (LegalityRule, error) {
+	if err := auth.Authorize(ctx, auth.ResourceLegalityRule, auth.OperationCreate); err != nil {
+		return LegalityRule{}, err
+	}
+
+	if err := rule.validate(); err != nil {
+		return LegalityRule{}, err
+	}
+
+	var newRule LegalityRule
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newRule, err = s.Store.CreateLegalityRule(ctx, rule)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityLegalityRule, newRule.ID, nil, newRule); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.LegalityRuleCreated, newRule.ID, legalityRuleTopics(newRule), newRule)
+	})
+	if err != nil {
+		return LegalityRule{}, err
+	}
+
+	return newRule, nil
+}
+
+func (s *Service) UpdateLegalityRule(ctx context.Context, solarSystemId string, id string, update LegalityRuleUpdate) (LegalityRule, error) {
+	if err := auth.Authorize(ctx, auth.ResourceLegalityRule, auth.OperationUpdate); err != nil {
+		return LegalityRule{}, err
+	}
+
+	if err := update.validate(); err != nil {
+		return LegalityRule{}, err
+	}
+
+	var updatedRule LegalityRule
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		rule, err := s.getLegalityRuleInSolarSystem(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		updatedRule, err = s.Store.UpdateLegalityRule(ctx, solarSystemId, id, update)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityLegalityRule, id, rule, updatedRule); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.LegalityRuleUpdated, id, legalityRuleTopics(updatedRule), updatedRule)
+	})
+	if err != nil {
+		return LegalityRule{}, err
+	}
+
+	return updatedRule, nil
+}
+
+// RemoveLegalityRule - deletes the rule outright, making what it
+// covered legal again. Rules are configuration and aren't restorable.
+func (s *Service) RemoveLegalityRule(ctx context.Context, solarSystemId string, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceLegalityRule, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		rule, err := s.getLegalityRuleInSolarSystem(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveLegalityRule(ctx, solarSystemId, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityLegalityRule, id, rule, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.LegalityRuleRemoved, id, legalityRuleTopics(rule), rule)
+	})
+}
+
+func (s *Service) getLegalityRuleInSolarSystem(ctx context.Context, solarSystemId string, id string) (LegalityRule, error) {
+	rule, err := s.Store.GetLegalityRuleById(ctx, id)
+	if err != nil {
+		return LegalityRule{}, err
+	}
+
+	if rule.SolarSystemID != solarSystemId {
+		return LegalityRule{}, ErrLegalityRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// LegalityReader - reads the rule that decides how a solar system
+// treats a commodity.
+type LegalityReader interface {
+	GetEffectiveLegalityRule(context.Context, string, string) (LegalityRule, error)
+}
+
+// CheckNotBanned - refuses a new market for a commodity the solar
+// system bans, by its own rule or its category's. Every path that
+// opens a market, the universe import included, goes through it.
+func CheckNotBanned(ctx context.Context, store LegalityReader, solarSystemId string, commodityId string) error {
+	rule, err := store.GetEffectiveLegalityRule(ctx, solarSystemId, commodityId)
+	if errors.Is(err, ErrLegalityRuleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if rule.Status == LegalityBanned {
+		return fmt.Errorf("%w: %s", ErrCommodityBanned, commodityId)
+	}
+
+	return nil
+}
+
+func legalityRuleTopics(rule LegalityRule) []string {
+	topics := []string{events.Topic(events.TopicSolarSystem, rule.SolarSystemID)}
+	if rule.CommodityID != "" {
+		topics = append(topics, events.Topic(events.TopicCommodity, rule.CommodityID))
+	}
+	return topics
+}
@@ -3,32 +3,89 @@ package solarSystem
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
 )
 
 var (
 	ErrFindingSolarSystem  = errors.New("failed to find solar system by id")
 	ErrSolarSystemNotFound = errors.New("solar system not found")
+	// ErrSolarSystemConflict - another live solar system already has the name.
+	ErrSolarSystemConflict = errors.New("a solar system with this name already exists")
 	ErrNotImplemented      = errors.New("not implemented")
+
+	ErrCommodityMarketNotFound = errors.New("commodity market not found")
+	ErrCommodityMarketConflict = errors.New("commodity is already traded in this solar system")
+	// ErrMarketReferenceNotFound - the solar system, station or commodity
+	// a market points at does not exist or has been deleted.
+	ErrMarketReferenceNotFound = errors.New("solar system, station or commodity not found")
+	// ErrDuplicateCommodity - a list of markets trades a commodity twice.
+	ErrDuplicateCommodity = errors.New("commodity is listed more than once")
 )
 
 type SolarSystem struct {
-	ID   string
-	Name string
+	ID          string
+	Name        string
+	Coordinates Coordinates
+	StarClass   StarClass
+	Region      string
+	Sector      string
+	DeletedAt   *time.Time
 }
 
 type SolarSystemWithCommodityMarkets struct {
 	ID               string
 	Name             string
+	Coordinates      Coordinates
+	StarClass        StarClass
+	Region           string
+	Sector           string
+	DeletedAt        *time.Time
 	CommodityMarkets []CommodityMarket
 }
 
+// CommodityMarket - a commodity traded in a solar system, at one of
+// its stations or, when StationID is empty, at the system level.
 type CommodityMarket struct {
 	ID             string
+	SolarSystemID  string
+	StationID      string
+	StationName    string
+	CommodityID    string
 	BasePrice      float64
 	DemandQuantity int
-	CommodityName  string
+	// Stock - the units the market holds, changed only by the
+	// production of the facilities trading through it.
+	Stock               int
+	CommodityName       string
+	CommodityUnitMass   float64
+	CommodityUnitVolume float64
+	SolarSystemName     string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+}
+
+// Filter - narrows the solar systems returned by a listing.
+type Filter struct {
+	IncludeDeleted bool
+}
+
+// MarketFilter - narrows the markets returned by a listing across
+// solar systems. Unset fields don't narrow it.
+type MarketFilter struct {
+	CommodityID    string
+	SolarSystemID  string
+	StationID      string
+	MinPrice       *float64
+	MaxPrice       *float64
+	MinDemand      *int
+	IncludeDeleted bool
 }
 
 type CommodityMarketUpdate struct {
@@ -36,28 +93,114 @@ type CommodityMarketUpdate struct {
 	DemandQuantity int
 }
 
+// CommodityMarketUpsert - one market of the full list a solar system
+// should trade, identified by its commodity.
+type CommodityMarketUpsert struct {
+	CommodityID    string
+	BasePrice      float64
+	DemandQuantity int
+}
+
+type MarketAction string
+
+const (
+	MarketActionCreated   MarketAction = "created"
+	MarketActionUpdated   MarketAction = "updated"
+	MarketActionUnchanged MarketAction = "unchanged"
+	MarketActionDeleted   MarketAction = "deleted"
+)
+
+// CommodityMarketResult - what replacing a solar system's markets
+// did to one of them.
+type CommodityMarketResult struct {
+	Action          MarketAction
+	CommodityMarket CommodityMarket
+}
+
+// MarketPriceChange - the data of a MarketPriceChanged event.
+type MarketPriceChange struct {
+	CommodityMarket
+	PreviousBasePrice float64
+}
+
+// Store - every method called with the context handed to a
+// WithTx callback runs inside that callback's transaction.
 type Store interface {
-	GetSolarSystemById(context.Context, string) (SolarSystemWithCommodityMarkets, error)
-	GetSolarSystemsByPagination(context.Context, data.Pagination) ([]SolarSystem, error)
+	WithTx(context.Context, func(context.Context) error) error
+	GetSolarSystemById(context.Context, string, bool) (SolarSystemWithCommodityMarkets, error)
+	GetSolarSystemsByPagination(context.Context, data.Pagination, Filter) ([]SolarSystem, error)
+	GetSolarSystemsByIds(context.Context, []string, bool) ([]SolarSystem, error)
+	GetSolarSystemsNearby(context.Context, Coordinates, float64, data.Pagination) ([]NearbySolarSystem, error)
+	GetSolarSystemNeighbors(context.Context, string, int) ([]NearbySolarSystem, error)
 	CreateSolarSystem(context.Context, SolarSystem) (SolarSystem, error)
 	RemoveSolarSystem(context.Context, string) error
-	GetCommodityMarketsBySolarSystemId(context.Context, string) ([]CommodityMarket, error)
-	CreateCommodityMarket(context.Context, string, float64, int, string) (CommodityMarket, error)
-	RemoveCommodityMarket(context.Context, string) error
-	UpdateCommodityMarket(context.Context, string, CommodityMarketUpdate) (CommodityMarket, error)
+	RestoreSolarSystem(context.Context, string) (SolarSystemWithCommodityMarkets, error)
+	GetCommodityMarketsBySolarSystemId(context.Context, string, bool) ([]CommodityMarket, error)
+	GetCommodityMarketsBySolarSystemIds(context.Context, []string, bool) ([]CommodityMarket, error)
+	GetCommodityMarketsByCommodityIds(context.Context, []string, bool) ([]CommodityMarket, error)
+	GetCommodityMarketsByPagination(context.Context, data.Pagination, MarketFilter) ([]CommodityMarket, error)
+	GetCommodityMarketById(context.Context, string, bool) (CommodityMarket, error)
+	CreateCommodityMarket(context.Context, string, string, float64, int, string) (CommodityMarket, error)
+	RemoveCommodityMarket(context.Context, string, string) error
+	RestoreCommodityMarket(context.Context, string, string) (CommodityMarket, error)
+	UpdateCommodityMarket(context.Context, string, string, CommodityMarketUpdate) (CommodityMarket, error)
+	UpsertCommodityMarket(context.Context, string, string, float64, int) (CommodityMarket, bool, error)
 	RemoveAllCommodityMarketsBySolarSystemId(context.Context, string) error
+	RemoveAllCommodityMarketsByStationId(context.Context, string) error
+	GetCelestialBodiesBySolarSystemId(context.Context, string, bool) ([]CelestialBody, error)
+	GetCelestialBodyById(context.Context, string, bool) (CelestialBody, error)
+	CreateCelestialBody(context.Context, CelestialBody) (CelestialBody, error)
+	RemoveCelestialBody(context.Context, string, string) error
+	RestoreCelestialBody(context.Context, string, string) (CelestialBody, error)
+	RemoveAllCelestialBodiesBySolarSystemId(context.Context, string) error
+	GetStationsBySolarSystemId(context.Context, string, bool) ([]Station, error)
+	GetStationById(context.Context, string, bool) (StationWithCommodityMarkets, error)
+	CreateStation(context.Context, Station) (Station, error)
+	UpdateStation(context.Context, string, string, StationUpdate) (Station, error)
+	RemoveStation(context.Context, string, string) error
+	RestoreStation(context.Context, string, string) (StationWithCommodityMarkets, error)
+	RemoveAllStationsBySolarSystemId(context.Context, string) error
+	GetLegalityRulesBySolarSystemId(context.Context, string) ([]LegalityRule, error)
+	GetLegalityRuleById(context.Context, string) (LegalityRule, error)
+	GetEffectiveLegalityRule(context.Context, string, string) (LegalityRule, error)
+	CreateLegalityRule(context.Context, LegalityRule) (LegalityRule, error)
+	UpdateLegalityRule(context.Context, string, string, LegalityRuleUpdate) (LegalityRule, error)
+	RemoveLegalityRule(context.Context, string, string) error
+}
+
+// Auditor - records every mutation made through the service.
+type Auditor interface {
+	Record(ctx context.Context, action audit.Action, entityType string, entityID string, before any, after any) error
+}
+
+// Publisher - writes the domain events raised by the service
+// to the outbox for delivery to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, eventType events.Type, entityID string, topics []string, data any) error
 }
 
 type Service struct {
-	Store Store
+	Store     Store
+	Auditor   Auditor
+	Publisher Publisher
 }
 
-func NewService(store Store) *Service {
-	return &Service{Store: store}
+func NewService(store Store, auditor Auditor, publisher Publisher) *Service {
+	return &Service{Store: store, Auditor: auditor, Publisher: publisher}
 }
 
-func (s *Service) FindSolarSystem(ctx context.Context, id string) (SolarSystemWithCommodityMarkets, error) {
-	solarSystem, err := s.Store.GetSolarSystemById(ctx, id)
+func (s *Service) FindSolarSystem(ctx context.Context, id string, includeDeleted bool) (SolarSystemWithCommodityMarkets, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRead); err != nil {
+		return SolarSystemWithCommodityMarkets{}, err
+	}
+
+	if includeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationReadDeleted); err != nil {
+			return SolarSystemWithCommodityMarkets{}, err
+		}
+	}
+
+	solarSystem, err := s.Store.GetSolarSystemById(ctx, id, includeDeleted)
 	if err != nil {
 		return SolarSystemWithCommodityMarkets{}, err
 	}
@@ -65,8 +208,18 @@ func (s *Service) FindSolarSystem(ctx context.Context, id string) (SolarSystemWi
 	return solarSystem, nil
 }
 
-func (s *Service) FindAllSolarSystems(ctx context.Context, pagination data.Pagination) ([]SolarSystem, error) {
-	solarSystems, err := s.Store.GetSolarSystemsByPagination(ctx, pagination)
+func (s *Service) FindAllSolarSystems(ctx context.Context, pagination data.Pagination, filter Filter) ([]SolarSystem, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if filter.IncludeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationReadDeleted); err != nil {
+			return nil, err
+		}
+	}
+
+	solarSystems, err := s.Store.GetSolarSystemsByPagination(ctx, pagination, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +227,113 @@ func (s *Service) FindAllSolarSystems(ctx context.Context, pagination data.Pagin
 	return solarSystems, nil
 }
 
+// FindSolarSystemsByIds - fetches many solar systems at once, for
+// callers resolving the solar systems of a set of markets.
+func (s *Service) FindSolarSystemsByIds(ctx context.Context, ids []string, includeDeleted bool) ([]SolarSystem, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if includeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationReadDeleted); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Store.GetSolarSystemsByIds(ctx, ids, includeDeleted)
+}
+
+// FindCommodityMarketsBySolarSystemIds - fetches the markets of many
+// solar systems at once. Callers group them by SolarSystemID.
+func (s *Service) FindCommodityMarketsBySolarSystemIds(ctx context.Context, solarSystemIds []string, includeDeleted bool) ([]CommodityMarket, error) {
+	if err := s.authorizeMarketRead(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetCommodityMarketsBySolarSystemIds(ctx, solarSystemIds, includeDeleted)
+}
+
+// FindCommodityMarketsByCommodityIds - fetches the markets trading many
+// commodities at once. Callers group them by CommodityID.
+func (s *Service) FindCommodityMarketsByCommodityIds(ctx context.Context, commodityIds []string, includeDeleted bool) ([]CommodityMarket, error) {
+	if err := s.authorizeMarketRead(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetCommodityMarketsByCommodityIds(ctx, commodityIds, includeDeleted)
+}
+
+// FindAllCommodityMarkets - lists the markets of every solar system,
+// so the prices of a commodity can be compared across the galaxy.
+func (s *Service) FindAllCommodityMarkets(ctx context.Context, pagination data.Pagination, filter MarketFilter) ([]CommodityMarket, error) {
+	if err := s.authorizeMarketRead(ctx, filter.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetCommodityMarketsByPagination(ctx, pagination, filter)
+}
+
+// FindCommodityMarket - fetches a market by its id alone, for callers
+// that need to learn which solar system it belongs to.
+func (s *Service) FindCommodityMarket(ctx context.Context, id string, includeDeleted bool) (CommodityMarket, error) {
+	if err := s.authorizeMarketRead(ctx, includeDeleted); err != nil {
+		return CommodityMarket{}, err
+	}
+
+	return s.Store.GetCommodityMarketById(ctx, id, includeDeleted)
+}
+
+// getCommodityMarketInSolarSystem - a market of another solar system
+// is reported as not found, so a market can't be reached through a
+// solar system it does not belong to.
+func (s *Service) getCommodityMarketInSolarSystem(ctx context.Context, solarSystemId string, id string, includeDeleted bool) (CommodityMarket, error) {
+	market, err := s.Store.GetCommodityMarketById(ctx, id, includeDeleted)
+	if err != nil {
+		return CommodityMarket{}, err
+	}
+
+	if market.SolarSystemID != solarSystemId {
+		return CommodityMarket{}, ErrCommodityMarketNotFound
+	}
+
+	return market, nil
+}
+
+func (s *Service) authorizeMarketRead(ctx context.Context, includeDeleted bool) error {
+	if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationRead); err != nil {
+		return err
+	}
+
+	if includeDeleted {
+		return auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationReadDeleted)
+	}
+
+	return nil
+}
+
 func (s *Service) CreateSolarSystem(ctx context.Context, solarSystem SolarSystem) (SolarSystem, error) {
-	newSolarSystem, err := s.Store.CreateSolarSystem(ctx, solarSystem)
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationCreate); err != nil {
+		return SolarSystem{}, err
+	}
+
+	if err := solarSystem.Validate(); err != nil {
+		return SolarSystem{}, err
+	}
+
+	var newSolarSystem SolarSystem
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newSolarSystem, err = s.Store.CreateSolarSystem(ctx, solarSystem)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntitySolarSystem, newSolarSystem.ID, nil, newSolarSystem); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.SolarSystemCreated, newSolarSystem.ID, solarSystemTopics(newSolarSystem.ID), newSolarSystem)
+	})
 	if err != nil {
 		return SolarSystem{}, err
 	}
@@ -84,7 +342,71 @@ func (s *Service) CreateSolarSystem(ctx context.Context, solarSystem SolarSystem
 }
 
 func (s *Service) RemoveSolarSystem(ctx context.Context, id string) error {
-	err := s.Store.RemoveSolarSystem(ctx, id)
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		removedSolarSystem, err := s.Store.GetSolarSystemById(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveAllCommodityMarketsBySolarSystemId(ctx, id); err != nil {
+			return fmt.Errorf("error removing commodity markets: %w", err)
+		}
+
+		for _, market := range removedSolarSystem.CommodityMarkets {
+			if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCommodityMarket, market.ID, market, nil); err != nil {
+				return err
+			}
+			if err := s.Publisher.Publish(ctx, events.MarketRemoved, market.ID, MarketTopics(market), market); err != nil {
+				return err
+			}
+		}
+
+		// stations and bodies go with the solar system, and come back
+		// with it when it is restored
+		stations, err := s.Store.GetStationsBySolarSystemId(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveAllStationsBySolarSystemId(ctx, id); err != nil {
+			return fmt.Errorf("error removing stations: %w", err)
+		}
+
+		for _, station := range stations {
+			if err := s.recordStationRemoved(ctx, StationWithCommodityMarkets{Station: station}); err != nil {
+				return err
+			}
+		}
+
+		bodies, err := s.Store.GetCelestialBodiesBySolarSystemId(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveAllCelestialBodiesBySolarSystemId(ctx, id); err != nil {
+			return fmt.Errorf("error removing celestial bodies: %w", err)
+		}
+
+		for _, body := range bodies {
+			if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCelestialBody, body.ID, body, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := s.Store.RemoveSolarSystem(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntitySolarSystem, id, removedSolarSystem, nil); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.SolarSystemRemoved, id, solarSystemTopics(id), removedSolarSystem)
+	})
 	if err != nil {
 		return err
 	}
@@ -92,8 +414,27 @@ func (s *Service) RemoveSolarSystem(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *Service) CreateCommodityMarket(ctx context.Context, solarSystemId string, basePrice float64, demandQuantity int, commodityId string) (CommodityMarket, error) {
-	newCommodityMarket, err := s.Store.CreateCommodityMarket(ctx, solarSystemId, basePrice, demandQuantity, commodityId)
+// CreateCommodityMarket - opens a market at the station, or at the
+// solar system level when stationId is empty.
+func (s *Service) CreateCommodityMarket(ctx context.Context, solarSystemId string, stationId string, basePrice float64, demandQuantity int, commodityId string) (CommodityMarket, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationCreate); err != nil {
+		return CommodityMarket{}, err
+	}
+
+	var newCommodityMarket CommodityMarket
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		if err := CheckNotBanned(ctx, s.Store, solarSystemId, commodityId); err != nil {
+			return err
+		}
+
+		var err error
+		newCommodityMarket, err = s.Store.CreateCommodityMarket(ctx, solarSystemId, stationId, basePrice, demandQuantity, commodityId)
+		if err != nil {
+			return err
+		}
+
+		return s.recordMarketCreated(ctx, newCommodityMarket)
+	})
 	if err != nil {
 		return CommodityMarket{}, err
 	}
@@ -101,8 +442,23 @@ func (s *Service) CreateCommodityMarket(ctx context.Context, solarSystemId strin
 	return newCommodityMarket, nil
 }
 
-func (s *Service) RemoveCommodityMarket(ctx context.Context, id string) error {
-	err := s.Store.RemoveCommodityMarket(ctx, id)
+func (s *Service) RemoveCommodityMarket(ctx context.Context, solarSystemId string, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		removedCommodityMarket, err := s.getCommodityMarketInSolarSystem(ctx, solarSystemId, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveCommodityMarket(ctx, solarSystemId, id); err != nil {
+			return err
+		}
+
+		return s.recordMarketRemoved(ctx, removedCommodityMarket)
+	})
 	if err != nil {
 		return err
 	}
@@ -110,11 +466,337 @@ func (s *Service) RemoveCommodityMarket(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *Service) UpdateCommodityMarket(ctx context.Context, commodityMarketId string, commodityMarketUpdate CommodityMarketUpdate) (CommodityMarket, error) {
-	updatedCommodityMarket, err := s.Store.UpdateCommodityMarket(ctx, commodityMarketId, commodityMarketUpdate)
+func (s *Service) UpdateCommodityMarket(ctx context.Context, solarSystemId string, commodityMarketId string, commodityMarketUpdate CommodityMarketUpdate) (CommodityMarket, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationUpdate); err != nil {
+		return CommodityMarket{}, err
+	}
+
+	var updatedCommodityMarket CommodityMarket
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		commodityMarket, err := s.getCommodityMarketInSolarSystem(ctx, solarSystemId, commodityMarketId, false)
+		if err != nil {
+			return err
+		}
+
+		updatedCommodityMarket, err = s.Store.UpdateCommodityMarket(ctx, solarSystemId, commodityMarketId, commodityMarketUpdate)
+		if err != nil {
+			return err
+		}
+
+		return s.recordMarketUpdated(ctx, commodityMarket, updatedCommodityMarket)
+	})
 	if err != nil {
 		return CommodityMarket{}, err
 	}
 
 	return updatedCommodityMarket, nil
 }
+
+// ReplaceCommodityMarkets - makes the live system level markets of the
+// solar system match the list as a single unit of work: listed markets
+// are created or updated, and those of commodities the list leaves out
+// are removed. Markets at its stations are left alone. Results follow
+// the order of the list, then the removals.
+func (s *Service) ReplaceCommodityMarkets(ctx context.Context, solarSystemId string, upserts []CommodityMarketUpsert) ([]CommodityMarketResult, error) {
+	for _, operation := range []auth.Operation{auth.OperationCreate, auth.OperationUpdate, auth.OperationDelete} {
+		if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, operation); err != nil {
+			return nil, err
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, upsert := range upserts {
+		if listed[upsert.CommodityID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCommodity, upsert.CommodityID)
+		}
+		listed[upsert.CommodityID] = true
+	}
+
+	var results []CommodityMarketResult
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		results = nil
+
+		system, err := s.Store.GetSolarSystemById(ctx, solarSystemId, false)
+		if err != nil {
+			return err
+		}
+
+		var systemMarkets []CommodityMarket
+		for _, market := range system.CommodityMarkets {
+			if market.StationID == "" {
+				systemMarkets = append(systemMarkets, market)
+			}
+		}
+
+		existing := map[string]CommodityMarket{}
+		for _, market := range systemMarkets {
+			existing[market.CommodityID] = market
+		}
+
+		for _, upsert := range upserts {
+			previous, found := existing[upsert.CommodityID]
+			if found && previous.BasePrice == upsert.BasePrice && previous.DemandQuantity == upsert.DemandQuantity {
+				results = append(results, CommodityMarketResult{Action: MarketActionUnchanged, CommodityMarket: previous})
+				continue
+			}
+
+			// markets listed before a ban are left to trade on
+			if !found {
+				if err := CheckNotBanned(ctx, s.Store, solarSystemId, upsert.CommodityID); err != nil {
+					return err
+				}
+			}
+
+			market, created, err := s.Store.UpsertCommodityMarket(ctx, solarSystemId, upsert.CommodityID, upsert.BasePrice, upsert.DemandQuantity)
+			if err != nil {
+				return fmt.Errorf("commodity %s: %w", upsert.CommodityID, err)
+			}
+
+			if created {
+				results = append(results, CommodityMarketResult{Action: MarketActionCreated, CommodityMarket: market})
+				if err := s.recordMarketCreated(ctx, market); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// a market created by someone else since the read above
+			// is updated too, and has no known previous state
+			if !found {
+				previous = market
+			}
+
+			results = append(results, CommodityMarketResult{Action: MarketActionUpdated, CommodityMarket: market})
+			if err := s.recordMarketUpdated(ctx, previous, market); err != nil {
+				return err
+			}
+		}
+
+		for _, market := range systemMarkets {
+			if listed[market.CommodityID] {
+				continue
+			}
+
+			if err := s.Store.RemoveCommodityMarket(ctx, solarSystemId, market.ID); err != nil {
+				return err
+			}
+
+			results = append(results, CommodityMarketResult{Action: MarketActionDeleted, CommodityMarket: market})
+			if err := s.recordMarketRemoved(ctx, market); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *Service) recordMarketCreated(ctx context.Context, market CommodityMarket) error {
+	if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityCommodityMarket, market.ID, nil, market); err != nil {
+		return err
+	}
+
+	return s.Publisher.Publish(ctx, events.MarketCreated, market.ID, MarketTopics(market), market)
+}
+
+// recordMarketUpdated - a change of price raises MarketPriceChanged,
+// any other change MarketUpdated.
+func (s *Service) recordMarketUpdated(ctx context.Context, previous CommodityMarket, market CommodityMarket) error {
+	if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityCommodityMarket, market.ID, previous, market); err != nil {
+		return err
+	}
+
+	if market.BasePrice != previous.BasePrice {
+		return s.Publisher.Publish(ctx, events.MarketPriceChanged, market.ID, MarketTopics(market), MarketPriceChange{
+			CommodityMarket:   market,
+			PreviousBasePrice: previous.BasePrice,
+		})
+	}
+
+	return s.Publisher.Publish(ctx, events.MarketUpdated, market.ID, MarketTopics(market), market)
+}
+
+// recordMarketsRestored - records the markets a restore brought back,
+// those live now that were deleted before it, against their deleted
+// state.
+func (s *Service) recordMarketsRestored(ctx context.Context, before []CommodityMarket, after []CommodityMarket) error {
+	beforeById := map[string]CommodityMarket{}
+	for _, market := range before {
+		beforeById[market.ID] = market
+	}
+
+	for _, market := range after {
+		deleted, ok := beforeById[market.ID]
+		if !ok || deleted.DeletedAt == nil {
+			continue
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCommodityMarket, market.ID, deleted, market); err != nil {
+			return err
+		}
+		if err := s.Publisher.Publish(ctx, events.MarketRestored, market.ID, MarketTopics(market), market); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) recordMarketRemoved(ctx context.Context, market CommodityMarket) error {
+	if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCommodityMarket, market.ID, market, nil); err != nil {
+		return err
+	}
+
+	return s.Publisher.Publish(ctx, events.MarketRemoved, market.ID, MarketTopics(market), market)
+}
+
+// RestoreSolarSystem - undoes a soft delete, bringing back the
+// markets, stations and bodies that were removed along with the
+// solar system.
+func (s *Service) RestoreSolarSystem(ctx context.Context, id string) (SolarSystemWithCommodityMarkets, error) {
+	if err := auth.Authorize(ctx, auth.ResourceSolarSystem, auth.OperationRestore); err != nil {
+		return SolarSystemWithCommodityMarkets{}, err
+	}
+
+	var restoredSolarSystem SolarSystemWithCommodityMarkets
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		deletedSolarSystem, err := s.Store.GetSolarSystemById(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		deletedStations, err := s.Store.GetStationsBySolarSystemId(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		deletedBodies, err := s.Store.GetCelestialBodiesBySolarSystemId(ctx, id, true)
+		if err != nil {
+			return err
+		}
+
+		restoredSolarSystem, err = s.Store.RestoreSolarSystem(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.recordMarketsRestored(ctx, deletedSolarSystem.CommodityMarkets, restoredSolarSystem.CommodityMarkets); err != nil {
+			return err
+		}
+
+		stations, err := s.Store.GetStationsBySolarSystemId(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		deletedStationsById := map[string]Station{}
+		for _, station := range deletedStations {
+			deletedStationsById[station.ID] = station
+		}
+
+		for _, station := range stations {
+			deleted, ok := deletedStationsById[station.ID]
+			if !ok || deleted.DeletedAt == nil {
+				continue
+			}
+
+			if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityStation, station.ID, deleted, station); err != nil {
+				return err
+			}
+			if err := s.Publisher.Publish(ctx, events.StationRestored, station.ID, stationTopics(station), StationWithCommodityMarkets{Station: station}); err != nil {
+				return err
+			}
+		}
+
+		bodies, err := s.Store.GetCelestialBodiesBySolarSystemId(ctx, id, false)
+		if err != nil {
+			return err
+		}
+
+		deletedBodiesById := map[string]CelestialBody{}
+		for _, body := range deletedBodies {
+			deletedBodiesById[body.ID] = body
+		}
+
+		for _, body := range bodies {
+			deleted, ok := deletedBodiesById[body.ID]
+			if !ok || deleted.DeletedAt == nil {
+				continue
+			}
+
+			if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCelestialBody, body.ID, deleted, body); err != nil {
+				return err
+			}
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntitySolarSystem, id, deletedSolarSystem, restoredSolarSystem); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.SolarSystemRestored, id, solarSystemTopics(id), restoredSolarSystem)
+	})
+	if err != nil {
+		return SolarSystemWithCommodityMarkets{}, err
+	}
+
+	return restoredSolarSystem, nil
+}
+
+func (s *Service) RestoreCommodityMarket(ctx context.Context, solarSystemId string, id string) (CommodityMarket, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCommodityMarket, auth.OperationRestore); err != nil {
+		return CommodityMarket{}, err
+	}
+
+	var restoredCommodityMarket CommodityMarket
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		deletedCommodityMarket, err := s.getCommodityMarketInSolarSystem(ctx, solarSystemId, id, true)
+		if err != nil {
+			return err
+		}
+
+		// the commodity may have been banned since the market was deleted
+		if err := CheckNotBanned(ctx, s.Store, solarSystemId, deletedCommodityMarket.CommodityID); err != nil {
+			return err
+		}
+
+		restoredCommodityMarket, err = s.Store.RestoreCommodityMarket(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCommodityMarket, id, deletedCommodityMarket, restoredCommodityMarket); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.MarketRestored, id, MarketTopics(restoredCommodityMarket), restoredCommodityMarket)
+	})
+	if err != nil {
+		return CommodityMarket{}, err
+	}
+
+	return restoredCommodityMarket, nil
+}
+
+func solarSystemTopics(id string) []string {
+	return []string{events.Topic(events.TopicSolarSystem, id)}
+}
+
+// MarketTopics - the topics a market's events are published to: the
+// market, its solar system, its commodity and, for one trading at a
+// station, the station.
+func MarketTopics(market CommodityMarket) []string {
+	topics := []string{
+		events.Topic(events.TopicMarket, market.ID),
+		events.Topic(events.TopicSolarSystem, market.SolarSystemID),
+		events.Topic(events.TopicCommodity, market.CommodityID),
+	}
+	if market.StationID != "" {
+		topics = append(topics, events.Topic(events.TopicStation, market.StationID))
+	}
+	return topics
+}
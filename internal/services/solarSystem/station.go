@@ -0,0 +1,481 @@
+package solarSystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+)
+
+var (
+	ErrCelestialBodyNotFound = errors.New("celestial body not found")
+	// ErrCelestialBodyConflict - another live body of the solar system already has the name.
+	ErrCelestialBodyConflict = errors.New("a celestial body with this name already exists in this solar system")
+	// ErrCelestialBodyInUse - live bodies orbit the body or live stations are placed at it.
+	ErrCelestialBodyInUse   = errors.New("celestial body has live bodies or stations placed at it")
+	ErrInvalidCelestialBody = errors.New("invalid celestial body")
+
+	ErrStationNotFound = errors.New("station not found")
+	// ErrStationConflict - another live station of the solar system already has the name.
+	ErrStationConflict = errors.New("a station with this name already exists in this solar system")
+	ErrInvalidStation  = errors.New("invalid station")
+
+	// ErrBodyReferenceNotFound - the solar system, or the celestial body
+	// a body orbits or a station is placed at, does not exist, has been
+	// deleted or belongs to another solar system.
+	ErrBodyReferenceNotFound = errors.New("solar system or celestial body not found")
+)
+
+type CelestialBodyKind string
+
+const (
+	CelestialBodyPlanet       CelestialBodyKind = "planet"
+	CelestialBodyMoon         CelestialBodyKind = "moon"
+	CelestialBodyAsteroidBelt CelestialBodyKind = "asteroidBelt"
+)
+
+var CelestialBodyKinds = []CelestialBodyKind{CelestialBodyPlanet, CelestialBodyMoon, CelestialBodyAsteroidBelt}
+
+// CelestialBody - a planet, moon or asteroid belt of a solar system.
+// Moons orbit a planet, named by ParentID; everything else orbits the
+// star and has no parent.
+type CelestialBody struct {
+	ID            string
+	SolarSystemID string
+	ParentID      string
+	Name          string
+	Kind          CelestialBodyKind
+	DeletedAt     *time.Time
+}
+
+func (b CelestialBody) validate() error {
+	switch b.Kind {
+	case CelestialBodyMoon:
+		if b.ParentID == "" {
+			return fmt.Errorf("%w: a moon must orbit a planet", ErrInvalidCelestialBody)
+		}
+		return nil
+	case CelestialBodyPlanet, CelestialBodyAsteroidBelt:
+		if b.ParentID != "" {
+			return fmt.Errorf("%w: a %s orbits the star", ErrInvalidCelestialBody, b.Kind)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown kind %q", ErrInvalidCelestialBody, b.Kind)
+}
+
+type StationKind string
+
+const (
+	// StationKindStation - an orbital station, around a body or in deep space.
+	StationKindStation StationKind = "station"
+	// StationKindOutpost - a settlement on the surface of a body.
+	StationKindOutpost StationKind = "outpost"
+)
+
+var StationKinds = []StationKind{StationKindStation, StationKindOutpost}
+
+// Station - a place within a solar system that markets can be
+// attached to, placed at the celestial body named by CelestialBodyID,
+// or in deep space when it is empty.
+type Station struct {
+	ID              string
+	SolarSystemID   string
+	CelestialBodyID string
+	Name            string
+	Kind            StationKind
+	DeletedAt       *time.Time
+}
+
+func (s Station) validate() error {
+	switch s.Kind {
+	case StationKindStation:
+		return nil
+	case StationKindOutpost:
+		if s.CelestialBodyID == "" {
+			return fmt.Errorf("%w: an outpost must be placed on a celestial body", ErrInvalidStation)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown kind %q", ErrInvalidStation, s.Kind)
+}
+
+type StationWithCommodityMarkets struct {
+	Station
+	CommodityMarkets []CommodityMarket
+}
+
+type StationUpdate struct {
+	Name            string
+	Kind            StationKind
+	CelestialBodyID string
+}
+
+// checkSolarSystem - checks the solar system exists before listing
+// what it holds, so an empty list isn't mistaken for a missing one.
+func (s *Service) checkSolarSystem(ctx context.Context, id string, includeDeleted bool) error {
+	systems, err := s.Store.GetSolarSystemsByIds(ctx, []string{id}, includeDeleted)
+	if err != nil {
+		return err
+	}
+
+	if len(systems) == 0 {
+		return ErrSolarSystemNotFound
+	}
+
+	return nil
+}
+
+// checkCelestialBody - a body of another solar system is reported as
+// not found, and one that isn't of the kinds given as invalid.
+func (s *Service) checkCelestialBody(ctx context.Context, solarSystemId string, id string, kinds ...CelestialBodyKind) error {
+	body, err := s.Store.GetCelestialBodyById(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, ErrCelestialBodyNotFound) {
+			return ErrBodyReferenceNotFound
+		}
+		return err
+	}
+
+	if body.SolarSystemID != solarSystemId {
+		return ErrBodyReferenceNotFound
+	}
+
+	for _, kind := range kinds {
+		if body.Kind == kind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: nothing of this kind can be placed at %s %q", ErrInvalidCelestialBody, body.Kind, body.Name)
+}
+
+func (s *Service) getCelestialBodyInSolarSystem(ctx context.Context, solarSystemId string, id string, includeDeleted bool) (CelestialBody, error) {
+	body, err := s.Store.GetCelestialBodyById(ctx, id, includeDeleted)
+	if err != nil {
+		return CelestialBody{}, err
+	}
+
+	if body.SolarSystemID != solarSystemId {
+		return CelestialBody{}, ErrCelestialBodyNotFound
+	}
+
+	return body, nil
+}
+
+func (s *Service) getStationInSolarSystem(ctx context.Context, solarSystemId string, id string, includeDeleted bool) (StationWithCommodityMarkets, error) {
+	station, err := s.Store.GetStationById(ctx, id, includeDeleted)
+	if err != nil {
+		return StationWithCommodityMarkets{}, err
+	}
+
+	if station.SolarSystemID != solarSystemId {
+		return StationWithCommodityMarkets{}, ErrStationNotFound
+	}
+
+	return station, nil
+}
+
+func (s *Service) FindCelestialBodies(ctx context.Context, solarSystemId string, includeDeleted bool) ([]CelestialBody, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCelestialBody, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if includeDeleted {
+		if err := auth.Authorize(ctx, auth.ResourceCelestialBody, auth.OperationReadDeleted); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkSolarSystem(ctx, solarSystemId, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetCelestialBodiesBySolarSystemId(ctx, solarSystemId, includeDeleted)
+}
+
+func (s *Service) CreateCelestialBody(ctx context.Context, body CelestialBody) (CelestialBody, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCelestialBody, auth.OperationCreate); err != nil {
+		return CelestialBody{}, err
+	}
+
+	if err := body.validate(); err != nil {
+		return CelestialBody{}, err
+	}
+
+	var newBody CelestialBody
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		if body.ParentID != "" {
+			if err := s.checkCelestialBody(ctx, body.SolarSystemID, body.ParentID, CelestialBodyPlanet); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		newBody, err = s.Store.CreateCelestialBody(ctx, body)
+		if err != nil {
+			return err
+		}
+
+		return s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityCelestialBody, newBody.ID, nil, newBody)
+	})
+	if err != nil {
+		return CelestialBody{}, err
+	}
+
+	return newBody, nil
+}
+
+// RemoveCelestialBody - a body can only be removed once nothing
+// orbits it and no station is placed at it.
+func (s *Service) RemoveCelestialBody(ctx context.Context, solarSystemId string, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceCelestialBody, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		removedBody, err := s.getCelestialBodyInSolarSystem(ctx, solarSystemId, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveCelestialBody(ctx, solarSystemId, id); err != nil {
+			return err
+		}
+
+		return s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityCelestialBody, id, removedBody, nil)
+	})
+}
+
+func (s *Service) RestoreCelestialBody(ctx context.Context, solarSystemId string, id string) (CelestialBody, error) {
+	if err := auth.Authorize(ctx, auth.ResourceCelestialBody, auth.OperationRestore); err != nil {
+		return CelestialBody{}, err
+	}
+
+	var restoredBody CelestialBody
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		deletedBody, err := s.getCelestialBodyInSolarSystem(ctx, solarSystemId, id, true)
+		if err != nil {
+			return err
+		}
+
+		restoredBody, err = s.Store.RestoreCelestialBody(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		return s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityCelestialBody, id, deletedBody, restoredBody)
+	})
+	if err != nil {
+		return CelestialBody{}, err
+	}
+
+	return restoredBody, nil
+}
+
+func (s *Service) FindStations(ctx context.Context, solarSystemId string, includeDeleted bool) ([]Station, error) {
+	if err := s.authorizeStationRead(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSolarSystem(ctx, solarSystemId, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return s.Store.GetStationsBySolarSystemId(ctx, solarSystemId, includeDeleted)
+}
+
+func (s *Service) FindStation(ctx context.Context, solarSystemId string, id string, includeDeleted bool) (StationWithCommodityMarkets, error) {
+	if err := s.authorizeStationRead(ctx, includeDeleted); err != nil {
+		return StationWithCommodityMarkets{}, err
+	}
+
+	return s.getStationInSolarSystem(ctx, solarSystemId, id, includeDeleted)
+}
+
+func (s *Service) authorizeStationRead(ctx context.Context, includeDeleted bool) error {
+	if err := auth.Authorize(ctx, auth.ResourceStation, auth.OperationRead); err != nil {
+		return err
+	}
+
+	if includeDeleted {
+		return auth.Authorize(ctx, auth.ResourceStation, auth.OperationReadDeleted)
+	}
+
+	return nil
+}
+
+func (s *Service) CreateStation(ctx context.Context, station Station) (Station, error) {
+	if err := auth.Authorize(ctx, auth.ResourceStation, auth.OperationCreate); err != nil {
+		return Station{}, err
+	}
+
+	if err := station.validate(); err != nil {
+		return Station{}, err
+	}
+
+	var newStation Station
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		if station.CelestialBodyID != "" {
+			if err := s.checkCelestialBody(ctx, station.SolarSystemID, station.CelestialBodyID, stationBodyKinds(station.Kind)...); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		newStation, err = s.Store.CreateStation(ctx, station)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionCreate, audit.EntityStation, newStation.ID, nil, newStation); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.StationCreated, newStation.ID, stationTopics(newStation), newStation)
+	})
+	if err != nil {
+		return Station{}, err
+	}
+
+	return newStation, nil
+}
+
+func (s *Service) UpdateStation(ctx context.Context, solarSystemId string, id string, update StationUpdate) (Station, error) {
+	if err := auth.Authorize(ctx, auth.ResourceStation, auth.OperationUpdate); err != nil {
+		return Station{}, err
+	}
+
+	candidate := Station{SolarSystemID: solarSystemId, CelestialBodyID: update.CelestialBodyID, Name: update.Name, Kind: update.Kind}
+	if err := candidate.validate(); err != nil {
+		return Station{}, err
+	}
+
+	var updatedStation Station
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		station, err := s.getStationInSolarSystem(ctx, solarSystemId, id, false)
+		if err != nil {
+			return err
+		}
+
+		if update.CelestialBodyID != "" {
+			if err := s.checkCelestialBody(ctx, solarSystemId, update.CelestialBodyID, stationBodyKinds(update.Kind)...); err != nil {
+				return err
+			}
+		}
+
+		updatedStation, err = s.Store.UpdateStation(ctx, solarSystemId, id, update)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityStation, id, station.Station, updatedStation); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.StationUpdated, id, stationTopics(updatedStation), updatedStation)
+	})
+	if err != nil {
+		return Station{}, err
+	}
+
+	return updatedStation, nil
+}
+
+// RemoveStation - soft deletes the station along with its markets,
+// which come back when it is restored.
+func (s *Service) RemoveStation(ctx context.Context, solarSystemId string, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceStation, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	return s.Store.WithTx(ctx, func(ctx context.Context) error {
+		removedStation, err := s.getStationInSolarSystem(ctx, solarSystemId, id, false)
+		if err != nil {
+			return err
+		}
+
+		if err := s.Store.RemoveAllCommodityMarketsByStationId(ctx, id); err != nil {
+			return fmt.Errorf("error removing commodity markets: %w", err)
+		}
+
+		for _, market := range removedStation.CommodityMarkets {
+			if err := s.recordMarketRemoved(ctx, market); err != nil {
+				return err
+			}
+		}
+
+		if err := s.Store.RemoveStation(ctx, solarSystemId, id); err != nil {
+			return err
+		}
+
+		return s.recordStationRemoved(ctx, removedStation)
+	})
+}
+
+func (s *Service) recordStationRemoved(ctx context.Context, station StationWithCommodityMarkets) error {
+	if err := s.Auditor.Record(ctx, audit.ActionDelete, audit.EntityStation, station.ID, station, nil); err != nil {
+		return err
+	}
+
+	return s.Publisher.Publish(ctx, events.StationRemoved, station.ID, stationTopics(station.Station), station)
+}
+
+// RestoreStation - undoes a soft delete, bringing back the markets
+// that were removed along with the station.
+func (s *Service) RestoreStation(ctx context.Context, solarSystemId string, id string) (StationWithCommodityMarkets, error) {
+	if err := auth.Authorize(ctx, auth.ResourceStation, auth.OperationRestore); err != nil {
+		return StationWithCommodityMarkets{}, err
+	}
+
+	var restoredStation StationWithCommodityMarkets
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		deletedStation, err := s.getStationInSolarSystem(ctx, solarSystemId, id, true)
+		if err != nil {
+			return err
+		}
+
+		restoredStation, err = s.Store.RestoreStation(ctx, solarSystemId, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.recordMarketsRestored(ctx, deletedStation.CommodityMarkets, restoredStation.CommodityMarkets); err != nil {
+			return err
+		}
+
+		if err := s.Auditor.Record(ctx, audit.ActionRestore, audit.EntityStation, id, deletedStation, restoredStation); err != nil {
+			return err
+		}
+
+		return s.Publisher.Publish(ctx, events.StationRestored, id, stationTopics(restoredStation.Station), restoredStation)
+	})
+	if err != nil {
+		return StationWithCommodityMarkets{}, err
+	}
+
+	return restoredStation, nil
+}
+
+// stationBodyKinds - the bodies a station of the kind can be placed
+// at: outposts are built on solid ground, stations orbit anything.
+func stationBodyKinds(kind StationKind) []CelestialBodyKind {
+	if kind == StationKindOutpost {
+		return []CelestialBodyKind{CelestialBodyPlanet, CelestialBodyMoon}
+	}
+
+	return CelestialBodyKinds
+}
+
+func stationTopics(station Station) []string {
+	return []string{
+		events.Topic(events.TopicStation, station.ID),
+		events.Topic(events.TopicSolarSystem, station.SolarSystemID),
+	}
+}
@@ -0,0 +1,308 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/jobs"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+)
+
+var (
+	ErrInvalidTopic = errors.New("invalid topic")
+)
+
+const (
+	DefaultPollInterval = 500 * time.Millisecond
+	// DefaultGapTimeout - how long the hub waits on a missing event id,
+	// which is either an outbox write not yet committed or one rolled back.
+	DefaultGapTimeout = 5 * time.Second
+	// DefaultBufferSize - events queued per subscriber. A subscriber that
+	// falls this far behind is closed and expected to resume by event id.
+	DefaultBufferSize = 256
+	pageSize          = 500
+)
+
+// topicResources - the resource a caller must be able to read
+// to subscribe to each kind of topic.
+var topicResources = map[string]auth.Resource{
+	events.TopicMarket:      auth.ResourceCommodityMarket,
+	events.TopicSolarSystem: auth.ResourceSolarSystem,
+	events.TopicCommodity:   auth.ResourceCommodity,
+	events.TopicStation:     auth.ResourceStation,
+	events.TopicRecipe:      auth.ResourceRecipe,
+	events.TopicShip:        auth.ResourceShip,
+}
+
+type Store interface {
+	GetLatestOutboxEventId(context.Context) (int64, error)
+	GetOutboxEventsAfter(context.Context, int64, []string, int) ([]events.Event, error)
+}
+
+// Service - fans committed outbox events out to live subscribers.
+// The outbox is polled rather than fed in-process so that every
+// server instance sees every event.
+type Service struct {
+	Store        Store
+	PollInterval time.Duration
+	GapTimeout   time.Duration
+	BufferSize   int
+
+	mu          sync.Mutex
+	lastID      int64
+	gapSince    time.Time
+	subscribers map[*Subscription]struct{}
+}
+
+func NewService(store Store) *Service {
+	return &Service{
+		Store:        store,
+		PollInterval: DefaultPollInterval,
+		GapTimeout:   DefaultGapTimeout,
+		BufferSize:   DefaultBufferSize,
+		subscribers:  map[*Subscription]struct{}{},
+	}
+}
+
+// Subscription - a live feed of the events published to any of its
+// topics. Events is closed when the subscription ends, whether by
+// Unsubscribe, shutdown, or the subscriber falling too far behind.
+type Subscription struct {
+	Events <-chan events.Event
+	// Replay - the events missed since the id the subscriber resumed
+	// from, to be sent before anything read from Events.
+	Replay []events.Event
+
+	events chan events.Event
+	mu     sync.RWMutex
+	topics map[string]struct{}
+}
+
+// ParseTopics - validates topics of the form "kind:id" and checks the
+// caller may read every entity they name.
+func ParseTopics(ctx context.Context, topics []string) ([]string, error) {
+	parsed := []string{}
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+
+		kind, id, found := strings.Cut(topic, ":")
+		resource, ok := topicResources[kind]
+		if !found || !ok || id == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+		}
+
+		if err := auth.Authorize(ctx, resource, auth.OperationRead); err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, topic)
+	}
+
+	return parsed, nil
+}
+
+// Subscribe - starts a subscription to the given topics, which may be
+// empty if topics are added later. When lastEventId
+// is set, the events after it that the subscriber missed are returned
+// in Replay, with no gap or overlap between Replay and Events.
+func (s *Service) Subscribe(ctx context.Context, topics []string, lastEventId *int64) (*Subscription, error) {
+	topics, err := ParseTopics(ctx, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		events: make(chan events.Event, s.BufferSize),
+		topics: map[string]struct{}{},
+	}
+	subscription.Events = subscription.events
+	subscription.Subscribe(topics)
+
+	s.mu.Lock()
+	s.subscribers[subscription] = struct{}{}
+	liveFrom := s.lastID
+	s.mu.Unlock()
+
+	if lastEventId != nil && *lastEventId < liveFrom {
+		replay, err := s.replay(ctx, topics, *lastEventId, liveFrom)
+		if err != nil {
+			s.Unsubscribe(subscription)
+			return nil, err
+		}
+		subscription.Replay = replay
+	}
+
+	return subscription, nil
+}
+
+func (s *Service) Unsubscribe(subscription *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(subscription)
+}
+
+// Close - ends every subscription, letting streaming requests finish
+// so that the server can shut down.
+func (s *Service) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscription := range s.subscribers {
+		s.remove(subscription)
+	}
+}
+
+// Start - polls the outbox until the context is cancelled. Only
+// events written after the service starts are delivered live.
+func (s *Service) Start(ctx context.Context) {
+	lastID, err := s.Store.GetLatestOutboxEventId(ctx)
+	if err != nil {
+		// without a starting point every retained event would be sent
+		// again, so the stream stays empty until the job is restarted
+		log.Printf("Error starting job stream: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.lastID = lastID
+	s.mu.Unlock()
+
+	jobs.RunPeriodically(ctx, "stream", s.PollInterval, s.poll)
+}
+
+func (s *Service) poll(ctx context.Context) error {
+	s.mu.Lock()
+	lastID := s.lastID
+	s.mu.Unlock()
+
+	outboxEvents, err := s.Store.GetOutboxEventsAfter(ctx, lastID, nil, pageSize)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range outboxEvents {
+		// ids are allocated before commit, so a missing id may still
+		// appear; hold the cursor there until it does or times out
+		if event.ID != s.lastID+1 {
+			if s.gapSince.IsZero() {
+				s.gapSince = time.Now()
+			}
+			if time.Since(s.gapSince) < s.GapTimeout {
+				break
+			}
+		}
+
+		s.gapSince = time.Time{}
+		s.lastID = event.ID
+		s.broadcast(event)
+	}
+
+	return nil
+}
+
+// broadcast - must be called with s.mu held.
+func (s *Service) broadcast(event events.Event) {
+	for subscription := range s.subscribers {
+		if !subscription.matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			s.remove(subscription)
+		}
+	}
+}
+
+// remove - must be called with s.mu held.
+func (s *Service) remove(subscription *Subscription) {
+	if _, ok := s.subscribers[subscription]; !ok {
+		return
+	}
+
+	delete(s.subscribers, subscription)
+	close(subscription.events)
+}
+
+func (s *Service) replay(ctx context.Context, topics []string, afterId int64, untilId int64) ([]events.Event, error) {
+	replay := []events.Event{}
+	for afterId < untilId {
+		page, err := s.Store.GetOutboxEventsAfter(ctx, afterId, topics, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("error replaying events: %w", err)
+		}
+
+		for _, event := range page {
+			if event.ID > untilId {
+				return replay, nil
+			}
+			replay = append(replay, event)
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+		afterId = page[len(page)-1].ID
+	}
+
+	return replay, nil
+}
+
+// Subscribe - adds topics to the subscription. Topics must already
+// have been checked with ParseTopics.
+func (s *Subscription) Subscribe(topics []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, topic := range topics {
+		s.topics[topic] = struct{}{}
+	}
+}
+
+func (s *Subscription) Unsubscribe(topics []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+}
+
+func (s *Subscription) Topics() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	topics := []string{}
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+
+	return topics
+}
+
+func (s *Subscription) matches(event events.Event) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, topic := range event.Topics {
+		if _, ok := s.topics[topic]; ok {
+			return true
+		}
+	}
+
+	return false
+}
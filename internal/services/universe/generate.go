@@ -0,0 +1,412 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+// MaxGeneratedSolarSystems - the most solar systems a single
+// generation may create.
+const MaxGeneratedSolarSystems = 10000
+
+var ErrInvalidGenerateConfig = errors.New("invalid generate config")
+
+type DistributionKind string
+
+const (
+	DistributionUniform DistributionKind = "uniform"
+	DistributionNormal  DistributionKind = "normal"
+	// DistributionLogNormal - Mean is the median of the distribution
+	// and StdDev the standard deviation of its logarithm, giving the
+	// long right tail prices tend to have.
+	DistributionLogNormal DistributionKind = "logNormal"
+)
+
+// Distribution - the values a generated quantity is drawn from. Every
+// draw is clamped to Min and Max, which is all a uniform draw reads.
+type Distribution struct {
+	Kind   DistributionKind
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+}
+
+func (d Distribution) validate(name string) error {
+	if d.Min < 0 || d.Max < d.Min {
+		return fmt.Errorf("%w: %s needs 0 <= min <= max", ErrInvalidGenerateConfig, name)
+	}
+
+	switch d.Kind {
+	case DistributionUniform:
+		return nil
+	case DistributionNormal, DistributionLogNormal:
+		if d.StdDev < 0 {
+			return fmt.Errorf("%w: %s has a negative stdDev", ErrInvalidGenerateConfig, name)
+		}
+		if d.Kind == DistributionLogNormal && d.Mean <= 0 {
+			return fmt.Errorf("%w: %s needs a positive mean", ErrInvalidGenerateConfig, name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s has unknown kind %q", ErrInvalidGenerateConfig, name, d.Kind)
+}
+
+func (d Distribution) sample(rng *rand.Rand) float64 {
+	var value float64
+	switch d.Kind {
+	case DistributionUniform:
+		value = d.Min + rng.Float64()*(d.Max-d.Min)
+	case DistributionNormal:
+		value = d.Mean + d.StdDev*rng.NormFloat64()
+	case DistributionLogNormal:
+		value = d.Mean * math.Exp(d.StdDev*rng.NormFloat64())
+	}
+
+	return math.Min(math.Max(value, d.Min), d.Max)
+}
+
+// GenerateConfig - what a generated universe is made of. The same
+// config always generates the same universe.
+type GenerateConfig struct {
+	Seed         int64
+	SolarSystems int
+	Commodities  int
+	// MinMarketsPerSystem and MaxMarketsPerSystem - each solar system
+	// trades a uniformly drawn number of distinct commodities, capped
+	// by the size of the catalog.
+	MinMarketsPerSystem int
+	MaxMarketsPerSystem int
+	BasePrice           Distribution
+	DemandQuantity      Distribution
+	// GalaxyRadius and GalaxyThickness - solar systems are scattered
+	// over a disc this many light years across its radius, thinning
+	// out from the core, and this thick through its plane.
+	GalaxyRadius    float64
+	GalaxyThickness float64
+}
+
+func DefaultGenerateConfig() GenerateConfig {
+	return GenerateConfig{
+		SolarSystems:        10,
+		Commodities:         20,
+		MinMarketsPerSystem: 3,
+		MaxMarketsPerSystem: 8,
+		BasePrice:           Distribution{Kind: DistributionLogNormal, Min: 1, Max: 10000, Mean: 100, StdDev: 0.8},
+		DemandQuantity:      Distribution{Kind: DistributionNormal, Min: 0, Max: 2000, Mean: 500, StdDev: 200},
+		GalaxyRadius:        1000,
+		GalaxyThickness:     50,
+	}
+}
+
+func (c GenerateConfig) validate() error {
+	if c.SolarSystems < 0 || c.SolarSystems > MaxGeneratedSolarSystems {
+		return fmt.Errorf("%w: solarSystems must be between 0 and %d", ErrInvalidGenerateConfig, MaxGeneratedSolarSystems)
+	}
+
+	if c.Commodities < 0 || c.Commodities > len(commodityNames) {
+		return fmt.Errorf("%w: commodities must be between 0 and %d", ErrInvalidGenerateConfig, len(commodityNames))
+	}
+
+	if c.MinMarketsPerSystem < 0 || c.MaxMarketsPerSystem < c.MinMarketsPerSystem {
+		return fmt.Errorf("%w: markets per system needs 0 <= min <= max", ErrInvalidGenerateConfig)
+	}
+
+	if !(c.GalaxyRadius > 0) || math.IsInf(c.GalaxyRadius, 0) || !(c.GalaxyThickness >= 0) || math.IsInf(c.GalaxyThickness, 0) {
+		return fmt.Errorf("%w: galaxy needs a positive radius and a thickness of at least 0", ErrInvalidGenerateConfig)
+	}
+
+	if err := c.BasePrice.validate("basePrice"); err != nil {
+		return err
+	}
+
+	return c.DemandQuantity.validate("demandQuantity")
+}
+
+// commodityKind - a kind of trade good, with the density of its bulk,
+// the range of volumes one unit of it is shipped in, and its category
+// and tags.
+type commodityKind struct {
+	Name      string
+	Density   float64 // kg/m³
+	MinVolume float64 // m³
+	MaxVolume float64 // m³
+	Category  commodity.Category
+	Tags      []string
+}
+
+var commodityKinds = []commodityKind{
+	{Name: "Water", Density: 1000, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryFood},
+	{Name: "Oxygen", Density: 1141, MinVolume: 0.2, MaxVolume: 1, Category: commodity.CategoryIndustrial},
+	{Name: "Hydrogen Fuel", Density: 71, MinVolume: 1, MaxVolume: 4, Category: commodity.CategoryFuel, Tags: []string{"flammable"}},
+	{Name: "Helium-3", Density: 125, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryFuel},
+	{Name: "Iron Ore", Density: 5000, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryOre},
+	{Name: "Copper", Density: 8960, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryRefinedMetal},
+	{Name: "Titanium", Density: 4500, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryRefinedMetal},
+	{Name: "Gold", Density: 19300, MinVolume: 0.01, MaxVolume: 0.05, Category: commodity.CategoryRefinedMetal, Tags: []string{"precious"}},
+	{Name: "Platinum", Density: 21450, MinVolume: 0.01, MaxVolume: 0.05, Category: commodity.CategoryRefinedMetal, Tags: []string{"precious"}},
+	{Name: "Uranium", Density: 19100, MinVolume: 0.01, MaxVolume: 0.1, Category: commodity.CategoryOre, Tags: []string{"radioactive"}},
+	{Name: "Silicon", Density: 2330, MinVolume: 0.1, MaxVolume: 1, Category: commodity.CategoryIndustrial},
+	{Name: "Polymers", Density: 950, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryIndustrial},
+	{Name: "Grain", Density: 760, MinVolume: 1, MaxVolume: 3, Category: commodity.CategoryFood, Tags: []string{"perishable"}},
+	{Name: "Livestock", Density: 1000, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryFood, Tags: []string{"perishable"}},
+	{Name: "Textiles", Density: 400, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryIndustrial},
+	{Name: "Medical Supplies", Density: 300, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryMedical},
+	{Name: "Electronics", Density: 600, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryTechnology},
+	{Name: "Machinery", Density: 2500, MinVolume: 1, MaxVolume: 4, Category: commodity.CategoryIndustrial},
+	{Name: "Robotics", Density: 1200, MinVolume: 0.5, MaxVolume: 2, Category: commodity.CategoryTechnology},
+	{Name: "Weapons", Density: 1800, MinVolume: 0.2, MaxVolume: 1, Category: commodity.CategoryWeapons, Tags: []string{"restricted"}},
+	{Name: "Luxury Goods", Density: 500, MinVolume: 0.05, MaxVolume: 0.3, Category: commodity.CategoryLuxury},
+	{Name: "Spices", Density: 550, MinVolume: 0.05, MaxVolume: 0.2, Category: commodity.CategoryLuxury, Tags: []string{"perishable"}},
+	{Name: "Antimatter", Density: 10, MinVolume: 0.01, MaxVolume: 0.05, Category: commodity.CategoryFuel, Tags: []string{"hazardous"}},
+	{Name: "Carbon Nanotubes", Density: 1300, MinVolume: 0.1, MaxVolume: 0.5, Category: commodity.CategoryTechnology},
+}
+
+// commodityQualities - qualify the kinds once a catalog needs more
+// commodities than there are kinds.
+var commodityQualities = []string{"Raw", "Refined", "Synthetic", "Compressed", "Industrial", "Premium"}
+
+var commodityNames = func() []string {
+	names := make([]string, 0, len(commodityKinds)*(len(commodityQualities)+1))
+	for _, kind := range commodityKinds {
+		names = append(names, kind.Name)
+	}
+	for _, quality := range commodityQualities {
+		for _, kind := range commodityKinds {
+			names = append(names, quality+" "+kind.Name)
+		}
+	}
+	return names
+}()
+
+var (
+	solarSystemSyllables = []string{
+		"al", "ar", "be", "ca", "de", "el", "fa", "ga", "ha", "is",
+		"jo", "ka", "lu", "ma", "ne", "on", "or", "pa", "qu", "ra",
+		"ri", "si", "ta", "ul", "um", "ve", "xa", "yo", "ze", "zu",
+	}
+	solarSystemSuffixes = []string{"Prime", "Major", "Minor", "Reach", "Gate", "Drift", "II", "III", "IV", "V"}
+)
+
+// starClassFrequencies - how common each class of star is among main
+// sequence stars, so generated systems are mostly dim red dwarfs.
+var starClassFrequencies = []struct {
+	Class     solarSystem.StarClass
+	Frequency float64
+}{
+	{Class: solarSystem.StarClassO, Frequency: 0.00003},
+	{Class: solarSystem.StarClassB, Frequency: 0.0013},
+	{Class: solarSystem.StarClassA, Frequency: 0.006},
+	{Class: solarSystem.StarClassF, Frequency: 0.03},
+	{Class: solarSystem.StarClassG, Frequency: 0.076},
+	{Class: solarSystem.StarClassK, Frequency: 0.121},
+	{Class: solarSystem.StarClassM, Frequency: 0.76567},
+}
+
+// galaxyRegions - the bands a galaxy's radius is split into, from the
+// core outwards, each an equal share of the radius.
+var galaxyRegions = []string{"Core", "Inner Rim", "Mid Rim", "Outer Rim"}
+
+// galaxySectors - the slices a galaxy is split into around its
+// centre, each an equal share of the full turn.
+var galaxySectors = []string{"Alpha", "Beta", "Gamma", "Delta", "Epsilon", "Zeta", "Eta", "Theta"}
+
+// Generator - a Source of a procedurally generated universe: a catalog
+// of commodities, then every solar system followed by its markets.
+// Everything is drawn from a single generator seeded by the config, so
+// the same config always yields the same records, and importing them
+// again leaves the universe unchanged.
+type Generator struct {
+	config           GenerateConfig
+	rng              *rand.Rand
+	commodities      []Record
+	solarSystemNames map[string]bool
+	pending          []Record
+}
+
+func NewGenerator(config GenerateConfig) (*Generator, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	g := &Generator{
+		config:           config,
+		rng:              rand.New(rand.NewSource(config.Seed)),
+		solarSystemNames: map[string]bool{},
+	}
+	g.commodities = g.generateCommodities()
+	g.pending = g.commodities
+
+	return g, nil
+}
+
+func (g *Generator) Next() (Record, error) {
+	for len(g.pending) == 0 {
+		if len(g.solarSystemNames) == g.config.SolarSystems {
+			return Record{}, io.EOF
+		}
+		g.pending = g.generateSolarSystem()
+	}
+
+	record := g.pending[0]
+	g.pending = g.pending[1:]
+	return record, nil
+}
+
+// generateCommodities - plain kinds come first, in a random order,
+// so qualified names only appear in the larger catalogs.
+func (g *Generator) generateCommodities() []Record {
+	kinds := len(commodityKinds)
+	order := make([]int, 0, len(commodityNames))
+	for _, i := range g.rng.Perm(kinds) {
+		order = append(order, i)
+	}
+	for _, i := range g.rng.Perm(len(commodityNames) - kinds) {
+		order = append(order, kinds+i)
+	}
+
+	commodities := make([]Record, 0, g.config.Commodities)
+	for _, i := range order[:g.config.Commodities] {
+		kind := commodityKinds[i%kinds]
+		volume := kind.MinVolume + g.rng.Float64()*(kind.MaxVolume-kind.MinVolume)
+		// the same kind varies a little in density between qualities
+		mass := volume * kind.Density * (0.9 + 0.2*g.rng.Float64())
+
+		// qualified names carry their quality as a tag too
+		tags := slices.Clone(kind.Tags)
+		if quality := i/kinds - 1; quality >= 0 {
+			tags = append(tags, strings.ToLower(commodityQualities[quality]))
+		}
+
+		commodities = append(commodities, Record{
+			Kind:       KindCommodity,
+			Name:       commodityNames[i],
+			UnitMass:   round(mass, 1),
+			UnitVolume: math.Max(round(volume, 2), 0.01),
+			Category:   kind.Category,
+			Tags:       tags,
+		})
+	}
+
+	return commodities
+}
+
+func (g *Generator) generateSolarSystem() []Record {
+	name := g.generateSolarSystemName()
+	coordinates := g.generateCoordinates()
+	records := []Record{{
+		Kind:        KindSolarSystem,
+		Name:        name,
+		Coordinates: coordinates,
+		StarClass:   g.generateStarClass(),
+		Region:      galaxyRegion(coordinates, g.config.GalaxyRadius),
+		Sector:      galaxySector(coordinates),
+	}}
+
+	markets := g.config.MinMarketsPerSystem + g.rng.Intn(g.config.MaxMarketsPerSystem-g.config.MinMarketsPerSystem+1)
+	markets = min(markets, len(g.commodities))
+	for _, i := range g.rng.Perm(len(g.commodities))[:markets] {
+		records = append(records, Record{
+			Kind:           KindMarket,
+			SolarSystem:    name,
+			Commodity:      g.commodities[i].Name,
+			BasePrice:      round(g.config.BasePrice.sample(g.rng), 2),
+			DemandQuantity: int(math.Round(g.config.DemandQuantity.sample(g.rng))),
+		})
+	}
+
+	return records
+}
+
+// generateSolarSystemName - two or three syllables, sometimes with a
+// suffix, numbered when the draws keep colliding with earlier names.
+func (g *Generator) generateSolarSystemName() string {
+	var name string
+	for attempt := 0; attempt < 10; attempt++ {
+		name = g.drawSolarSystemName()
+		if !g.solarSystemNames[name] {
+			g.solarSystemNames[name] = true
+			return name
+		}
+	}
+
+	for number := 2; ; number++ {
+		numbered := fmt.Sprintf("%s-%d", name, number)
+		if !g.solarSystemNames[numbered] {
+			g.solarSystemNames[numbered] = true
+			return numbered
+		}
+	}
+}
+
+func (g *Generator) drawSolarSystemName() string {
+	syllables := 2 + g.rng.Intn(2)
+	var builder strings.Builder
+	for i := 0; i < syllables; i++ {
+		builder.WriteString(solarSystemSyllables[g.rng.Intn(len(solarSystemSyllables))])
+	}
+
+	name := strings.ToUpper(builder.String()[:1]) + builder.String()[1:]
+	if g.rng.Intn(4) == 0 {
+		name += " " + solarSystemSuffixes[g.rng.Intn(len(solarSystemSuffixes))]
+	}
+
+	return name
+}
+
+// generateCoordinates - a point in the galaxy's disc. The distance
+// from the centre is drawn uniformly, which crowds systems towards the
+// core, and the height is drawn from a normal around the plane.
+func (g *Generator) generateCoordinates() solarSystem.Coordinates {
+	distance := g.rng.Float64() * g.config.GalaxyRadius
+	angle := g.rng.Float64() * 2 * math.Pi
+	height := g.rng.NormFloat64() * g.config.GalaxyThickness / 4
+	height = math.Min(math.Max(height, -g.config.GalaxyThickness/2), g.config.GalaxyThickness/2)
+
+	return solarSystem.Coordinates{
+		X: round(distance*math.Cos(angle), 2),
+		Y: round(distance*math.Sin(angle), 2),
+		Z: round(height, 2),
+	}
+}
+
+func (g *Generator) generateStarClass() solarSystem.StarClass {
+	draw := g.rng.Float64()
+	for _, class := range starClassFrequencies {
+		if draw < class.Frequency {
+			return class.Class
+		}
+		draw -= class.Frequency
+	}
+
+	return solarSystem.StarClassM
+}
+
+func galaxyRegion(coordinates solarSystem.Coordinates, radius float64) string {
+	band := int(math.Hypot(coordinates.X, coordinates.Y) / radius * float64(len(galaxyRegions)))
+	return galaxyRegions[min(band, len(galaxyRegions)-1)]
+}
+
+func galaxySector(coordinates solarSystem.Coordinates) string {
+	angle := math.Atan2(coordinates.Y, coordinates.X)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+
+	slice := int(angle / (2 * math.Pi) * float64(len(galaxySectors)))
+	return galaxySectors[min(slice, len(galaxySectors)-1)]
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,514 @@
+package universe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+var (
+	ErrInvalidRecord = errors.New("invalid record")
+
+	// errDryRun - rolls back the transaction of a dry run import
+	// once every record has been applied.
+	errDryRun = errors.New("dry run")
+)
+
+// Kind - the entity a record describes.
+type Kind string
+
+const (
+	KindCommodity   Kind = "commodity"
+	KindSolarSystem Kind = "solarSystem"
+	KindMarket      Kind = "market"
+)
+
+// Record - one entity of an import or export. Commodities and solar
+// systems are keyed by their name, and a market by the names of its
+// solar system and commodity, so a universe can be moved between
+// deployments whose ids differ. Only system level markets are
+// records; stations and their markets stay with the deployment.
+type Record struct {
+	Kind Kind
+	// Name - the name of a commodity or solar system.
+	Name       string
+	UnitMass   float64
+	UnitVolume float64
+	// Category and Tags - what kind of goods a commodity is.
+	Category commodity.Category
+	Tags     []string
+	// Coordinates, StarClass, Region and Sector - where a solar
+	// system is and what its star is like.
+	Coordinates solarSystem.Coordinates
+	StarClass   solarSystem.StarClass
+	Region      string
+	Sector      string
+	// SolarSystem and Commodity - the names a market references.
+	SolarSystem    string
+	Commodity      string
+	BasePrice      float64
+	DemandQuantity int
+}
+
+// Key - the natural key the record is matched on.
+func (r Record) Key() string {
+	if r.Kind == KindMarket {
+		return r.SolarSystem + "/" + r.Commodity
+	}
+	return r.Name
+}
+
+// RecordError - a record that could not be imported, numbered from
+// 1 in the order the source yielded it.
+type RecordError struct {
+	Number int
+	Err    error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Number, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Source - yields the records of an import one at a time, returning
+// io.EOF after the last, so an import never holds the universe in
+// memory. A market may only follow the records of the commodity and
+// solar system it references, unless those already exist.
+type Source interface {
+	Next() (Record, error)
+}
+
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+)
+
+// Change - a record that created or updated an entity.
+type Change struct {
+	Kind   Kind
+	Key    string
+	Action Action
+}
+
+type Counts struct {
+	Created   int
+	Updated   int
+	Unchanged int
+}
+
+func (c *Counts) add(action Action) {
+	switch action {
+	case ActionCreated:
+		c.Created++
+	case ActionUpdated:
+		c.Updated++
+	case ActionUnchanged:
+		c.Unchanged++
+	}
+}
+
+// Report - what an import changed or, for a dry run, would have.
+type Report struct {
+	DryRun       bool
+	Commodities  Counts
+	SolarSystems Counts
+	Markets      Counts
+	Changes      []Change
+}
+
+func (r *Report) add(kind Kind, key string, action Action) {
+	switch kind {
+	case KindCommodity:
+		r.Commodities.add(action)
+	case KindSolarSystem:
+		r.SolarSystems.add(action)
+	case KindMarket:
+		r.Markets.add(action)
+	}
+
+	if action != ActionUnchanged {
+		r.Changes = append(r.Changes, Change{Kind: kind, Key: key, Action: action})
+	}
+}
+
+// Store - every method called with the context handed to a
+// WithTx callback runs inside that callback's transaction.
+type Store interface {
+	WithTx(context.Context, func(context.Context) error) error
+	GetCommodityByName(context.Context, string) (commodity.Commodity, error)
+	CreateCommodity(context.Context, commodity.Commodity) (commodity.Commodity, error)
+	UpdateCommodity(context.Context, commodity.Commodity) (commodity.Commodity, error)
+	GetSolarSystemByName(context.Context, string) (solarSystem.SolarSystem, error)
+	CreateSolarSystem(context.Context, solarSystem.SolarSystem) (solarSystem.SolarSystem, error)
+	UpdateSolarSystem(context.Context, solarSystem.SolarSystem) (solarSystem.SolarSystem, error)
+	GetCommodityMarketByReferences(context.Context, string, string) (solarSystem.CommodityMarket, error)
+	CreateCommodityMarket(context.Context, string, string, float64, int, string) (solarSystem.CommodityMarket, error)
+	UpdateCommodityMarket(context.Context, string, string, solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error)
+	GetEffectiveLegalityRule(context.Context, string, string) (solarSystem.LegalityRule, error)
+	StreamCommodityRecords(context.Context, func(Record) error) error
+	StreamSolarSystemRecords(context.Context, func(Record) error) error
+	StreamMarketRecords(context.Context, func(Record) error) error
+}
+
+// Auditor - records every mutation made through the service.
+type Auditor interface {
+	Record(ctx context.Context, action audit.Action, entityType string, entityID string, before any, after any) error
+}
+
+// Publisher - writes the domain events raised by the service
+// to the outbox for delivery to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, eventType events.Type, entityID string, topics []string, data any) error
+}
+
+type Service struct {
+	Store     Store
+	Auditor   Auditor
+	Publisher Publisher
+}
+
+func NewService(store Store, auditor Auditor, publisher Publisher) *Service {
+	return &Service{Store: store, Auditor: auditor, Publisher: publisher}
+}
+
+// Export - hands every live commodity, then solar system, then
+// market to write as it is read, in an order Import accepts.
+func (s *Service) Export(ctx context.Context, write func(Record) error) error {
+	for _, resource := range []auth.Resource{auth.ResourceCommodity, auth.ResourceSolarSystem, auth.ResourceCommodityMarket} {
+		if err := auth.Authorize(ctx, resource, auth.OperationRead); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Store.StreamCommodityRecords(ctx, write); err != nil {
+		return fmt.Errorf("error exporting commodities: %w", err)
+	}
+
+	if err := s.Store.StreamSolarSystemRecords(ctx, write); err != nil {
+		return fmt.Errorf("error exporting solar systems: %w", err)
+	}
+
+	if err := s.Store.StreamMarketRecords(ctx, write); err != nil {
+		return fmt.Errorf("error exporting markets: %w", err)
+	}
+
+	return nil
+}
+
+// Import - creates or updates the entity of every record, matched by
+// its natural key, as a single unit of work: an invalid record leaves
+// the universe untouched. A dry run applies every record the same way
+// and rolls back, so its report includes conflicts a real run would hit.
+func (s *Service) Import(ctx context.Context, source Source, dryRun bool) (Report, error) {
+	if err := authorizeImport(ctx); err != nil {
+		return Report{}, err
+	}
+
+	report := Report{DryRun: dryRun}
+	err := s.Store.WithTx(ctx, func(ctx context.Context) error {
+		importer := &importer{Service: s, commodityIds: map[string]string{}, solarSystemIds: map[string]string{}}
+		for number := 1; ; number++ {
+			record, err := source.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return &RecordError{Number: number, Err: err}
+			}
+
+			action, err := importer.apply(ctx, record)
+			if err != nil {
+				return &RecordError{Number: number, Err: err}
+			}
+
+			report.add(record.Kind, record.Key(), action)
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return Report{}, fmt.Errorf("error importing universe: %w", err)
+	}
+
+	return report, nil
+}
+
+// Generate - imports the universe a Generator yields for the config,
+// as a single unit of work like any other import.
+func (s *Service) Generate(ctx context.Context, config GenerateConfig, dryRun bool) (Report, error) {
+	if err := authorizeImport(ctx); err != nil {
+		return Report{}, err
+	}
+
+	generator, err := NewGenerator(config)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return s.Import(ctx, generator, dryRun)
+}
+
+// authorizeImport - an import may create or update every kind of
+// entity it holds records of.
+func authorizeImport(ctx context.Context) error {
+	for _, permission := range []auth.Permission{
+		{Resource: auth.ResourceCommodity, Operation: auth.OperationCreate},
+		{Resource: auth.ResourceCommodity, Operation: auth.OperationUpdate},
+		{Resource: auth.ResourceSolarSystem, Operation: auth.OperationCreate},
+		{Resource: auth.ResourceSolarSystem, Operation: auth.OperationUpdate},
+		{Resource: auth.ResourceCommodityMarket, Operation: auth.OperationCreate},
+		{Resource: auth.ResourceCommodityMarket, Operation: auth.OperationUpdate},
+	} {
+		if err := auth.Authorize(ctx, permission.Resource, permission.Operation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importer - remembers the ids of the commodities and solar systems
+// an import has seen by name, so the markets that follow them don't
+// each look them up again.
+type importer struct {
+	*Service
+	commodityIds   map[string]string
+	solarSystemIds map[string]string
+}
+
+func (i *importer) apply(ctx context.Context, record Record) (Action, error) {
+	switch record.Kind {
+	case KindCommodity:
+		return i.applyCommodity(ctx, record)
+	case KindSolarSystem:
+		return i.applySolarSystem(ctx, record)
+	case KindMarket:
+		return i.applyMarket(ctx, record)
+	}
+
+	return "", fmt.Errorf("%w: unknown kind %q", ErrInvalidRecord, record.Kind)
+}
+
+func (i *importer) applyCommodity(ctx context.Context, record Record) (Action, error) {
+	if record.Name == "" {
+		return "", fmt.Errorf("%w: commodity has no name", ErrInvalidRecord)
+	}
+
+	incoming, err := commodity.Commodity{
+		Name:       record.Name,
+		UnitMass:   record.UnitMass,
+		UnitVolume: record.UnitVolume,
+		Category:   record.Category,
+		Tags:       record.Tags,
+	}.Normalize()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	existing, err := i.Store.GetCommodityByName(ctx, record.Name)
+	if errors.Is(err, commodity.ErrCommodityNotFound) {
+		created, err := i.Store.CreateCommodity(ctx, incoming)
+		if err != nil {
+			return "", err
+		}
+		i.commodityIds[created.Name] = created.ID
+
+		if err := i.Auditor.Record(ctx, audit.ActionCreate, audit.EntityCommodity, created.ID, nil, created); err != nil {
+			return "", err
+		}
+
+		return ActionCreated, i.Publisher.Publish(ctx, events.CommodityCreated, created.ID, commodityTopics(created.ID), created)
+	}
+	if err != nil {
+		return "", err
+	}
+	i.commodityIds[existing.Name] = existing.ID
+
+	if existing.UnitMass == incoming.UnitMass && existing.UnitVolume == incoming.UnitVolume &&
+		existing.Category == incoming.Category && slices.Equal(existing.Tags, incoming.Tags) {
+		return ActionUnchanged, nil
+	}
+
+	changed := existing
+	changed.UnitMass = incoming.UnitMass
+	changed.UnitVolume = incoming.UnitVolume
+	changed.Category = incoming.Category
+	changed.Tags = incoming.Tags
+	updated, err := i.Store.UpdateCommodity(ctx, changed)
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityCommodity, updated.ID, existing, updated); err != nil {
+		return "", err
+	}
+
+	return ActionUpdated, i.Publisher.Publish(ctx, events.CommodityUpdated, updated.ID, commodityTopics(updated.ID), updated)
+}
+
+func (i *importer) applySolarSystem(ctx context.Context, record Record) (Action, error) {
+	if record.Name == "" {
+		return "", fmt.Errorf("%w: solar system has no name", ErrInvalidRecord)
+	}
+
+	system := solarSystem.SolarSystem{
+		Name:        record.Name,
+		Coordinates: record.Coordinates,
+		StarClass:   record.StarClass,
+		Region:      record.Region,
+		Sector:      record.Sector,
+	}
+	if err := system.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	existing, err := i.Store.GetSolarSystemByName(ctx, record.Name)
+	if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+		created, err := i.Store.CreateSolarSystem(ctx, system)
+		if err != nil {
+			return "", err
+		}
+		i.solarSystemIds[created.Name] = created.ID
+
+		if err := i.Auditor.Record(ctx, audit.ActionCreate, audit.EntitySolarSystem, created.ID, nil, created); err != nil {
+			return "", err
+		}
+
+		return ActionCreated, i.Publisher.Publish(ctx, events.SolarSystemCreated, created.ID, solarSystemTopics(created.ID), created)
+	}
+	if err != nil {
+		return "", err
+	}
+	i.solarSystemIds[existing.Name] = existing.ID
+
+	system.ID = existing.ID
+	if system == existing {
+		return ActionUnchanged, nil
+	}
+
+	updated, err := i.Store.UpdateSolarSystem(ctx, system)
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.Auditor.Record(ctx, audit.ActionUpdate, audit.EntitySolarSystem, updated.ID, existing, updated); err != nil {
+		return "", err
+	}
+
+	return ActionUpdated, i.Publisher.Publish(ctx, events.SolarSystemUpdated, updated.ID, solarSystemTopics(updated.ID), updated)
+}
+
+func (i *importer) applyMarket(ctx context.Context, record Record) (Action, error) {
+	if record.SolarSystem == "" || record.Commodity == "" {
+		return "", fmt.Errorf("%w: market needs a solar system and a commodity", ErrInvalidRecord)
+	}
+
+	solarSystemId, err := i.solarSystemId(ctx, record.SolarSystem)
+	if err != nil {
+		return "", err
+	}
+
+	commodityId, err := i.commodityId(ctx, record.Commodity)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := i.Store.GetCommodityMarketByReferences(ctx, solarSystemId, commodityId)
+	if errors.Is(err, solarSystem.ErrCommodityMarketNotFound) {
+		if err := solarSystem.CheckNotBanned(ctx, i.Store, solarSystemId, commodityId); err != nil {
+			return "", fmt.Errorf("market in solar system %q: %w", record.SolarSystem, err)
+		}
+
+		created, err := i.Store.CreateCommodityMarket(ctx, solarSystemId, "", record.BasePrice, record.DemandQuantity, commodityId)
+		if err != nil {
+			return "", err
+		}
+
+		if err := i.Auditor.Record(ctx, audit.ActionCreate, audit.EntityCommodityMarket, created.ID, nil, created); err != nil {
+			return "", err
+		}
+
+		return ActionCreated, i.Publisher.Publish(ctx, events.MarketCreated, created.ID, solarSystem.MarketTopics(created), created)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if existing.BasePrice == record.BasePrice && existing.DemandQuantity == record.DemandQuantity {
+		return ActionUnchanged, nil
+	}
+
+	updated, err := i.Store.UpdateCommodityMarket(ctx, solarSystemId, existing.ID, solarSystem.CommodityMarketUpdate{
+		BasePrice:      record.BasePrice,
+		DemandQuantity: record.DemandQuantity,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.Auditor.Record(ctx, audit.ActionUpdate, audit.EntityCommodityMarket, updated.ID, existing, updated); err != nil {
+		return "", err
+	}
+
+	if updated.BasePrice != existing.BasePrice {
+		return ActionUpdated, i.Publisher.Publish(ctx, events.MarketPriceChanged, updated.ID, solarSystem.MarketTopics(updated), solarSystem.MarketPriceChange{
+			CommodityMarket:   updated,
+			PreviousBasePrice: existing.BasePrice,
+		})
+	}
+
+	return ActionUpdated, i.Publisher.Publish(ctx, events.MarketUpdated, updated.ID, solarSystem.MarketTopics(updated), updated)
+}
+
+func (i *importer) solarSystemId(ctx context.Context, name string) (string, error) {
+	if id, ok := i.solarSystemIds[name]; ok {
+		return id, nil
+	}
+
+	found, err := i.Store.GetSolarSystemByName(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("market references solar system %q: %w", name, err)
+	}
+	i.solarSystemIds[name] = found.ID
+
+	return found.ID, nil
+}
+
+func (i *importer) commodityId(ctx context.Context, name string) (string, error) {
+	if id, ok := i.commodityIds[name]; ok {
+		return id, nil
+	}
+
+	found, err := i.Store.GetCommodityByName(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("market references commodity %q: %w", name, err)
+	}
+	i.commodityIds[name] = found.ID
+
+	return found.ID, nil
+}
+
+func commodityTopics(id string) []string {
+	return []string{events.Topic(events.TopicCommodity, id)}
+}
+
+func solarSystemTopics(id string) []string {
+	return []string{events.Topic(events.TopicSolarSystem, id)}
+}
@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhook   = errors.New("invalid webhook")
+	ErrInvalidStatus    = errors.New("invalid delivery status")
+	ErrDeliveryNotDead  = errors.New("only dead deliveries can be retried")
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the webhook's secret, prefixed "sha256=".
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Webhook - a URL subscribed to domain events. An empty
+// EventTypes subscribes it to every event.
+type Webhook struct {
+	ID         string
+	URL        string
+	EventTypes []events.Type
+	CreatedAt  time.Time
+}
+
+// CreatedWebhook - returned once on creation, the only
+// time the signing secret is shown.
+type CreatedWebhook struct {
+	Webhook
+	Secret string
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	// DeliveryStatusDead - delivery gave up after the maximum
+	// number of attempts. Dead deliveries form the dead-letter view.
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+func ParseDeliveryStatus(status string) (DeliveryStatus, error) {
+	switch DeliveryStatus(status) {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusDead:
+		return DeliveryStatus(status), nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+}
+
+// Delivery - one event sent, or to be sent, to one webhook.
+type Delivery struct {
+	ID                 string
+	WebhookID          string
+	EventID            int64
+	EventType          events.Type
+	Status             DeliveryStatus
+	Attempts           int
+	LastError          string
+	LastResponseStatus int
+	NextAttemptAt      *time.Time
+	DeliveredAt        *time.Time
+	CreatedAt          time.Time
+}
+
+// PendingDelivery - a delivery claimed by the dispatcher, with
+// everything needed to send it.
+type PendingDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+	Event  events.Event
+}
+
+// DeliveryFilter - narrows the deliveries returned by a listing.
+// An empty Status matches every delivery.
+type DeliveryFilter struct {
+	Status DeliveryStatus
+}
+
+type Store interface {
+	GetWebhookById(context.Context, string) (Webhook, error)
+	GetWebhooksByPagination(context.Context, data.Pagination) ([]Webhook, error)
+	CreateWebhook(context.Context, Webhook, string) (Webhook, error)
+	RemoveWebhook(context.Context, string) error
+	GetDeliveriesByWebhookId(context.Context, string, DeliveryFilter, data.Pagination) ([]Delivery, error)
+	RetryDelivery(context.Context, string, string) (Delivery, error)
+}
+
+type Service struct {
+	Store Store
+}
+
+func NewService(store Store) *Service {
+	return &Service{Store: store}
+}
+
+func (s *Service) FindAllWebhooks(ctx context.Context, pagination data.Pagination) ([]Webhook, error) {
+	if err := auth.Authorize(ctx, auth.ResourceWebhook, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.Store.GetWebhooksByPagination(ctx, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhooks by pagination: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// CreateWebhook - registers a URL for the given event types and
+// generates the secret its deliveries will be signed with.
+func (s *Service) CreateWebhook(ctx context.Context, webhookUrl string, eventTypes []string) (CreatedWebhook, error) {
+	if err := auth.Authorize(ctx, auth.ResourceWebhook, auth.OperationCreate); err != nil {
+		return CreatedWebhook{}, err
+	}
+
+	parsed, err := url.Parse(webhookUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return CreatedWebhook{}, fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidWebhook)
+	}
+
+	types := []events.Type{}
+	for _, eventType := range eventTypes {
+		t, err := events.ParseType(eventType)
+		if err != nil {
+			return CreatedWebhook{}, fmt.Errorf("%w: %w", ErrInvalidWebhook, err)
+		}
+		types = append(types, t)
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return CreatedWebhook{}, err
+	}
+
+	webhook, err := s.Store.CreateWebhook(ctx, Webhook{URL: webhookUrl, EventTypes: types}, secret)
+	if err != nil {
+		return CreatedWebhook{}, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return CreatedWebhook{Webhook: webhook, Secret: secret}, nil
+}
+
+func (s *Service) RemoveWebhook(ctx context.Context, id string) error {
+	if err := auth.Authorize(ctx, auth.ResourceWebhook, auth.OperationDelete); err != nil {
+		return err
+	}
+
+	if err := s.Store.RemoveWebhook(ctx, id); err != nil {
+		return fmt.Errorf("error removing webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) FindDeliveries(ctx context.Context, webhookId string, filter DeliveryFilter, pagination data.Pagination) ([]Delivery, error) {
+	if err := auth.Authorize(ctx, auth.ResourceWebhook, auth.OperationRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.Store.GetWebhookById(ctx, webhookId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.Store.GetDeliveriesByWebhookId(ctx, webhookId, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RetryDelivery - moves a dead delivery back to pending with a
+// fresh set of attempts, to be sent on the dispatcher's next run.
+func (s *Service) RetryDelivery(ctx context.Context, webhookId string, deliveryId string) (Delivery, error) {
+	if err := auth.Authorize(ctx, auth.ResourceWebhook, auth.OperationUpdate); err != nil {
+		return Delivery{}, err
+	}
+
+	delivery, err := s.Store.RetryDelivery(ctx, webhookId, deliveryId)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("error retrying webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// Sign - computes the signature header value for a delivery body.
+// Receivers recompute it with their copy of the secret to verify a delivery.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/transport/grpc/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type commodityServer struct {
+	pb.UnimplementedCommodityServiceServer
+	server *Server
+}
+
+func (c *commodityServer) ListCommodities(ctx context.Context, req *pb.ListCommoditiesRequest) (*pb.ListCommoditiesResponse, error) {
+	pagination := convertPagination(req.GetPagination())
+
+	commodities, err := c.server.CommodityService.FindAllCommodity(ctx, pagination, commodity.Filter{IncludeDeleted: req.GetIncludeDeleted()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListCommoditiesResponse{Pagination: convertPaginationToProto(pagination)}
+	for _, found := range commodities {
+		response.Commodities = append(response.Commodities, convertCommodityToProto(found))
+	}
+
+	return response, nil
+}
+
+func (c *commodityServer) GetCommodity(ctx context.Context, req *pb.GetCommodityRequest) (*pb.Commodity, error) {
+	found, err := c.server.CommodityService.FindCommodity(ctx, req.GetId(), req.GetIncludeDeleted())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityToProto(found), nil
+}
+
+func (c *commodityServer) CreateCommodity(ctx context.Context, req *pb.CreateCommodityRequest) (*pb.Commodity, error) {
+	created, err := c.server.CommodityService.CreateCommodity(ctx, commodity.Commodity{
+		Name:       req.GetName(),
+		UnitMass:   req.GetUnitMass(),
+		UnitVolume: req.GetUnitVolume(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityToProto(created), nil
+}
+
+func (c *commodityServer) RemoveCommodity(ctx context.Context, req *pb.RemoveCommodityRequest) (*emptypb.Empty, error) {
+	mode := commodity.RemovalModeRestrict
+	if req.GetMode() != "" {
+		parsed, err := commodity.ParseRemovalMode(req.GetMode())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		mode = parsed
+	}
+
+	if err := c.server.CommodityService.RemoveCommodity(ctx, req.GetId(), mode); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *commodityServer) RestoreCommodity(ctx context.Context, req *pb.RestoreCommodityRequest) (*pb.Commodity, error) {
+	restored, err := c.server.CommodityService.RestoreCommodity(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityToProto(restored), nil
+}
@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/transport/grpc/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var marketEventTypes = map[events.Type]bool{
+	events.MarketCreated:      true,
+	events.MarketUpdated:      true,
+	events.MarketPriceChanged: true,
+	events.MarketRemoved:      true,
+	events.MarketRestored:     true,
+}
+
+// marketEventData - the published data of a market event, in the
+// camelCase shape of the v1 market DTO that the event service encodes.
+type marketEventData struct {
+	ID                string     `json:"id"`
+	SolarSystemID     string     `json:"solarSystemId"`
+	CommodityID       string     `json:"commodityId"`
+	CommodityName     string     `json:"commodityName"`
+	BasePrice         float64    `json:"basePrice"`
+	DemandQuantity    int        `json:"demandQuantity"`
+	DeletedAt         *time.Time `json:"deletedAt"`
+	PreviousBasePrice float64    `json:"previousBasePrice"`
+}
+
+type commodityMarketServer struct {
+	pb.UnimplementedCommodityMarketServiceServer
+	server *Server
+}
+
+func (m *commodityMarketServer) CreateCommodityMarket(ctx context.Context, req *pb.CreateCommodityMarketRequest) (*pb.CommodityMarket, error) {
+	created, err := m.server.SolarSystemService.CreateCommodityMarket(ctx, req.GetSolarSystemId(), "", req.GetBasePrice(), int(req.GetDemandQuantity()), req.GetCommodityId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityMarketToProto(created), nil
+}
+
+// UpdateCommodityMarket - markets are addressed by id alone over gRPC,
+// so the solar system the update is scoped to is looked up first.
+func (m *commodityMarketServer) UpdateCommodityMarket(ctx context.Context, req *pb.UpdateCommodityMarketRequest) (*pb.CommodityMarket, error) {
+	market, err := m.server.SolarSystemService.FindCommodityMarket(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	updated, err := m.server.SolarSystemService.UpdateCommodityMarket(ctx, market.SolarSystemID, market.ID, solarSystem.CommodityMarketUpdate{
+		BasePrice:      req.GetBasePrice(),
+		DemandQuantity: int(req.GetDemandQuantity()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityMarketToProto(updated), nil
+}
+
+func (m *commodityMarketServer) RemoveCommodityMarket(ctx context.Context, req *pb.RemoveCommodityMarketRequest) (*emptypb.Empty, error) {
+	market, err := m.server.SolarSystemService.FindCommodityMarket(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := m.server.SolarSystemService.RemoveCommodityMarket(ctx, market.SolarSystemID, market.ID); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (m *commodityMarketServer) RestoreCommodityMarket(ctx context.Context, req *pb.RestoreCommodityMarketRequest) (*pb.CommodityMarket, error) {
+	market, err := m.server.SolarSystemService.FindCommodityMarket(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	restored, err := m.server.SolarSystemService.RestoreCommodityMarket(ctx, market.SolarSystemID, market.ID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertCommodityMarketToProto(restored), nil
+}
+
+// WatchMarkets - sends the market events published to the requested
+// topics until the client cancels or the stream service closes.
+func (m *commodityMarketServer) WatchMarkets(req *pb.WatchMarketsRequest, stream pb.CommodityMarketService_WatchMarketsServer) error {
+	subscription, err := m.server.StreamService.Subscribe(stream.Context(), req.GetTopics(), req.LastEventId)
+	if err != nil {
+		return toStatus(err)
+	}
+	defer m.server.StreamService.Unsubscribe(subscription)
+
+	for _, event := range subscription.Replay {
+		if err := sendMarketEvent(stream, event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return nil
+			}
+			if err := sendMarketEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendMarketEvent - sends the event if it concerns a market, skipping
+// the solar system and commodity events that share its topics.
+func sendMarketEvent(stream pb.CommodityMarketService_WatchMarketsServer, event events.Event) error {
+	if !marketEventTypes[event.Type] {
+		return nil
+	}
+
+	// every market event carries the market, and price changes
+	// add the previous price alongside its fields
+	var data marketEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		log.Println("Error decoding market event", event.ID, err)
+		return nil
+	}
+
+	market := solarSystem.CommodityMarket{
+		ID:             data.ID,
+		SolarSystemID:  data.SolarSystemID,
+		CommodityID:    data.CommodityID,
+		CommodityName:  data.CommodityName,
+		BasePrice:      data.BasePrice,
+		DemandQuantity: data.DemandQuantity,
+		DeletedAt:      data.DeletedAt,
+	}
+
+	return stream.Send(&pb.MarketEvent{
+		Id:                event.ID,
+		Type:              string(event.Type),
+		Market:            convertCommodityMarketToProto(market),
+		PreviousBasePrice: data.PreviousBasePrice,
+		CreatedAt:         timestamppb.New(event.CreatedAt),
+	})
+}
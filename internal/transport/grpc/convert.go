@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/transport/grpc/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertPagination(pagination *pb.Pagination) data.Pagination {
+	return data.NewPagination(int(pagination.GetPage()), int(pagination.GetPerPage()), pagination.GetOrderBy())
+}
+
+func convertPaginationToProto(pagination data.Pagination) *pb.Pagination {
+	return &pb.Pagination{
+		Page:    int32(pagination.Page),
+		PerPage: int32(pagination.PerPage),
+		OrderBy: pagination.OrderBy,
+	}
+}
+
+func convertTimeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func convertCommodityToProto(c commodity.Commodity) *pb.Commodity {
+	return &pb.Commodity{
+		Id:         c.ID,
+		Name:       c.Name,
+		UnitMass:   c.UnitMass,
+		UnitVolume: c.UnitVolume,
+		DeletedAt:  convertTimeToProto(c.DeletedAt),
+	}
+}
+
+func convertSolarSystemToProto(s solarSystem.SolarSystem) *pb.SolarSystem {
+	return &pb.SolarSystem{
+		Id:        s.ID,
+		Name:      s.Name,
+		DeletedAt: convertTimeToProto(s.DeletedAt),
+	}
+}
+
+func convertSolarSystemWithCommodityMarketsToProto(s solarSystem.SolarSystemWithCommodityMarkets) *pb.SolarSystem {
+	markets := []*pb.CommodityMarket{}
+	for _, market := range s.CommodityMarkets {
+		markets = append(markets, convertCommodityMarketToProto(market))
+	}
+
+	return &pb.SolarSystem{
+		Id:               s.ID,
+		Name:             s.Name,
+		DeletedAt:        convertTimeToProto(s.DeletedAt),
+		CommodityMarkets: markets,
+	}
+}
+
+func convertCommodityMarketToProto(m solarSystem.CommodityMarket) *pb.CommodityMarket {
+	return &pb.CommodityMarket{
+		Id:             m.ID,
+		SolarSystemId:  m.SolarSystemID,
+		CommodityId:    m.CommodityID,
+		CommodityName:  m.CommodityName,
+		BasePrice:      m.BasePrice,
+		DemandQuantity: int32(m.DemandQuantity),
+		DeletedAt:      convertTimeToProto(m.DeletedAt),
+	}
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/stream"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus - maps a service error to the gRPC status matching the
+// response the REST transport gives for it.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, commodity.ErrCommodityNotFound),
+		errors.Is(err, solarSystem.ErrSolarSystemNotFound),
+		errors.Is(err, solarSystem.ErrCommodityMarketNotFound),
+		errors.Is(err, solarSystem.ErrMarketReferenceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, commodity.ErrCommodityInUse),
+		errors.Is(err, solarSystem.ErrCommodityBanned):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, solarSystem.ErrCommodityMarketConflict),
+		errors.Is(err, commodity.ErrCommodityConflict),
+		errors.Is(err, solarSystem.ErrSolarSystemConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, commodity.ErrInvalidRemovalMode),
+		errors.Is(err, stream.ErrInvalidTopic):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Println("Error handling call", err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys carrying credentials, matching the REST headers.
+const (
+	ApiKeyMetadata        = "x-api-key"
+	AuthorizationMetadata = "authorization"
+)
+
+func (s *Server) authUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	principal, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(auth.WithPrincipal(ctx, principal), req)
+}
+
+func (s *Server) authStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	principal, err := s.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &principalStream{ServerStream: stream, ctx: auth.WithPrincipal(stream.Context(), principal)})
+}
+
+// principalStream - a server stream whose context carries the principal.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (p *principalStream) Context() context.Context {
+	return p.ctx
+}
+
+func (s *Server) authenticate(ctx context.Context) (auth.Principal, error) {
+	principal, err := s.authenticateMetadata(ctx)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrInvalidCredentials) || errors.Is(err, auth.ErrJwtNotConfigured) {
+			log.Println("Unauthenticated call", err)
+			return auth.Principal{}, status.Error(codes.Unauthenticated, err.Error())
+		}
+		log.Println("Error authenticating call", err)
+		return auth.Principal{}, status.Error(codes.Internal, "error authenticating call")
+	}
+
+	return principal, nil
+}
+
+func (s *Server) authenticateMetadata(ctx context.Context) (auth.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if apiKey := first(md, ApiKeyMetadata); apiKey != "" {
+		return s.AuthService.AuthenticateApiKey(ctx, apiKey)
+	}
+
+	scheme, credential, found := strings.Cut(first(md, AuthorizationMetadata), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || credential == "" {
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+
+	if auth.IsApiKey(credential) {
+		return s.AuthService.AuthenticateApiKey(ctx, credential)
+	}
+
+	return s.AuthService.AuthenticateBearerToken(ctx, credential)
+}
+
+// rateLimitUnaryInterceptor and rateLimitStreamInterceptor - limit
+// each peer address ahead of authentication, ignoring any credential
+// presented, so made up credentials are turned away by address before
+// they reach the database.
+func (s *Server) rateLimitUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.allow(ctx, info.FullMethod, peerIpKey(ctx)); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) rateLimitStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.allow(stream.Context(), info.FullMethod, peerIpKey(stream.Context())); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// principalRateLimitUnaryInterceptor and
+// principalRateLimitStreamInterceptor - run after authentication and
+// limit each verified principal as well, wherever its calls come from.
+func (s *Server) principalRateLimitUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.allowPrincipal(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) principalRateLimitStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.allowPrincipal(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (s *Server) allowPrincipal(ctx context.Context, method string) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return s.allow(ctx, method, "principal:"+principal.ID)
+}
+
+// allow - applies the REST rate limits to gRPC calls, keyed the same
+// way. Methods fall into the default group, as no group prefix matches them.
+func (s *Server) allow(ctx context.Context, method string, clientKey string) error {
+	result, err := s.RateLimiter.Allow(ctx, clientKey, method)
+	if err != nil {
+		// a failing limiter backend should not take the API down with it
+		log.Println("Error checking rate limit", err)
+		return nil
+	}
+
+	if !result.Allowed {
+		log.Println("Rate limit exceeded", method)
+		retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return nil
+}
+
+func peerIpKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "ip:unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return "ip:" + host
+}
+
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: commodity.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CommodityService_ListCommodities_FullMethodName  = "/spacesim.v1.CommodityService/ListCommodities"
+	CommodityService_GetCommodity_FullMethodName     = "/spacesim.v1.CommodityService/GetCommodity"
+	CommodityService_CreateCommodity_FullMethodName  = "/spacesim.v1.CommodityService/CreateCommodity"
+	CommodityService_RemoveCommodity_FullMethodName  = "/spacesim.v1.CommodityService/RemoveCommodity"
+	CommodityService_RestoreCommodity_FullMethodName = "/spacesim.v1.CommodityService/RestoreCommodity"
+)
+
+// CommodityServiceClient is the client API for CommodityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CommodityServiceClient interface {
+	ListCommodities(ctx context.Context, in *ListCommoditiesRequest, opts ...grpc.CallOption) (*ListCommoditiesResponse, error)
+	GetCommodity(ctx context.Context, in *GetCommodityRequest, opts ...grpc.CallOption) (*Commodity, error)
+	CreateCommodity(ctx context.Context, in *CreateCommodityRequest, opts ...grpc.CallOption) (*Commodity, error)
+	RemoveCommodity(ctx context.Context, in *RemoveCommodityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreCommodity(ctx context.Context, in *RestoreCommodityRequest, opts ...grpc.CallOption) (*Commodity, error)
+}
+
+type commodityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommodityServiceClient(cc grpc.ClientConnInterface) CommodityServiceClient {
+	return &commodityServiceClient{cc}
+}
+
+func (c *commodityServiceClient) ListCommodities(ctx context.Context, in *ListCommoditiesRequest, opts ...grpc.CallOption) (*ListCommoditiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommoditiesResponse)
+	err := c.cc.Invoke(ctx, CommodityService_ListCommodities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityServiceClient) GetCommodity(ctx context.Context, in *GetCommodityRequest, opts ...grpc.CallOption) (*Commodity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Commodity)
+	err := c.cc.Invoke(ctx, CommodityService_GetCommodity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityServiceClient) CreateCommodity(ctx context.Context, in *CreateCommodityRequest, opts ...grpc.CallOption) (*Commodity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Commodity)
+	err := c.cc.Invoke(ctx, CommodityService_CreateCommodity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityServiceClient) RemoveCommodity(ctx context.Context, in *RemoveCommodityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CommodityService_RemoveCommodity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityServiceClient) RestoreCommodity(ctx context.Context, in *RestoreCommodityRequest, opts ...grpc.CallOption) (*Commodity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Commodity)
+	err := c.cc.Invoke(ctx, CommodityService_RestoreCommodity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommodityServiceServer is the server API for CommodityService service.
+// All implementations must embed UnimplementedCommodityServiceServer
+// for forward compatibility.
+type CommodityServiceServer interface {
+	ListCommodities(context.Context, *ListCommoditiesRequest) (*ListCommoditiesResponse, error)
+	GetCommodity(context.Context, *GetCommodityRequest) (*Commodity, error)
+	CreateCommodity(context.Context, *CreateCommodityRequest) (*Commodity, error)
+	RemoveCommodity(context.Context, *RemoveCommodityRequest) (*emptypb.Empty, error)
+	RestoreCommodity(context.Context, *RestoreCommodityRequest) (*Commodity, error)
+	mustEmbedUnimplementedCommodityServiceServer()
+}
+
+// UnimplementedCommodityServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCommodityServiceServer struct{}
+
+func (UnimplementedCommodityServiceServer) ListCommodities(context.Context, *ListCommoditiesRequest) (*ListCommoditiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommodities not implemented")
+}
+func (UnimplementedCommodityServiceServer) GetCommodity(context.Context, *GetCommodityRequest) (*Commodity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommodity not implemented")
+}
+func (UnimplementedCommodityServiceServer) CreateCommodity(context.Context, *CreateCommodityRequest) (*Commodity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommodity not implemented")
+}
+func (UnimplementedCommodityServiceServer) RemoveCommodity(context.Context, *RemoveCommodityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCommodity not implemented")
+}
+func (UnimplementedCommodityServiceServer) RestoreCommodity(context.Context, *RestoreCommodityRequest) (*Commodity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCommodity not implemented")
+}
+func (UnimplementedCommodityServiceServer) mustEmbedUnimplementedCommodityServiceServer() {}
+func (UnimplementedCommodityServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCommodityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CommodityServiceServer will
+// result in compilation errors.
+type UnsafeCommodityServiceServer interface {
+	mustEmbedUnimplementedCommodityServiceServer()
+}
+
+func RegisterCommodityServiceServer(s grpc.ServiceRegistrar, srv CommodityServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCommodityServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CommodityService_ServiceDesc, srv)
+}
+
+func _CommodityService_ListCommodities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommoditiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityServiceServer).ListCommodities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityService_ListCommodities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityServiceServer).ListCommodities(ctx, req.(*ListCommoditiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityService_GetCommodity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommodityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityServiceServer).GetCommodity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityService_GetCommodity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityServiceServer).GetCommodity(ctx, req.(*GetCommodityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityService_CreateCommodity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommodityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityServiceServer).CreateCommodity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityService_CreateCommodity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityServiceServer).CreateCommodity(ctx, req.(*CreateCommodityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityService_RemoveCommodity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCommodityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityServiceServer).RemoveCommodity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityService_RemoveCommodity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityServiceServer).RemoveCommodity(ctx, req.(*RemoveCommodityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityService_RestoreCommodity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCommodityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityServiceServer).RestoreCommodity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityService_RestoreCommodity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityServiceServer).RestoreCommodity(ctx, req.(*RestoreCommodityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CommodityService_ServiceDesc is the grpc.ServiceDesc for CommodityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CommodityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacesim.v1.CommodityService",
+	HandlerType: (*CommodityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCommodities",
+			Handler:    _CommodityService_ListCommodities_Handler,
+		},
+		{
+			MethodName: "GetCommodity",
+			Handler:    _CommodityService_GetCommodity_Handler,
+		},
+		{
+			MethodName: "CreateCommodity",
+			Handler:    _CommodityService_CreateCommodity_Handler,
+		},
+		{
+			MethodName: "RemoveCommodity",
+			Handler:    _CommodityService_RemoveCommodity_Handler,
+		},
+		{
+			MethodName: "RestoreCommodity",
+			Handler:    _CommodityService_RestoreCommodity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "commodity.proto",
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: commodity_market.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CommodityMarketService_CreateCommodityMarket_FullMethodName  = "/spacesim.v1.CommodityMarketService/CreateCommodityMarket"
+	CommodityMarketService_UpdateCommodityMarket_FullMethodName  = "/spacesim.v1.CommodityMarketService/UpdateCommodityMarket"
+	CommodityMarketService_RemoveCommodityMarket_FullMethodName  = "/spacesim.v1.CommodityMarketService/RemoveCommodityMarket"
+	CommodityMarketService_RestoreCommodityMarket_FullMethodName = "/spacesim.v1.CommodityMarketService/RestoreCommodityMarket"
+	CommodityMarketService_WatchMarkets_FullMethodName           = "/spacesim.v1.CommodityMarketService/WatchMarkets"
+)
+
+// CommodityMarketServiceClient is the client API for CommodityMarketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CommodityMarketServiceClient interface {
+	CreateCommodityMarket(ctx context.Context, in *CreateCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error)
+	UpdateCommodityMarket(ctx context.Context, in *UpdateCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error)
+	RemoveCommodityMarket(ctx context.Context, in *RemoveCommodityMarketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreCommodityMarket(ctx context.Context, in *RestoreCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error)
+	// WatchMarkets streams changes to the markets named by the topics,
+	// e.g. "market:{id}", "solarSystem:{id}", "station:{id}" or "commodity:{id}".
+	WatchMarkets(ctx context.Context, in *WatchMarketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketEvent], error)
+}
+
+type commodityMarketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommodityMarketServiceClient(cc grpc.ClientConnInterface) CommodityMarketServiceClient {
+	return &commodityMarketServiceClient{cc}
+}
+
+func (c *commodityMarketServiceClient) CreateCommodityMarket(ctx context.Context, in *CreateCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommodityMarket)
+	err := c.cc.Invoke(ctx, CommodityMarketService_CreateCommodityMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityMarketServiceClient) UpdateCommodityMarket(ctx context.Context, in *UpdateCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommodityMarket)
+	err := c.cc.Invoke(ctx, CommodityMarketService_UpdateCommodityMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityMarketServiceClient) RemoveCommodityMarket(ctx context.Context, in *RemoveCommodityMarketRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CommodityMarketService_RemoveCommodityMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityMarketServiceClient) RestoreCommodityMarket(ctx context.Context, in *RestoreCommodityMarketRequest, opts ...grpc.CallOption) (*CommodityMarket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommodityMarket)
+	err := c.cc.Invoke(ctx, CommodityMarketService_RestoreCommodityMarket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commodityMarketServiceClient) WatchMarkets(ctx context.Context, in *WatchMarketsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CommodityMarketService_ServiceDesc.Streams[0], CommodityMarketService_WatchMarkets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMarketsRequest, MarketEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommodityMarketService_WatchMarketsClient = grpc.ServerStreamingClient[MarketEvent]
+
+// CommodityMarketServiceServer is the server API for CommodityMarketService service.
+// All implementations must embed UnimplementedCommodityMarketServiceServer
+// for forward compatibility.
+type CommodityMarketServiceServer interface {
+	CreateCommodityMarket(context.Context, *CreateCommodityMarketRequest) (*CommodityMarket, error)
+	UpdateCommodityMarket(context.Context, *UpdateCommodityMarketRequest) (*CommodityMarket, error)
+	RemoveCommodityMarket(context.Context, *RemoveCommodityMarketRequest) (*emptypb.Empty, error)
+	RestoreCommodityMarket(context.Context, *RestoreCommodityMarketRequest) (*CommodityMarket, error)
+	// WatchMarkets streams changes to the markets named by the topics,
+	// e.g. "market:{id}", "solarSystem:{id}", "station:{id}" or "commodity:{id}".
+	WatchMarkets(*WatchMarketsRequest, grpc.ServerStreamingServer[MarketEvent]) error
+	mustEmbedUnimplementedCommodityMarketServiceServer()
+}
+
+// UnimplementedCommodityMarketServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCommodityMarketServiceServer struct{}
+
+func (UnimplementedCommodityMarketServiceServer) CreateCommodityMarket(context.Context, *CreateCommodityMarketRequest) (*CommodityMarket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommodityMarket not implemented")
+}
+func (UnimplementedCommodityMarketServiceServer) UpdateCommodityMarket(context.Context, *UpdateCommodityMarketRequest) (*CommodityMarket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommodityMarket not implemented")
+}
+func (UnimplementedCommodityMarketServiceServer) RemoveCommodityMarket(context.Context, *RemoveCommodityMarketRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCommodityMarket not implemented")
+}
+func (UnimplementedCommodityMarketServiceServer) RestoreCommodityMarket(context.Context, *RestoreCommodityMarketRequest) (*CommodityMarket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCommodityMarket not implemented")
+}
+func (UnimplementedCommodityMarketServiceServer) WatchMarkets(*WatchMarketsRequest, grpc.ServerStreamingServer[MarketEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMarkets not implemented")
+}
+func (UnimplementedCommodityMarketServiceServer) mustEmbedUnimplementedCommodityMarketServiceServer() {
+}
+func (UnimplementedCommodityMarketServiceServer) testEmbeddedByValue() {}
+
+// UnsafeCommodityMarketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CommodityMarketServiceServer will
+// result in compilation errors.
+type UnsafeCommodityMarketServiceServer interface {
+	mustEmbedUnimplementedCommodityMarketServiceServer()
+}
+
+func RegisterCommodityMarketServiceServer(s grpc.ServiceRegistrar, srv CommodityMarketServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCommodityMarketServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CommodityMarketService_ServiceDesc, srv)
+}
+
+func _CommodityMarketService_CreateCommodityMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommodityMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityMarketServiceServer).CreateCommodityMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityMarketService_CreateCommodityMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityMarketServiceServer).CreateCommodityMarket(ctx, req.(*CreateCommodityMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityMarketService_UpdateCommodityMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommodityMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityMarketServiceServer).UpdateCommodityMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityMarketService_UpdateCommodityMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityMarketServiceServer).UpdateCommodityMarket(ctx, req.(*UpdateCommodityMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityMarketService_RemoveCommodityMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCommodityMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityMarketServiceServer).RemoveCommodityMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityMarketService_RemoveCommodityMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityMarketServiceServer).RemoveCommodityMarket(ctx, req.(*RemoveCommodityMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityMarketService_RestoreCommodityMarket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCommodityMarketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommodityMarketServiceServer).RestoreCommodityMarket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommodityMarketService_RestoreCommodityMarket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommodityMarketServiceServer).RestoreCommodityMarket(ctx, req.(*RestoreCommodityMarketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommodityMarketService_WatchMarkets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMarketsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommodityMarketServiceServer).WatchMarkets(m, &grpc.GenericServerStream[WatchMarketsRequest, MarketEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CommodityMarketService_WatchMarketsServer = grpc.ServerStreamingServer[MarketEvent]
+
+// CommodityMarketService_ServiceDesc is the grpc.ServiceDesc for CommodityMarketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CommodityMarketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacesim.v1.CommodityMarketService",
+	HandlerType: (*CommodityMarketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCommodityMarket",
+			Handler:    _CommodityMarketService_CreateCommodityMarket_Handler,
+		},
+		{
+			MethodName: "UpdateCommodityMarket",
+			Handler:    _CommodityMarketService_UpdateCommodityMarket_Handler,
+		},
+		{
+			MethodName: "RemoveCommodityMarket",
+			Handler:    _CommodityMarketService_RemoveCommodityMarket_Handler,
+		},
+		{
+			MethodName: "RestoreCommodityMarket",
+			Handler:    _CommodityMarketService_RestoreCommodityMarket_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMarkets",
+			Handler:       _CommodityMarketService_WatchMarkets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "commodity_market.proto",
+}
@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: solar_system.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SolarSystem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Only populated by GetSolarSystem and RestoreSolarSystem.
+	CommodityMarkets []*CommodityMarket `protobuf:"bytes,4,rep,name=commodity_markets,json=commodityMarkets,proto3" json:"commodity_markets,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SolarSystem) Reset() {
+	*x = SolarSystem{}
+	mi := &file_solar_system_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolarSystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolarSystem) ProtoMessage() {}
+
+func (x *SolarSystem) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolarSystem.ProtoReflect.Descriptor instead.
+func (*SolarSystem) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SolarSystem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SolarSystem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SolarSystem) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *SolarSystem) GetCommodityMarkets() []*CommodityMarket {
+	if x != nil {
+		return x.CommodityMarkets
+	}
+	return nil
+}
+
+type ListSolarSystemsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Pagination     *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListSolarSystemsRequest) Reset() {
+	*x = ListSolarSystemsRequest{}
+	mi := &file_solar_system_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSolarSystemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSolarSystemsRequest) ProtoMessage() {}
+
+func (x *ListSolarSystemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSolarSystemsRequest.ProtoReflect.Descriptor instead.
+func (*ListSolarSystemsRequest) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSolarSystemsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListSolarSystemsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListSolarSystemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SolarSystems  []*SolarSystem         `protobuf:"bytes,1,rep,name=solar_systems,json=solarSystems,proto3" json:"solar_systems,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSolarSystemsResponse) Reset() {
+	*x = ListSolarSystemsResponse{}
+	mi := &file_solar_system_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSolarSystemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSolarSystemsResponse) ProtoMessage() {}
+
+func (x *ListSolarSystemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSolarSystemsResponse.ProtoReflect.Descriptor instead.
+func (*ListSolarSystemsResponse) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSolarSystemsResponse) GetSolarSystems() []*SolarSystem {
+	if x != nil {
+		return x.SolarSystems
+	}
+	return nil
+}
+
+func (x *ListSolarSystemsResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetSolarSystemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSolarSystemRequest) Reset() {
+	*x = GetSolarSystemRequest{}
+	mi := &file_solar_system_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSolarSystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSolarSystemRequest) ProtoMessage() {}
+
+func (x *GetSolarSystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSolarSystemRequest.ProtoReflect.Descriptor instead.
+func (*GetSolarSystemRequest) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSolarSystemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSolarSystemRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type CreateSolarSystemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSolarSystemRequest) Reset() {
+	*x = CreateSolarSystemRequest{}
+	mi := &file_solar_system_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSolarSystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSolarSystemRequest) ProtoMessage() {}
+
+func (x *CreateSolarSystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSolarSystemRequest.ProtoReflect.Descriptor instead.
+func (*CreateSolarSystemRequest) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateSolarSystemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveSolarSystemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSolarSystemRequest) Reset() {
+	*x = RemoveSolarSystemRequest{}
+	mi := &file_solar_system_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSolarSystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSolarSystemRequest) ProtoMessage() {}
+
+func (x *RemoveSolarSystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSolarSystemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSolarSystemRequest) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveSolarSystemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreSolarSystemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSolarSystemRequest) Reset() {
+	*x = RestoreSolarSystemRequest{}
+	mi := &file_solar_system_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSolarSystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSolarSystemRequest) ProtoMessage() {}
+
+func (x *RestoreSolarSystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solar_system_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSolarSystemRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSolarSystemRequest) Descriptor() ([]byte, []int) {
+	return file_solar_system_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RestoreSolarSystemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_solar_system_proto protoreflect.FileDescriptor
+
+var file_solar_system_proto_rawDesc = string([]byte{
+	0x0a, 0x12, 0x73, 0x6f, 0x6c, 0x61, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76,
+	0x31, 0x1a, 0x16, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb7, 0x01, 0x0a, 0x0b, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x49, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x64, 0x69, 0x74,
+	0x79, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x64, 0x69, 0x74, 0x79, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x10, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x64, 0x69, 0x74, 0x79, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x22,
+	0x7b, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x92, 0x01, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0d, 0x73, 0x6f, 0x6c,
+	0x61, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x0c, 0x73, 0x6f, 0x6c, 0x61,
+	0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x50, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6c,
+	0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x2a, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x6f, 0x6c,
+	0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x2b, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x32, 0xc7, 0x03, 0x0a,
+	0x12, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x24, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x22, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69,
+	0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x12, 0x54, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6f,
+	0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x25, 0x2e, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6f,
+	0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x52, 0x0a, 0x11, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
+	0x25, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x56,
+	0x0a, 0x12, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x12, 0x26, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6c, 0x61, 0x72, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x69, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6c, 0x61, 0x72,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x46, 0x61, 0x69, 0x72, 0x6c, 0x65, 0x79, 0x43, 0x2f, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x2d, 0x73, 0x69, 0x6d, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+})
+
+var (
+	file_solar_system_proto_rawDescOnce sync.Once
+	file_solar_system_proto_rawDescData []byte
+)
+
+func file_solar_system_proto_rawDescGZIP() []byte {
+	file_solar_system_proto_rawDescOnce.Do(func() {
+		file_solar_system_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_solar_system_proto_rawDesc), len(file_solar_system_proto_rawDesc)))
+	})
+	return file_solar_system_proto_rawDescData
+}
+
+var file_solar_system_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_solar_system_proto_goTypes = []any{
+	(*SolarSystem)(nil),               // 0: spacesim.v1.SolarSystem
+	(*ListSolarSystemsRequest)(nil),   // 1: spacesim.v1.ListSolarSystemsRequest
+	(*ListSolarSystemsResponse)(nil),  // 2: spacesim.v1.ListSolarSystemsResponse
+	(*GetSolarSystemRequest)(nil),     // 3: spacesim.v1.GetSolarSystemRequest
+	(*CreateSolarSystemRequest)(nil),  // 4: spacesim.v1.CreateSolarSystemRequest
+	(*RemoveSolarSystemRequest)(nil),  // 5: spacesim.v1.RemoveSolarSystemRequest
+	(*RestoreSolarSystemRequest)(nil), // 6: spacesim.v1.RestoreSolarSystemRequest
+	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*CommodityMarket)(nil),           // 8: spacesim.v1.CommodityMarket
+	(*Pagination)(nil),                // 9: spacesim.v1.Pagination
+	(*emptypb.Empty)(nil),             // 10: google.protobuf.Empty
+}
+var file_solar_system_proto_depIdxs = []int32{
+	7,  // 0: spacesim.v1.SolarSystem.deleted_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: spacesim.v1.SolarSystem.commodity_markets:type_name -> spacesim.v1.CommodityMarket
+	9,  // 2: spacesim.v1.ListSolarSystemsRequest.pagination:type_name -> spacesim.v1.Pagination
+	0,  // 3: spacesim.v1.ListSolarSystemsResponse.solar_systems:type_name -> spacesim.v1.SolarSystem
+	9,  // 4: spacesim.v1.ListSolarSystemsResponse.pagination:type_name -> spacesim.v1.Pagination
+	1,  // 5: spacesim.v1.SolarSystemService.ListSolarSystems:input_type -> spacesim.v1.ListSolarSystemsRequest
+	3,  // 6: spacesim.v1.SolarSystemService.GetSolarSystem:input_type -> spacesim.v1.GetSolarSystemRequest
+	4,  // 7: spacesim.v1.SolarSystemService.CreateSolarSystem:input_type -> spacesim.v1.CreateSolarSystemRequest
+	5,  // 8: spacesim.v1.SolarSystemService.RemoveSolarSystem:input_type -> spacesim.v1.RemoveSolarSystemRequest
+	6,  // 9: spacesim.v1.SolarSystemService.RestoreSolarSystem:input_type -> spacesim.v1.RestoreSolarSystemRequest
+	2,  // 10: spacesim.v1.SolarSystemService.ListSolarSystems:output_type -> spacesim.v1.ListSolarSystemsResponse
+	0,  // 11: spacesim.v1.SolarSystemService.GetSolarSystem:output_type -> spacesim.v1.SolarSystem
+	0,  // 12: spacesim.v1.SolarSystemService.CreateSolarSystem:output_type -> spacesim.v1.SolarSystem
+	10, // 13: spacesim.v1.SolarSystemService.RemoveSolarSystem:output_type -> google.protobuf.Empty
+	0,  // 14: spacesim.v1.SolarSystemService.RestoreSolarSystem:output_type -> spacesim.v1.SolarSystem
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_solar_system_proto_init() }
+func file_solar_system_proto_init() {
+	if File_solar_system_proto != nil {
+		return
+	}
+	file_commodity_market_proto_init()
+	file_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_solar_system_proto_rawDesc), len(file_solar_system_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_solar_system_proto_goTypes,
+		DependencyIndexes: file_solar_system_proto_depIdxs,
+		MessageInfos:      file_solar_system_proto_msgTypes,
+	}.Build()
+	File_solar_system_proto = out.File
+	file_solar_system_proto_goTypes = nil
+	file_solar_system_proto_depIdxs = nil
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: solar_system.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SolarSystemService_ListSolarSystems_FullMethodName   = "/spacesim.v1.SolarSystemService/ListSolarSystems"
+	SolarSystemService_GetSolarSystem_FullMethodName     = "/spacesim.v1.SolarSystemService/GetSolarSystem"
+	SolarSystemService_CreateSolarSystem_FullMethodName  = "/spacesim.v1.SolarSystemService/CreateSolarSystem"
+	SolarSystemService_RemoveSolarSystem_FullMethodName  = "/spacesim.v1.SolarSystemService/RemoveSolarSystem"
+	SolarSystemService_RestoreSolarSystem_FullMethodName = "/spacesim.v1.SolarSystemService/RestoreSolarSystem"
+)
+
+// SolarSystemServiceClient is the client API for SolarSystemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SolarSystemServiceClient interface {
+	ListSolarSystems(ctx context.Context, in *ListSolarSystemsRequest, opts ...grpc.CallOption) (*ListSolarSystemsResponse, error)
+	GetSolarSystem(ctx context.Context, in *GetSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error)
+	CreateSolarSystem(ctx context.Context, in *CreateSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error)
+	RemoveSolarSystem(ctx context.Context, in *RemoveSolarSystemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreSolarSystem(ctx context.Context, in *RestoreSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error)
+}
+
+type solarSystemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSolarSystemServiceClient(cc grpc.ClientConnInterface) SolarSystemServiceClient {
+	return &solarSystemServiceClient{cc}
+}
+
+func (c *solarSystemServiceClient) ListSolarSystems(ctx context.Context, in *ListSolarSystemsRequest, opts ...grpc.CallOption) (*ListSolarSystemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSolarSystemsResponse)
+	err := c.cc.Invoke(ctx, SolarSystemService_ListSolarSystems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solarSystemServiceClient) GetSolarSystem(ctx context.Context, in *GetSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolarSystem)
+	err := c.cc.Invoke(ctx, SolarSystemService_GetSolarSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solarSystemServiceClient) CreateSolarSystem(ctx context.Context, in *CreateSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolarSystem)
+	err := c.cc.Invoke(ctx, SolarSystemService_CreateSolarSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solarSystemServiceClient) RemoveSolarSystem(ctx context.Context, in *RemoveSolarSystemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SolarSystemService_RemoveSolarSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solarSystemServiceClient) RestoreSolarSystem(ctx context.Context, in *RestoreSolarSystemRequest, opts ...grpc.CallOption) (*SolarSystem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolarSystem)
+	err := c.cc.Invoke(ctx, SolarSystemService_RestoreSolarSystem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SolarSystemServiceServer is the server API for SolarSystemService service.
+// All implementations must embed UnimplementedSolarSystemServiceServer
+// for forward compatibility.
+type SolarSystemServiceServer interface {
+	ListSolarSystems(context.Context, *ListSolarSystemsRequest) (*ListSolarSystemsResponse, error)
+	GetSolarSystem(context.Context, *GetSolarSystemRequest) (*SolarSystem, error)
+	CreateSolarSystem(context.Context, *CreateSolarSystemRequest) (*SolarSystem, error)
+	RemoveSolarSystem(context.Context, *RemoveSolarSystemRequest) (*emptypb.Empty, error)
+	RestoreSolarSystem(context.Context, *RestoreSolarSystemRequest) (*SolarSystem, error)
+	mustEmbedUnimplementedSolarSystemServiceServer()
+}
+
+// UnimplementedSolarSystemServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSolarSystemServiceServer struct{}
+
+func (UnimplementedSolarSystemServiceServer) ListSolarSystems(context.Context, *ListSolarSystemsRequest) (*ListSolarSystemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSolarSystems not implemented")
+}
+func (UnimplementedSolarSystemServiceServer) GetSolarSystem(context.Context, *GetSolarSystemRequest) (*SolarSystem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSolarSystem not implemented")
+}
+func (UnimplementedSolarSystemServiceServer) CreateSolarSystem(context.Context, *CreateSolarSystemRequest) (*SolarSystem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSolarSystem not implemented")
+}
+func (UnimplementedSolarSystemServiceServer) RemoveSolarSystem(context.Context, *RemoveSolarSystemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSolarSystem not implemented")
+}
+func (UnimplementedSolarSystemServiceServer) RestoreSolarSystem(context.Context, *RestoreSolarSystemRequest) (*SolarSystem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSolarSystem not implemented")
+}
+func (UnimplementedSolarSystemServiceServer) mustEmbedUnimplementedSolarSystemServiceServer() {}
+func (UnimplementedSolarSystemServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeSolarSystemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SolarSystemServiceServer will
+// result in compilation errors.
+type UnsafeSolarSystemServiceServer interface {
+	mustEmbedUnimplementedSolarSystemServiceServer()
+}
+
+func RegisterSolarSystemServiceServer(s grpc.ServiceRegistrar, srv SolarSystemServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSolarSystemServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SolarSystemService_ServiceDesc, srv)
+}
+
+func _SolarSystemService_ListSolarSystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSolarSystemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolarSystemServiceServer).ListSolarSystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolarSystemService_ListSolarSystems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolarSystemServiceServer).ListSolarSystems(ctx, req.(*ListSolarSystemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolarSystemService_GetSolarSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSolarSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolarSystemServiceServer).GetSolarSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolarSystemService_GetSolarSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolarSystemServiceServer).GetSolarSystem(ctx, req.(*GetSolarSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolarSystemService_CreateSolarSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSolarSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolarSystemServiceServer).CreateSolarSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolarSystemService_CreateSolarSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolarSystemServiceServer).CreateSolarSystem(ctx, req.(*CreateSolarSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolarSystemService_RemoveSolarSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSolarSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolarSystemServiceServer).RemoveSolarSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolarSystemService_RemoveSolarSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolarSystemServiceServer).RemoveSolarSystem(ctx, req.(*RemoveSolarSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolarSystemService_RestoreSolarSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSolarSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolarSystemServiceServer).RestoreSolarSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolarSystemService_RestoreSolarSystem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolarSystemServiceServer).RestoreSolarSystem(ctx, req.(*RestoreSolarSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SolarSystemService_ServiceDesc is the grpc.ServiceDesc for SolarSystemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SolarSystemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacesim.v1.SolarSystemService",
+	HandlerType: (*SolarSystemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSolarSystems",
+			Handler:    _SolarSystemService_ListSolarSystems_Handler,
+		},
+		{
+			MethodName: "GetSolarSystem",
+			Handler:    _SolarSystemService_GetSolarSystem_Handler,
+		},
+		{
+			MethodName: "CreateSolarSystem",
+			Handler:    _SolarSystemService_CreateSolarSystem_Handler,
+		},
+		{
+			MethodName: "RemoveSolarSystem",
+			Handler:    _SolarSystemService_RemoveSolarSystem_Handler,
+		},
+		{
+			MethodName: "RestoreSolarSystem",
+			Handler:    _SolarSystemService_RestoreSolarSystem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "solar_system.proto",
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"log"
+	"net"
+	"os"
+
+	"github.com/FairleyC/space-sim-service/internal/ratelimit"
+	"github.com/FairleyC/space-sim-service/internal/transport/grpc/pb"
+	transport "github.com/FairleyC/space-sim-service/internal/transport/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const DefaultAddr = ":9090"
+
+// Server - exposes the same services as the REST transport over gRPC,
+// listening on GRPC_ADDR (default ":9090").
+type Server struct {
+	CommodityService   transport.HttpExposedCommodityService
+	SolarSystemService transport.HttpExposedSolarSystemService
+	AuthService        transport.HttpExposedAuthService
+	StreamService      transport.HttpExposedStreamService
+	RateLimiter        *ratelimit.Limiter
+	Addr               string
+	Server             *grpc.Server
+}
+
+func NewServer(commodityService transport.HttpExposedCommodityService, solarSystemService transport.HttpExposedSolarSystemService, authService transport.HttpExposedAuthService, streamService transport.HttpExposedStreamService, rateLimiter *ratelimit.Limiter) *Server {
+	s := &Server{
+		CommodityService:   commodityService,
+		SolarSystemService: solarSystemService,
+		AuthService:        authService,
+		StreamService:      streamService,
+		RateLimiter:        rateLimiter,
+		Addr:               DefaultAddr,
+	}
+
+	if addr := os.Getenv("GRPC_ADDR"); addr != "" {
+		s.Addr = addr
+	}
+
+	s.Server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.rateLimitUnaryInterceptor, s.authUnaryInterceptor, s.principalRateLimitUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.rateLimitStreamInterceptor, s.authStreamInterceptor, s.principalRateLimitStreamInterceptor),
+	)
+
+	pb.RegisterCommodityServiceServer(s.Server, &commodityServer{server: s})
+	pb.RegisterSolarSystemServiceServer(s.Server, &solarSystemServer{server: s})
+	pb.RegisterCommodityMarketServiceServer(s.Server, &commodityMarketServer{server: s})
+	reflection.Register(s.Server)
+
+	return s
+}
+
+// Serve - listens until Stop is called. Unlike the REST handler it
+// does not wait for a signal, so it is run alongside it.
+func (s *Server) Serve() error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Serving gRPC on", s.Addr)
+
+	return s.Server.Serve(listener)
+}
+
+// Stop - lets in-flight calls finish before closing the listener.
+// Open WatchMarkets streams end once the stream service is closed.
+func (s *Server) Stop() {
+	s.Server.GracefulStop()
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/transport/grpc/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type solarSystemServer struct {
+	pb.UnimplementedSolarSystemServiceServer
+	server *Server
+}
+
+func (s *solarSystemServer) ListSolarSystems(ctx context.Context, req *pb.ListSolarSystemsRequest) (*pb.ListSolarSystemsResponse, error) {
+	pagination := convertPagination(req.GetPagination())
+
+	solarSystems, err := s.server.SolarSystemService.FindAllSolarSystems(ctx, pagination, solarSystem.Filter{IncludeDeleted: req.GetIncludeDeleted()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &pb.ListSolarSystemsResponse{Pagination: convertPaginationToProto(pagination)}
+	for _, system := range solarSystems {
+		response.SolarSystems = append(response.SolarSystems, convertSolarSystemToProto(system))
+	}
+
+	return response, nil
+}
+
+func (s *solarSystemServer) GetSolarSystem(ctx context.Context, req *pb.GetSolarSystemRequest) (*pb.SolarSystem, error) {
+	found, err := s.server.SolarSystemService.FindSolarSystem(ctx, req.GetId(), req.GetIncludeDeleted())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertSolarSystemWithCommodityMarketsToProto(found), nil
+}
+
+func (s *solarSystemServer) CreateSolarSystem(ctx context.Context, req *pb.CreateSolarSystemRequest) (*pb.SolarSystem, error) {
+	created, err := s.server.SolarSystemService.CreateSolarSystem(ctx, solarSystem.SolarSystem{Name: req.GetName()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertSolarSystemToProto(created), nil
+}
+
+func (s *solarSystemServer) RemoveSolarSystem(ctx context.Context, req *pb.RemoveSolarSystemRequest) (*emptypb.Empty, error) {
+	if err := s.server.SolarSystemService.RemoveSolarSystem(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *solarSystemServer) RestoreSolarSystem(ctx context.Context, req *pb.RestoreSolarSystemRequest) (*pb.SolarSystem, error) {
+	restored, err := s.server.SolarSystemService.RestoreSolarSystem(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return convertSolarSystemWithCommodityMarketsToProto(restored), nil
+}
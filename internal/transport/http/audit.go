@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+)
+
+func (h *Handler) GetAuditEntries(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetAuditEntries")
+
+	pagination := data.GetPagination(r)
+
+	query := r.URL.Query()
+	filter := audit.Filter{
+		EntityType: query.Get("entity"),
+		EntityID:   query.Get("id"),
+		ActorID:    query.Get("actor"),
+	}
+
+	var err error
+	if filter.From, err = getTimeParam(r, "from"); err != nil {
+		log.Println("Invalid from time", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = getTimeParam(r, "to"); err != nil {
+		log.Println("Invalid to time", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.AuditService.FindEntries(r.Context(), filter, pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, audit.ErrInvalidFilter) {
+			log.Println("Invalid audit filter", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		log.Println("Error getting audit entries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(AuditEntryListV1{
+		Entries:    mapDtos(entries, newAuditEntryV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding audit entries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// getTimeParam - reads an optional RFC3339 timestamp from the
+// query string, returning nil when the parameter is absent.
+func getTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
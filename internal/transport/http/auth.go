@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetApiKeys")
+
+	pagination := data.GetPagination(r)
+
+	apiKeys, err := h.AuthService.FindAllApiKeys(r.Context(), pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error getting api keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(ApiKeyListV1{
+		ApiKeys:    mapDtos(apiKeys, newApiKeyV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding api keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostApiKey(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostApiKey")
+	var request CreateApiKeyRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding api key", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Name == "" {
+		log.Println("Name was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Role == "" {
+		request.Role = string(auth.RoleReadOnly)
+	}
+
+	apiKey, err := h.AuthService.CreateApiKey(r.Context(), request.Name, auth.Role(request.Role))
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidRole) {
+			log.Println("Invalid role", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		log.Println("Error creating api key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(CreatedApiKeyV1{
+		ApiKeyV1: newApiKeyV1(apiKey.ApiKey),
+		Key:      apiKey.Key,
+	}); err != nil {
+		log.Println("Error encoding api key", err)
+		return
+	}
+}
+
+func (h *Handler) DeleteApiKey(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteApiKey")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.AuthService.RemoveApiKey(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, auth.ErrApiKeyNotFound) {
+			log.Println("Api key not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting api key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
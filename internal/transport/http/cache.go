@@ -0,0 +1,40 @@
+package http
+
+import (
+	"expvar"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+// writeCacheControl - lets clients reuse the response of a live read
+// for as long as the server caches it. Responses depend on the
+// caller's credentials, so shared caches may not store them, and
+// reads of deleted rows are never cached on either side.
+func (h *Handler) writeCacheControl(w http.ResponseWriter, r *http.Request) {
+	if h.CacheMaxAge <= 0 || getIncludeDeleted(r) {
+		w.Header().Set("Cache-Control", "private, no-cache")
+		return
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.CacheMaxAge.Seconds())))
+}
+
+// GetMetrics - the process's expvar metrics, among them the cache's
+// hits and misses per namespace.
+func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetMetrics")
+
+	if err := auth.Authorize(r.Context(), auth.ResourceMetrics, auth.OperationRead); err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error authorizing metrics", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	expvar.Handler().ServeHTTP(w, r)
+}
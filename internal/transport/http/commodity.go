@@ -11,26 +11,36 @@ import (
 	"github.com/gorilla/mux"
 )
 
-type CommodityResponse struct {
-	Commodities []commodity.Commodity `json:"commodities"`
-	Pagination  data.Pagination       `json:"pagination"`
-}
-
 func (h *Handler) GetCommodities(w http.ResponseWriter, r *http.Request) {
 	log.Println("REQUEST: GetCommodities")
 
 	pagination := data.GetPagination(r)
 
-	commodities, err := h.CommodityService.FindAllCommodity(r.Context(), pagination)
+	filter := commodity.Filter{
+		IncludeDeleted: getIncludeDeleted(r),
+		Category:       commodity.Category(r.URL.Query().Get("category")),
+		Tag:            r.URL.Query().Get("tag"),
+	}
+
+	commodities, err := h.CommodityService.FindAllCommodity(r.Context(), pagination, filter)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, commodity.ErrInvalidCategory) {
+			log.Println("Invalid commodity filter", err)
+			writeInvalidRequest(w, err)
+			return
+		}
 		log.Println("Error getting commodities", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(CommodityResponse{
-		Commodities: commodities,
-		Pagination:  pagination,
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(CommodityListV1{
+		Commodities: mapDtos(commodities, newCommodityV1),
+		Pagination:  newPaginationV1(pagination),
 	}); err != nil {
 		log.Println("Error encoding commodities", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -49,8 +59,11 @@ func (h *Handler) GetCommodity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foundCommodity, err := h.CommodityService.FindCommodity(r.Context(), id)
+	foundCommodity, err := h.CommodityService.FindCommodity(r.Context(), id, getIncludeDeleted(r))
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
 		if errors.Is(err, commodity.ErrCommodityNotFound) {
 			log.Println("Commodity not found", err)
 			w.WriteHeader(http.StatusNotFound)
@@ -61,45 +74,53 @@ func (h *Handler) GetCommodity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(foundCommodity); err != nil {
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(newCommodityV1(foundCommodity)); err != nil {
 		log.Println("Error encoding commodity", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-type CommodityJson struct {
-	ID         string
-	Name       string
-	UnitMass   float64
-	UnitVolume float64
-}
-
 func (h *Handler) PostCommodity(w http.ResponseWriter, r *http.Request) {
 	log.Println("REQUEST: PostCommodity")
-	var commodityJson CommodityJson
-	if err := json.NewDecoder(r.Body).Decode(&commodityJson); err != nil {
+	var request CreateCommodityRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
 		log.Println("Error decoding commodity", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	commodity := commodity.Commodity{
-		ID:         commodityJson.ID,
-		Name:       commodityJson.Name,
-		UnitMass:   commodityJson.UnitMass,
-		UnitVolume: commodityJson.UnitVolume,
+	newCommodity := commodity.Commodity{
+		Name:       request.Name,
+		UnitMass:   request.UnitMass,
+		UnitVolume: request.UnitVolume,
+		Category:   commodity.Category(request.Category),
+		Tags:       request.Tags,
 	}
 
-	commodity, err := h.CommodityService.CreateCommodity(r.Context(), commodity)
+	newCommodity, err := h.CommodityService.CreateCommodity(r.Context(), newCommodity)
 
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, commodity.ErrInvalidCategory) || errors.Is(err, commodity.ErrInvalidTag) {
+			log.Println("Invalid commodity", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityConflict) {
+			log.Println("Commodity name is taken", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
 		log.Println("Error creating commodity", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(commodity); err != nil {
+	if err := json.NewEncoder(w).Encode(newCommodityV1(newCommodity)); err != nil {
 		log.Println("Error encoding commodity", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -116,8 +137,47 @@ func (h *Handler) DeleteCommodity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.CommodityService.RemoveCommodity(r.Context(), id)
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = string(commodity.RemovalModeRestrict)
+	}
+
+	removalMode, err := commodity.ParseRemovalMode(mode)
+	if err != nil {
+		log.Println("Invalid removal mode", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = h.CommodityService.RemoveCommodity(r.Context(), id, removalMode)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityNotFound) {
+			log.Println("Commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityInUse) {
+			log.Println("Commodity is in use", err)
+			response := CommodityInUseV1{
+				Error:            commodity.ErrCommodityInUse.Error(),
+				CommodityMarkets: []DependentMarketV1{},
+			}
+
+			var inUseError *commodity.InUseError
+			if errors.As(err, &inUseError) {
+				response.CommodityMarkets = mapDtos(inUseError.Markets, newDependentMarketV1)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			if err := json.NewEncoder(w).Encode(response); err != nil {
+				log.Println("Error encoding commodity in use response", err)
+			}
+			return
+		}
 		log.Println("Error deleting commodity", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -125,3 +185,41 @@ func (h *Handler) DeleteCommodity(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *Handler) RestoreCommodity(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RestoreCommodity")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restoredCommodity, err := h.CommodityService.RestoreCommodity(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityNotFound) {
+			log.Println("Commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityConflict) {
+			log.Println("Commodity name has been taken since it was removed", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error restoring commodity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newCommodityV1(restoredCommodity)); err != nil {
+		log.Println("Error encoding commodity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetCommodityMarkets(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetCommodityMarkets")
+
+	pagination := data.GetPagination(r)
+
+	filter, err := getMarketFilter(r)
+	if err != nil {
+		log.Println("Invalid commodity market filter", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filter.CommodityID = r.URL.Query().Get("commodityId")
+	filter.SolarSystemID = r.URL.Query().Get("solarSystemId")
+	filter.StationID = r.URL.Query().Get("stationId")
+
+	h.writeCommodityMarkets(w, r, pagination, filter)
+}
+
+// GetCommodityMarketsByCommodity - every solar system trading the
+// commodity, so its prices can be compared across the galaxy.
+func (h *Handler) GetCommodityMarketsByCommodity(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetCommodityMarketsByCommodity")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pagination := data.GetPagination(r)
+
+	filter, err := getMarketFilter(r)
+	if err != nil {
+		log.Println("Invalid commodity market filter", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	filter.CommodityID = id
+	filter.SolarSystemID = r.URL.Query().Get("solarSystemId")
+	filter.StationID = r.URL.Query().Get("stationId")
+
+	// a commodity no system trades lists no markets, which only
+	// tells the caller apart from a missing one when checked first
+	if _, err := h.CommodityService.FindCommodity(r.Context(), id, filter.IncludeDeleted); err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityNotFound) {
+			log.Println("Commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting commodity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCommodityMarkets(w, r, pagination, filter)
+}
+
+func (h *Handler) writeCommodityMarkets(w http.ResponseWriter, r *http.Request, pagination data.Pagination, filter solarSystem.MarketFilter) {
+	commodityMarkets, err := h.SolarSystemService.FindAllCommodityMarkets(r.Context(), pagination, filter)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error getting commodity markets", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(newCommodityMarketListResponse(r, commodityMarkets, pagination)); err != nil {
+		log.Println("Error encoding commodity markets", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// getMarketFilter - reads the price and demand bounds shared by the
+// market listings from the query string.
+func getMarketFilter(r *http.Request) (solarSystem.MarketFilter, error) {
+	filter := solarSystem.MarketFilter{
+		IncludeDeleted: getIncludeDeleted(r),
+	}
+
+	var err error
+	if filter.MinPrice, err = getFloatParam(r, "minPrice"); err != nil {
+		return solarSystem.MarketFilter{}, err
+	}
+	if filter.MaxPrice, err = getFloatParam(r, "maxPrice"); err != nil {
+		return solarSystem.MarketFilter{}, err
+	}
+	if filter.MinDemand, err = getIntParam(r, "minDemand"); err != nil {
+		return solarSystem.MarketFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// getFloatParam - reads an optional number from the query
+// string, returning nil when the parameter is absent.
+func getFloatParam(r *http.Request, name string) (*float64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+// getIntParam - reads an optional integer from the query
+// string, returning nil when the parameter is absent.
+func getIntParam(r *http.Request, name string) (*int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,1120 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
+)
+
+// The request and response bodies of the REST API. They are kept apart
+// from the domain types so that a change to a service can't silently
+// change the wire format; once published, a versioned type only gains
+// fields. v2 shares every v1 type it has no V2 counterpart for.
+
+// decodeJsonBody - decodes a request body into a DTO, rejecting
+// fields the DTO does not declare rather than dropping them.
+func decodeJsonBody(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// mapDtos - converts a slice of domain values, returning an empty
+// slice rather than nil so lists always encode as [].
+func mapDtos[T any, D any](values []T, convert func(T) D) []D {
+	dtos := make([]D, 0, len(values))
+	for _, value := range values {
+		dtos = append(dtos, convert(value))
+	}
+	return dtos
+}
+
+// optionalString - an empty reference encodes as null.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+type PaginationV1 struct {
+	Page    int    `json:"page"`
+	PerPage int    `json:"perPage"`
+	OrderBy string `json:"orderBy"`
+}
+
+func newPaginationV1(pagination data.Pagination) PaginationV1 {
+	return PaginationV1{
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+		OrderBy: pagination.OrderBy,
+	}
+}
+
+type CreateCommodityRequestV1 struct {
+	Name       string   `json:"name"`
+	UnitMass   float64  `json:"unitMass"`
+	UnitVolume float64  `json:"unitVolume"`
+	Category   string   `json:"category"`
+	Tags       []string `json:"tags"`
+}
+
+type CommodityV1 struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	UnitMass   float64    `json:"unitMass"`
+	UnitVolume float64    `json:"unitVolume"`
+	Category   string     `json:"category"`
+	Tags       []string   `json:"tags"`
+	DeletedAt  *time.Time `json:"deletedAt"`
+}
+
+func newCommodityV1(commodity commodity.Commodity) CommodityV1 {
+	return CommodityV1{
+		ID:         commodity.ID,
+		Name:       commodity.Name,
+		UnitMass:   commodity.UnitMass,
+		UnitVolume: commodity.UnitVolume,
+		Category:   string(commodity.Category),
+		Tags:       commodity.Tags,
+		DeletedAt:  commodity.DeletedAt,
+	}
+}
+
+type CommodityListV1 struct {
+	Commodities []CommodityV1 `json:"commodities"`
+	Pagination  PaginationV1  `json:"pagination"`
+}
+
+type DependentMarketV1 struct {
+	ID              string `json:"id"`
+	SolarSystemID   string `json:"solarSystemId"`
+	SolarSystemName string `json:"solarSystemName"`
+}
+
+func newDependentMarketV1(market commodity.DependentMarket) DependentMarketV1 {
+	return DependentMarketV1{
+		ID:              market.ID,
+		SolarSystemID:   market.SolarSystemID,
+		SolarSystemName: market.SolarSystemName,
+	}
+}
+
+// CommodityInUseV1 - the conflict response of a restricted
+// commodity removal, listing the markets in the way.
+type CommodityInUseV1 struct {
+	Error            string              `json:"error"`
+	CommodityMarkets []DependentMarketV1 `json:"commodityMarkets"`
+}
+
+// CoordinatesV1 - a position in the galaxy, in light years from its centre.
+type CoordinatesV1 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+func newCoordinatesV1(coordinates solarSystem.Coordinates) CoordinatesV1 {
+	return CoordinatesV1{X: coordinates.X, Y: coordinates.Y, Z: coordinates.Z}
+}
+
+func (c CoordinatesV1) toCoordinates() solarSystem.Coordinates {
+	return solarSystem.Coordinates{X: c.X, Y: c.Y, Z: c.Z}
+}
+
+type CreateSolarSystemRequestV1 struct {
+	Name        string        `json:"name"`
+	Coordinates CoordinatesV1 `json:"coordinates"`
+	StarClass   string        `json:"starClass"`
+	Region      string        `json:"region"`
+	Sector      string        `json:"sector"`
+}
+
+type SolarSystemV1 struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Coordinates CoordinatesV1 `json:"coordinates"`
+	StarClass   string        `json:"starClass"`
+	Region      string        `json:"region"`
+	Sector      string        `json:"sector"`
+	DeletedAt   *time.Time    `json:"deletedAt"`
+}
+
+func newSolarSystemV1(solarSystem solarSystem.SolarSystem) SolarSystemV1 {
+	return SolarSystemV1{
+		ID:          solarSystem.ID,
+		Name:        solarSystem.Name,
+		Coordinates: newCoordinatesV1(solarSystem.Coordinates),
+		StarClass:   string(solarSystem.StarClass),
+		Region:      solarSystem.Region,
+		Sector:      solarSystem.Sector,
+		DeletedAt:   solarSystem.DeletedAt,
+	}
+}
+
+// SolarSystemDetailV1 - a solar system together with its markets.
+type SolarSystemDetailV1 struct {
+	ID               string              `json:"id"`
+	Name             string              `json:"name"`
+	Coordinates      CoordinatesV1       `json:"coordinates"`
+	StarClass        string              `json:"starClass"`
+	Region           string              `json:"region"`
+	Sector           string              `json:"sector"`
+	DeletedAt        *time.Time          `json:"deletedAt"`
+	CommodityMarkets []CommodityMarketV1 `json:"commodityMarkets"`
+}
+
+func newSolarSystemDetailV1(solarSystem solarSystem.SolarSystemWithCommodityMarkets) SolarSystemDetailV1 {
+	return SolarSystemDetailV1{
+		ID:               solarSystem.ID,
+		Name:             solarSystem.Name,
+		Coordinates:      newCoordinatesV1(solarSystem.Coordinates),
+		StarClass:        string(solarSystem.StarClass),
+		Region:           solarSystem.Region,
+		Sector:           solarSystem.Sector,
+		DeletedAt:        solarSystem.DeletedAt,
+		CommodityMarkets: mapDtos(solarSystem.CommodityMarkets, newCommodityMarketV1),
+	}
+}
+
+type SolarSystemListV1 struct {
+	SolarSystems []SolarSystemV1 `json:"solarSystems"`
+	Pagination   PaginationV1    `json:"pagination"`
+}
+
+// NearbySolarSystemV1 - a solar system and its distance in light years
+// from the point or solar system it was found around.
+type NearbySolarSystemV1 struct {
+	SolarSystemV1
+	Distance float64 `json:"distance"`
+}
+
+func newNearbySolarSystemV1(nearby solarSystem.NearbySolarSystem) NearbySolarSystemV1 {
+	return NearbySolarSystemV1{SolarSystemV1: newSolarSystemV1(nearby.SolarSystem), Distance: nearby.Distance}
+}
+
+type NearbySolarSystemListV1 struct {
+	SolarSystems []NearbySolarSystemV1 `json:"solarSystems"`
+	Pagination   PaginationV1          `json:"pagination"`
+}
+
+type NeighborListV1 struct {
+	SolarSystems []NearbySolarSystemV1 `json:"solarSystems"`
+}
+
+// CreateCommodityMarketRequestV1 - a market without a stationId is
+// traded at the solar system level.
+type CreateCommodityMarketRequestV1 struct {
+	CommodityID    string  `json:"commodityId"`
+	StationID      string  `json:"stationId"`
+	BasePrice      float64 `json:"basePrice"`
+	DemandQuantity int     `json:"demandQuantity"`
+}
+
+type UpdateCommodityMarketRequestV1 struct {
+	BasePrice      float64 `json:"basePrice"`
+	DemandQuantity int     `json:"demandQuantity"`
+}
+
+// ReplaceCommodityMarketsRequestV1 - the full list of markets the
+// solar system should trade. An empty list removes them all, but
+// leaving the list out is rejected.
+type ReplaceCommodityMarketsRequestV1 struct {
+	CommodityMarkets []CreateCommodityMarketRequestV1 `json:"commodityMarkets"`
+}
+
+func (r ReplaceCommodityMarketsRequestV1) toUpserts() []solarSystem.CommodityMarketUpsert {
+	upserts := make([]solarSystem.CommodityMarketUpsert, 0, len(r.CommodityMarkets))
+	for _, market := range r.CommodityMarkets {
+		upserts = append(upserts, solarSystem.CommodityMarketUpsert{
+			CommodityID:    market.CommodityID,
+			BasePrice:      market.BasePrice,
+			DemandQuantity: market.DemandQuantity,
+		})
+	}
+	return upserts
+}
+
+type CommodityMarketV1 struct {
+	ID              string     `json:"id"`
+	SolarSystemID   string     `json:"solarSystemId"`
+	SolarSystemName string     `json:"solarSystemName"`
+	StationID       *string    `json:"stationId"`
+	StationName     *string    `json:"stationName"`
+	CommodityID     string     `json:"commodityId"`
+	CommodityName   string     `json:"commodityName"`
+	BasePrice       float64    `json:"basePrice"`
+	DemandQuantity  int        `json:"demandQuantity"`
+	Stock           int        `json:"stock"`
+	DeletedAt       *time.Time `json:"deletedAt"`
+}
+
+func newCommodityMarketV1(market solarSystem.CommodityMarket) CommodityMarketV1 {
+	return CommodityMarketV1{
+		ID:              market.ID,
+		SolarSystemID:   market.SolarSystemID,
+		SolarSystemName: market.SolarSystemName,
+		StationID:       optionalString(market.StationID),
+		StationName:     optionalString(market.StationName),
+		CommodityID:     market.CommodityID,
+		CommodityName:   market.CommodityName,
+		BasePrice:       market.BasePrice,
+		DemandQuantity:  market.DemandQuantity,
+		Stock:           market.Stock,
+		DeletedAt:       market.DeletedAt,
+	}
+}
+
+type CommodityMarketListV1 struct {
+	CommodityMarkets []CommodityMarketV1 `json:"commodityMarkets"`
+	Pagination       PaginationV1        `json:"pagination"`
+}
+
+// MarketPriceChangeV1 - the data of a MarketPriceChanged event: the
+// market with the base price it changed from.
+type MarketPriceChangeV1 struct {
+	CommodityMarketV1
+	PreviousBasePrice float64 `json:"previousBasePrice"`
+}
+
+func newMarketPriceChangeV1(change solarSystem.MarketPriceChange) MarketPriceChangeV1 {
+	return MarketPriceChangeV1{
+		CommodityMarketV1: newCommodityMarketV1(change.CommodityMarket),
+		PreviousBasePrice: change.PreviousBasePrice,
+	}
+}
+
+type CommodityMarketResultV1 struct {
+	Action          string            `json:"action"`
+	CommodityMarket CommodityMarketV1 `json:"commodityMarket"`
+}
+
+func newCommodityMarketResultV1(result solarSystem.CommodityMarketResult) CommodityMarketResultV1 {
+	return CommodityMarketResultV1{Action: string(result.Action), CommodityMarket: newCommodityMarketV1(result.CommodityMarket)}
+}
+
+type CommodityMarketResultListV1 struct {
+	Results []CommodityMarketResultV1 `json:"results"`
+}
+
+type CreateCelestialBodyRequestV1 struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	ParentID string `json:"parentId"`
+}
+
+type CelestialBodyV1 struct {
+	ID            string     `json:"id"`
+	SolarSystemID string     `json:"solarSystemId"`
+	ParentID      *string    `json:"parentId"`
+	Name          string     `json:"name"`
+	Kind          string     `json:"kind"`
+	DeletedAt     *time.Time `json:"deletedAt"`
+}
+
+func newCelestialBodyV1(body solarSystem.CelestialBody) CelestialBodyV1 {
+	return CelestialBodyV1{
+		ID:            body.ID,
+		SolarSystemID: body.SolarSystemID,
+		ParentID:      optionalString(body.ParentID),
+		Name:          body.Name,
+		Kind:          string(body.Kind),
+		DeletedAt:     body.DeletedAt,
+	}
+}
+
+type CelestialBodyListV1 struct {
+	CelestialBodies []CelestialBodyV1 `json:"celestialBodies"`
+}
+
+// StationRequestV1 - creates a station, or replaces one on update. A
+// missing kind is an orbital station, and a missing celestialBodyId
+// places it in deep space.
+type StationRequestV1 struct {
+	Name            string `json:"name"`
+	Kind            string `json:"kind"`
+	CelestialBodyID string `json:"celestialBodyId"`
+}
+
+func (s StationRequestV1) kind() solarSystem.StationKind {
+	if s.Kind == "" {
+		return solarSystem.StationKindStation
+	}
+	return solarSystem.StationKind(s.Kind)
+}
+
+type StationV1 struct {
+	ID              string     `json:"id"`
+	SolarSystemID   string     `json:"solarSystemId"`
+	CelestialBodyID *string    `json:"celestialBodyId"`
+	Name            string     `json:"name"`
+	Kind            string     `json:"kind"`
+	DeletedAt       *time.Time `json:"deletedAt"`
+}
+
+func newStationV1(station solarSystem.Station) StationV1 {
+	return StationV1{
+		ID:              station.ID,
+		SolarSystemID:   station.SolarSystemID,
+		CelestialBodyID: optionalString(station.CelestialBodyID),
+		Name:            station.Name,
+		Kind:            string(station.Kind),
+		DeletedAt:       station.DeletedAt,
+	}
+}
+
+type StationListV1 struct {
+	Stations []StationV1 `json:"stations"`
+}
+
+// StationDetailV1 - a station together with its markets.
+type StationDetailV1 struct {
+	StationV1
+	CommodityMarkets []CommodityMarketV1 `json:"commodityMarkets"`
+}
+
+func newStationDetailV1(station solarSystem.StationWithCommodityMarkets) StationDetailV1 {
+	return StationDetailV1{
+		StationV1:        newStationV1(station.Station),
+		CommodityMarkets: mapDtos(station.CommodityMarkets, newCommodityMarketV1),
+	}
+}
+
+// LegalityRuleRequestV1 - creates a rule covering either a commodityId
+// or a category, or, on update, changes its status and taxRate.
+type LegalityRuleRequestV1 struct {
+	CommodityID string  `json:"commodityId"`
+	Category    string  `json:"category"`
+	Status      string  `json:"status"`
+	TaxRate     float64 `json:"taxRate"`
+}
+
+type LegalityRuleV1 struct {
+	ID            string  `json:"id"`
+	SolarSystemID string  `json:"solarSystemId"`
+	CommodityID   *string `json:"commodityId"`
+	Category      *string `json:"category"`
+	Status        string  `json:"status"`
+	TaxRate       float64 `json:"taxRate"`
+}
+
+func newLegalityRuleV1(rule solarSystem.LegalityRule) LegalityRuleV1 {
+	return LegalityRuleV1{
+		ID:            rule.ID,
+		SolarSystemID: rule.SolarSystemID,
+		CommodityID:   optionalString(rule.CommodityID),
+		Category:      optionalString(string(rule.Category)),
+		Status:        string(rule.Status),
+		TaxRate:       rule.TaxRate,
+	}
+}
+
+type LegalityRuleListV1 struct {
+	LegalityRules []LegalityRuleV1 `json:"legalityRules"`
+}
+
+type RecipeComponentRequestV1 struct {
+	CommodityID string `json:"commodityId"`
+	Quantity    int    `json:"quantity"`
+}
+
+// RecipeRequestV1 - creates a recipe, or replaces one on update.
+type RecipeRequestV1 struct {
+	Name         string                     `json:"name"`
+	CycleSeconds int                        `json:"cycleSeconds"`
+	Inputs       []RecipeComponentRequestV1 `json:"inputs"`
+	Outputs      []RecipeComponentRequestV1 `json:"outputs"`
+}
+
+func (r RecipeRequestV1) toRecipe(id string) production.Recipe {
+	toComponent := func(component RecipeComponentRequestV1) production.Component {
+		return production.Component{CommodityID: component.CommodityID, Quantity: component.Quantity}
+	}
+	return production.Recipe{
+		ID:        id,
+		Name:      r.Name,
+		CycleTime: time.Duration(r.CycleSeconds) * time.Second,
+		Inputs:    mapDtos(r.Inputs, toComponent),
+		Outputs:   mapDtos(r.Outputs, toComponent),
+	}
+}
+
+type RecipeComponentV1 struct {
+	CommodityID   string `json:"commodityId"`
+	CommodityName string `json:"commodityName"`
+	Quantity      int    `json:"quantity"`
+}
+
+func newRecipeComponentV1(component production.Component) RecipeComponentV1 {
+	return RecipeComponentV1{
+		CommodityID:   component.CommodityID,
+		CommodityName: component.CommodityName,
+		Quantity:      component.Quantity,
+	}
+}
+
+type RecipeV1 struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	CycleSeconds int                 `json:"cycleSeconds"`
+	Inputs       []RecipeComponentV1 `json:"inputs"`
+	Outputs      []RecipeComponentV1 `json:"outputs"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+}
+
+func newRecipeV1(recipe production.Recipe) RecipeV1 {
+	return RecipeV1{
+		ID:           recipe.ID,
+		Name:         recipe.Name,
+		CycleSeconds: int(recipe.CycleTime / time.Second),
+		Inputs:       mapDtos(recipe.Inputs, newRecipeComponentV1),
+		Outputs:      mapDtos(recipe.Outputs, newRecipeComponentV1),
+		CreatedAt:    recipe.CreatedAt,
+		UpdatedAt:    recipe.UpdatedAt,
+	}
+}
+
+type RecipeListV1 struct {
+	Recipes    []RecipeV1   `json:"recipes"`
+	Pagination PaginationV1 `json:"pagination"`
+}
+
+// FacilityRequestV1 - creates a facility, or updates one. The
+// stationId is only read on create, and a missing enabled means true.
+type FacilityRequestV1 struct {
+	Name      string `json:"name"`
+	StationID string `json:"stationId"`
+	RecipeID  string `json:"recipeId"`
+	Enabled   *bool  `json:"enabled"`
+}
+
+func (f FacilityRequestV1) enabled() bool {
+	return f.Enabled == nil || *f.Enabled
+}
+
+type FacilityV1 struct {
+	ID            string     `json:"id"`
+	SolarSystemID string     `json:"solarSystemId"`
+	StationID     *string    `json:"stationId"`
+	RecipeID      string     `json:"recipeId"`
+	RecipeName    string     `json:"recipeName"`
+	Name          string     `json:"name"`
+	Enabled       bool       `json:"enabled"`
+	Status        string     `json:"status"`
+	StalledReason *string    `json:"stalledReason"`
+	LastCycleAt   *time.Time `json:"lastCycleAt"`
+	NextCycleAt   time.Time  `json:"nextCycleAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+func newFacilityV1(facility production.Facility) FacilityV1 {
+	return FacilityV1{
+		ID:            facility.ID,
+		SolarSystemID: facility.SolarSystemID,
+		StationID:     optionalString(facility.StationID),
+		RecipeID:      facility.RecipeID,
+		RecipeName:    facility.RecipeName,
+		Name:          facility.Name,
+		Enabled:       facility.Enabled,
+		Status:        string(facility.Status),
+		StalledReason: optionalString(facility.StalledReason),
+		LastCycleAt:   facility.LastCycleAt,
+		NextCycleAt:   facility.NextCycleAt,
+		CreatedAt:     facility.CreatedAt,
+		UpdatedAt:     facility.UpdatedAt,
+	}
+}
+
+type FacilityListV1 struct {
+	Facilities []FacilityV1 `json:"facilities"`
+}
+
+type MaterialV1 struct {
+	CommodityID   string  `json:"commodityId"`
+	CommodityName string  `json:"commodityName"`
+	Quantity      float64 `json:"quantity"`
+}
+
+func newMaterialV1(material production.Material) MaterialV1 {
+	return MaterialV1{
+		CommodityID:   material.CommodityID,
+		CommodityName: material.CommodityName,
+		Quantity:      material.Quantity,
+	}
+}
+
+type ProductionStepV1 struct {
+	MaterialV1
+	RecipeID   string  `json:"recipeId"`
+	RecipeName string  `json:"recipeName"`
+	Cycles     float64 `json:"cycles"`
+}
+
+func newProductionStepV1(step production.ProductionStep) ProductionStepV1 {
+	return ProductionStepV1{
+		MaterialV1: newMaterialV1(step.Material),
+		RecipeID:   step.RecipeID,
+		RecipeName: step.RecipeName,
+		Cycles:     step.Cycles,
+	}
+}
+
+type BillOfMaterialsV1 struct {
+	CommodityID   string             `json:"commodityId"`
+	CommodityName string             `json:"commodityName"`
+	Quantity      float64            `json:"quantity"`
+	Steps         []ProductionStepV1 `json:"steps"`
+	RawMaterials  []MaterialV1       `json:"rawMaterials"`
+}
+
+func newBillOfMaterialsV1(bill production.BillOfMaterials) BillOfMaterialsV1 {
+	return BillOfMaterialsV1{
+		CommodityID:   bill.CommodityID,
+		CommodityName: bill.CommodityName,
+		Quantity:      bill.Quantity,
+		Steps:         mapDtos(bill.Steps, newProductionStepV1),
+		RawMaterials:  mapDtos(bill.RawMaterials, newMaterialV1),
+	}
+}
+
+type CreateShipRequestV1 struct {
+	Name          string  `json:"name"`
+	SolarSystemID string  `json:"solarSystemId"`
+	Speed         float64 `json:"speed"`
+}
+
+type ShipTravelRequestV1 struct {
+	DestinationID string `json:"destinationId"`
+}
+
+// ShipTradeRequestV1 - a positive quantity buys from the market, a
+// negative one sells to it.
+type ShipTradeRequestV1 struct {
+	CommodityMarketID string `json:"commodityMarketId"`
+	Quantity          int    `json:"quantity"`
+}
+
+type CargoV1 struct {
+	CommodityID   string `json:"commodityId"`
+	CommodityName string `json:"commodityName"`
+	Quantity      int    `json:"quantity"`
+}
+
+func newCargoV1(cargo ship.Cargo) CargoV1 {
+	return CargoV1{
+		CommodityID:   cargo.CommodityID,
+		CommodityName: cargo.CommodityName,
+		Quantity:      cargo.Quantity,
+	}
+}
+
+type ShipV1 struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	SolarSystemID   string     `json:"solarSystemId"`
+	SolarSystemName string     `json:"solarSystemName"`
+	Speed           float64    `json:"speed"`
+	InTransit       bool       `json:"inTransit"`
+	DestinationID   *string    `json:"destinationId"`
+	DestinationName *string    `json:"destinationName"`
+	DepartedAt      *time.Time `json:"departedAt"`
+	ArrivesAt       *time.Time `json:"arrivesAt"`
+	Cargo           []CargoV1  `json:"cargo"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+func newShipV1(s ship.Ship) ShipV1 {
+	return ShipV1{
+		ID:              s.ID,
+		Name:            s.Name,
+		SolarSystemID:   s.SolarSystemID,
+		SolarSystemName: s.SolarSystemName,
+		Speed:           s.Speed,
+		InTransit:       s.InTransit(),
+		DestinationID:   optionalString(s.DestinationID),
+		DestinationName: optionalString(s.DestinationName),
+		DepartedAt:      s.DepartedAt,
+		ArrivesAt:       s.ArrivesAt,
+		Cargo:           mapDtos(s.Cargo, newCargoV1),
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+type ShipListV1 struct {
+	Ships      []ShipV1     `json:"ships"`
+	Pagination PaginationV1 `json:"pagination"`
+}
+
+type CreateApiKeyRequestV1 struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type ApiKeyV1 struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Prefix    string    `json:"prefix"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func newApiKeyV1(apiKey auth.ApiKey) ApiKeyV1 {
+	return ApiKeyV1{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Role:      string(apiKey.Role),
+		CreatedAt: apiKey.CreatedAt,
+	}
+}
+
+// CreatedApiKeyV1 - the only response carrying the plain text key.
+type CreatedApiKeyV1 struct {
+	ApiKeyV1
+	Key string `json:"key"`
+}
+
+type ApiKeyListV1 struct {
+	ApiKeys    []ApiKeyV1   `json:"apiKeys"`
+	Pagination PaginationV1 `json:"pagination"`
+}
+
+// AuditEntryV1 - before and after hold the entity as it was
+// recorded at the time, so their shape follows the entity's.
+type AuditEntryV1 struct {
+	ID         string          `json:"id"`
+	ActorID    string          `json:"actorId"`
+	ActorName  string          `json:"actorName"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+func newAuditEntryV1(entry audit.Entry) AuditEntryV1 {
+	return AuditEntryV1{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		Action:     string(entry.Action),
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Before:     entry.Before,
+		After:      entry.After,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+type AuditEntryListV1 struct {
+	Entries    []AuditEntryV1 `json:"entries"`
+	Pagination PaginationV1   `json:"pagination"`
+}
+
+type CreateWebhookRequestV1 struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+type WebhookV1 struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func newWebhookV1(webhook webhook.Webhook) WebhookV1 {
+	return WebhookV1{
+		ID:  webhook.ID,
+		URL: webhook.URL,
+		EventTypes: mapDtos(webhook.EventTypes, func(eventType events.Type) string {
+			return string(eventType)
+		}),
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+// CreatedWebhookV1 - the only response carrying the signing secret.
+type CreatedWebhookV1 struct {
+	WebhookV1
+	Secret string `json:"secret"`
+}
+
+type WebhookListV1 struct {
+	Webhooks   []WebhookV1  `json:"webhooks"`
+	Pagination PaginationV1 `json:"pagination"`
+}
+
+type DeliveryV1 struct {
+	ID                 string     `json:"id"`
+	WebhookID          string     `json:"webhookId"`
+	EventID            int64      `json:"eventId"`
+	EventType          string     `json:"eventType"`
+	Status             string     `json:"status"`
+	Attempts           int        `json:"attempts"`
+	LastError          string     `json:"lastError"`
+	LastResponseStatus int        `json:"lastResponseStatus"`
+	NextAttemptAt      *time.Time `json:"nextAttemptAt"`
+	DeliveredAt        *time.Time `json:"deliveredAt"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+func newDeliveryV1(delivery webhook.Delivery) DeliveryV1 {
+	return DeliveryV1{
+		ID:                 delivery.ID,
+		WebhookID:          delivery.WebhookID,
+		EventID:            delivery.EventID,
+		EventType:          string(delivery.EventType),
+		Status:             string(delivery.Status),
+		Attempts:           delivery.Attempts,
+		LastError:          delivery.LastError,
+		LastResponseStatus: delivery.LastResponseStatus,
+		NextAttemptAt:      delivery.NextAttemptAt,
+		DeliveredAt:        delivery.DeliveredAt,
+		CreatedAt:          delivery.CreatedAt,
+	}
+}
+
+type DeliveryListV1 struct {
+	Deliveries []DeliveryV1 `json:"deliveries"`
+	Pagination PaginationV1 `json:"pagination"`
+}
+
+// MarketCommodityV2 - the commodity embedded in a v2 market, carrying
+// what is needed to size a cargo without fetching the commodity.
+type MarketCommodityV2 struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	UnitMass   float64 `json:"unitMass"`
+	UnitVolume float64 `json:"unitVolume"`
+}
+
+type CommodityMarketV2 struct {
+	ID              string            `json:"id"`
+	SolarSystemID   string            `json:"solarSystemId"`
+	SolarSystemName string            `json:"solarSystemName"`
+	StationID       *string           `json:"stationId"`
+	StationName     *string           `json:"stationName"`
+	Commodity       MarketCommodityV2 `json:"commodity"`
+	BasePrice       float64           `json:"basePrice"`
+	DemandQuantity  int               `json:"demandQuantity"`
+	Stock           int               `json:"stock"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	UpdatedAt       time.Time         `json:"updatedAt"`
+	DeletedAt       *time.Time        `json:"deletedAt"`
+}
+
+func newCommodityMarketV2(market solarSystem.CommodityMarket) CommodityMarketV2 {
+	return CommodityMarketV2{
+		ID:              market.ID,
+		SolarSystemID:   market.SolarSystemID,
+		SolarSystemName: market.SolarSystemName,
+		StationID:       optionalString(market.StationID),
+		StationName:     optionalString(market.StationName),
+		Commodity: MarketCommodityV2{
+			ID:         market.CommodityID,
+			Name:       market.CommodityName,
+			UnitMass:   market.CommodityUnitMass,
+			UnitVolume: market.CommodityUnitVolume,
+		},
+		BasePrice:      market.BasePrice,
+		DemandQuantity: market.DemandQuantity,
+		Stock:          market.Stock,
+		CreatedAt:      market.CreatedAt,
+		UpdatedAt:      market.UpdatedAt,
+		DeletedAt:      market.DeletedAt,
+	}
+}
+
+type CommodityMarketListV2 struct {
+	CommodityMarkets []CommodityMarketV2 `json:"commodityMarkets"`
+	Pagination       PaginationV1        `json:"pagination"`
+}
+
+type StationDetailV2 struct {
+	StationV1
+	CommodityMarkets []CommodityMarketV2 `json:"commodityMarkets"`
+}
+
+func newStationDetailV2(station solarSystem.StationWithCommodityMarkets) StationDetailV2 {
+	return StationDetailV2{
+		StationV1:        newStationV1(station.Station),
+		CommodityMarkets: mapDtos(station.CommodityMarkets, newCommodityMarketV2),
+	}
+}
+
+type SolarSystemDetailV2 struct {
+	ID               string              `json:"id"`
+	Name             string              `json:"name"`
+	Coordinates      CoordinatesV1       `json:"coordinates"`
+	StarClass        string              `json:"starClass"`
+	Region           string              `json:"region"`
+	Sector           string              `json:"sector"`
+	DeletedAt        *time.Time          `json:"deletedAt"`
+	CommodityMarkets []CommodityMarketV2 `json:"commodityMarkets"`
+}
+
+func newSolarSystemDetailV2(solarSystem solarSystem.SolarSystemWithCommodityMarkets) SolarSystemDetailV2 {
+	return SolarSystemDetailV2{
+		ID:               solarSystem.ID,
+		Name:             solarSystem.Name,
+		Coordinates:      newCoordinatesV1(solarSystem.Coordinates),
+		StarClass:        string(solarSystem.StarClass),
+		Region:           solarSystem.Region,
+		Sector:           solarSystem.Sector,
+		DeletedAt:        solarSystem.DeletedAt,
+		CommodityMarkets: mapDtos(solarSystem.CommodityMarkets, newCommodityMarketV2),
+	}
+}
+
+type CommodityMarketResultV2 struct {
+	Action          string            `json:"action"`
+	CommodityMarket CommodityMarketV2 `json:"commodityMarket"`
+}
+
+func newCommodityMarketResultV2(result solarSystem.CommodityMarketResult) CommodityMarketResultV2 {
+	return CommodityMarketResultV2{Action: string(result.Action), CommodityMarket: newCommodityMarketV2(result.CommodityMarket)}
+}
+
+type CommodityMarketResultListV2 struct {
+	Results []CommodityMarketResultV2 `json:"results"`
+}
+
+// CommodityRecordV1, SolarSystemRecordV1 and MarketRecordV1 - the
+// elements of an import or export document, keyed by name.
+type CommodityRecordV1 struct {
+	Name       string   `json:"name"`
+	UnitMass   float64  `json:"unitMass"`
+	UnitVolume float64  `json:"unitVolume"`
+	Category   string   `json:"category"`
+	Tags       []string `json:"tags"`
+}
+
+func newCommodityRecordV1(record universe.Record) CommodityRecordV1 {
+	return CommodityRecordV1{Name: record.Name, UnitMass: record.UnitMass, UnitVolume: record.UnitVolume, Category: string(record.Category), Tags: record.Tags}
+}
+
+func (c CommodityRecordV1) toRecord() universe.Record {
+	return universe.Record{Kind: universe.KindCommodity, Name: c.Name, UnitMass: c.UnitMass, UnitVolume: c.UnitVolume, Category: commodity.Category(c.Category), Tags: c.Tags}
+}
+
+type SolarSystemRecordV1 struct {
+	Name        string        `json:"name"`
+	Coordinates CoordinatesV1 `json:"coordinates"`
+	StarClass   string        `json:"starClass"`
+	Region      string        `json:"region"`
+	Sector      string        `json:"sector"`
+}
+
+func newSolarSystemRecordV1(record universe.Record) SolarSystemRecordV1 {
+	return SolarSystemRecordV1{
+		Name:        record.Name,
+		Coordinates: newCoordinatesV1(record.Coordinates),
+		StarClass:   string(record.StarClass),
+		Region:      record.Region,
+		Sector:      record.Sector,
+	}
+}
+
+func (s SolarSystemRecordV1) toRecord() universe.Record {
+	return universe.Record{
+		Kind:        universe.KindSolarSystem,
+		Name:        s.Name,
+		Coordinates: s.Coordinates.toCoordinates(),
+		StarClass:   solarSystem.StarClass(s.StarClass),
+		Region:      s.Region,
+		Sector:      s.Sector,
+	}
+}
+
+type MarketRecordV1 struct {
+	SolarSystem    string  `json:"solarSystem"`
+	Commodity      string  `json:"commodity"`
+	BasePrice      float64 `json:"basePrice"`
+	DemandQuantity int     `json:"demandQuantity"`
+}
+
+func newMarketRecordV1(record universe.Record) MarketRecordV1 {
+	return MarketRecordV1{
+		SolarSystem:    record.SolarSystem,
+		Commodity:      record.Commodity,
+		BasePrice:      record.BasePrice,
+		DemandQuantity: record.DemandQuantity,
+	}
+}
+
+func (m MarketRecordV1) toRecord() universe.Record {
+	return universe.Record{
+		Kind:           universe.KindMarket,
+		SolarSystem:    m.SolarSystem,
+		Commodity:      m.Commodity,
+		BasePrice:      m.BasePrice,
+		DemandQuantity: m.DemandQuantity,
+	}
+}
+
+type ImportCountsV1 struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+func newImportCountsV1(counts universe.Counts) ImportCountsV1 {
+	return ImportCountsV1{Created: counts.Created, Updated: counts.Updated, Unchanged: counts.Unchanged}
+}
+
+type ImportChangeV1 struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	Action string `json:"action"`
+}
+
+func newImportChangeV1(change universe.Change) ImportChangeV1 {
+	return ImportChangeV1{Kind: string(change.Kind), Key: change.Key, Action: string(change.Action)}
+}
+
+// ImportReportV1 - changes lists only the records that created or
+// updated an entity; unchanged ones are only counted.
+type ImportReportV1 struct {
+	DryRun       bool             `json:"dryRun"`
+	Commodities  ImportCountsV1   `json:"commodities"`
+	SolarSystems ImportCountsV1   `json:"solarSystems"`
+	Markets      ImportCountsV1   `json:"markets"`
+	Changes      []ImportChangeV1 `json:"changes"`
+}
+
+func newImportReportV1(report universe.Report) ImportReportV1 {
+	return ImportReportV1{
+		DryRun:       report.DryRun,
+		Commodities:  newImportCountsV1(report.Commodities),
+		SolarSystems: newImportCountsV1(report.SolarSystems),
+		Markets:      newImportCountsV1(report.Markets),
+		Changes:      mapDtos(report.Changes, newImportChangeV1),
+	}
+}
+
+type DistributionV1 struct {
+	Kind   string  `json:"kind"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+}
+
+func (d DistributionV1) toDistribution() universe.Distribution {
+	return universe.Distribution{
+		Kind:   universe.DistributionKind(d.Kind),
+		Min:    d.Min,
+		Max:    d.Max,
+		Mean:   d.Mean,
+		StdDev: d.StdDev,
+	}
+}
+
+// GenerateRequestV1 - every field is optional, falling back to
+// universe.DefaultGenerateConfig, and a missing seed to a random one.
+type GenerateRequestV1 struct {
+	Seed                *int64          `json:"seed"`
+	SolarSystems        *int            `json:"solarSystems"`
+	Commodities         *int            `json:"commodities"`
+	MinMarketsPerSystem *int            `json:"minMarketsPerSystem"`
+	MaxMarketsPerSystem *int            `json:"maxMarketsPerSystem"`
+	BasePrice           *DistributionV1 `json:"basePrice"`
+	DemandQuantity      *DistributionV1 `json:"demandQuantity"`
+	GalaxyRadius        *float64        `json:"galaxyRadius"`
+	GalaxyThickness     *float64        `json:"galaxyThickness"`
+}
+
+func (g GenerateRequestV1) toGenerateConfig() universe.GenerateConfig {
+	config := universe.DefaultGenerateConfig()
+	config.Seed = time.Now().UnixNano()
+	if g.Seed != nil {
+		config.Seed = *g.Seed
+	}
+	if g.SolarSystems != nil {
+		config.SolarSystems = *g.SolarSystems
+	}
+	if g.Commodities != nil {
+		config.Commodities = *g.Commodities
+	}
+	if g.MinMarketsPerSystem != nil {
+		config.MinMarketsPerSystem = *g.MinMarketsPerSystem
+	}
+	if g.MaxMarketsPerSystem != nil {
+		config.MaxMarketsPerSystem = *g.MaxMarketsPerSystem
+	}
+	if g.BasePrice != nil {
+		config.BasePrice = g.BasePrice.toDistribution()
+	}
+	if g.DemandQuantity != nil {
+		config.DemandQuantity = g.DemandQuantity.toDistribution()
+	}
+	if g.GalaxyRadius != nil {
+		config.GalaxyRadius = *g.GalaxyRadius
+	}
+	if g.GalaxyThickness != nil {
+		config.GalaxyThickness = *g.GalaxyThickness
+	}
+	return config
+}
+
+// GenerateReportV1 - the import report of a generation, with the seed
+// it was drawn from so the same universe can be generated again.
+type GenerateReportV1 struct {
+	Seed int64 `json:"seed"`
+	ImportReportV1
+}
+
+func newGenerateReportV1(seed int64, report universe.Report) GenerateReportV1 {
+	return GenerateReportV1{Seed: seed, ImportReportV1: newImportReportV1(report)}
+}
+
+// NewEventDataV1 - the v1 DTO an event's domain data is published as,
+// so webhooks and stream subscribers receive the same camelCase shapes
+// as the REST API rather than whatever the domain structs look like.
+func NewEventDataV1(data any) any {
+	switch data := data.(type) {
+	case commodity.Commodity:
+		return newCommodityV1(data)
+	case commodity.DependentMarket:
+		return newDependentMarketV1(data)
+	case solarSystem.SolarSystem:
+		return newSolarSystemV1(data)
+	case solarSystem.SolarSystemWithCommodityMarkets:
+		return newSolarSystemDetailV1(data)
+	case solarSystem.CommodityMarket:
+		return newCommodityMarketV1(data)
+	case solarSystem.MarketPriceChange:
+		return newMarketPriceChangeV1(data)
+	case solarSystem.Station:
+		return newStationV1(data)
+	case solarSystem.StationWithCommodityMarkets:
+		return newStationDetailV1(data)
+	case solarSystem.LegalityRule:
+		return newLegalityRuleV1(data)
+	case production.Recipe:
+		return newRecipeV1(data)
+	case production.Facility:
+		return newFacilityV1(data)
+	case ship.Ship:
+		return newShipV1(data)
+	}
+
+	return data
+}
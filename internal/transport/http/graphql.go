@@ -0,0 +1,371 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// MaxGraphqlDepth - how deeply selections may nest. The schema is
+// cyclic (markets lead back to commodities and solar systems), so
+// without a limit one small query could fan out without bound.
+const MaxGraphqlDepth = 8
+
+var errInternalGraphql = errors.New("internal error")
+
+type GraphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (h *Handler) PostGraphql(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostGraphql")
+	var request GraphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("Error decoding graphql request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Query == "" {
+		log.Println("Query was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphqlLoadersKey{}, h.newGraphqlLoaders())
+	result := h.executeGraphql(ctx, request)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Println("Error encoding graphql result", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// executeGraphql - parses and validates the query before running it,
+// so the depth limit is checked ahead of any resolver.
+func (h *Handler) executeGraphql(ctx context.Context, request GraphqlRequest) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&h.GraphqlSchema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if depth := graphqlDepth(document); depth > MaxGraphqlDepth {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(fmt.Errorf("query depth %d exceeds the maximum of %d", depth, MaxGraphqlDepth))}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.GraphqlSchema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       ctx,
+	})
+}
+
+// graphqlDepth - the deepest nesting of fields in any operation of
+// the document, following fragment spreads. Introspection fields are
+// left out, as tools nest them deeply to describe wrapped types.
+func graphqlDepth(document *ast.Document) int {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	var selectionDepth func(selectionSet *ast.SelectionSet) int
+	selectionDepth = func(selectionSet *ast.SelectionSet) int {
+		if selectionSet == nil {
+			return 0
+		}
+
+		deepest := 0
+		for _, selection := range selectionSet.Selections {
+			depth := 0
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if strings.HasPrefix(selection.Name.Value, "__") {
+					continue
+				}
+				depth = 1 + selectionDepth(selection.SelectionSet)
+			case *ast.InlineFragment:
+				depth = selectionDepth(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				// validation has already rejected unknown and cyclic fragments
+				if fragment, ok := fragments[selection.Name.Value]; ok {
+					depth = selectionDepth(fragment.SelectionSet)
+				}
+			}
+			deepest = max(deepest, depth)
+		}
+
+		return deepest
+	}
+
+	deepest := 0
+	for _, definition := range document.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			deepest = max(deepest, selectionDepth(operation.SelectionSet))
+		}
+	}
+
+	return deepest
+}
+
+type graphqlLoadersKey struct{}
+
+func graphqlLoadersFromContext(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(graphqlLoadersKey{}).(*graphqlLoaders)
+}
+
+// graphqlError - passes authentication and authorization failures,
+// and invalid filters, on to the client, and hides anything else
+// behind a generic message.
+func graphqlError(err error) error {
+	if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrForbidden) {
+		return err
+	}
+
+	if errors.Is(err, commodity.ErrInvalidCategory) {
+		return err
+	}
+
+	log.Println("Error resolving graphql field", err)
+	return errInternalGraphql
+}
+
+// loadGraphql - resolves a field through one of the request's loaders.
+func loadGraphql[V any](p graphql.ResolveParams, loader *batchLoader[V], id string, includeDeleted bool) (interface{}, error) {
+	thunk := loader.load(p.Context, id, includeDeleted)
+	return func() (interface{}, error) {
+		result, err := thunk()
+		if err != nil {
+			return nil, graphqlError(err)
+		}
+		return result, nil
+	}, nil
+}
+
+func graphqlPagination(p graphql.ResolveParams) data.Pagination {
+	page, _ := p.Args["page"].(int)
+	perPage, _ := p.Args["perPage"].(int)
+	orderBy, _ := p.Args["orderBy"].(string)
+	return data.NewPagination(page, perPage, orderBy)
+}
+
+func graphqlIncludeDeleted(p graphql.ResolveParams) bool {
+	includeDeleted, _ := p.Args["includeDeleted"].(bool)
+	return includeDeleted
+}
+
+func (h *Handler) newGraphqlSchema() (graphql.Schema, error) {
+	includeDeletedArgument := &graphql.ArgumentConfig{
+		Type:         graphql.Boolean,
+		DefaultValue: false,
+	}
+	paginationArguments := graphql.FieldConfigArgument{
+		"page":           &graphql.ArgumentConfig{Type: graphql.Int},
+		"perPage":        &graphql.ArgumentConfig{Type: graphql.Int},
+		"orderBy":        &graphql.ArgumentConfig{Type: graphql.String},
+		"includeDeleted": includeDeletedArgument,
+	}
+	commoditiesArguments := graphql.FieldConfigArgument{
+		"category": &graphql.ArgumentConfig{Type: graphql.String},
+		"tag":      &graphql.ArgumentConfig{Type: graphql.String},
+	}
+	for name, argument := range paginationArguments {
+		commoditiesArguments[name] = argument
+	}
+	byIdArguments := graphql.FieldConfigArgument{
+		"id":             &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		"includeDeleted": includeDeletedArgument,
+	}
+	marketsArguments := graphql.FieldConfigArgument{
+		"includeDeleted": includeDeletedArgument,
+	}
+
+	var commodityType, solarSystemType, commodityMarketType *graphql.Object
+
+	commodityType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Commodity",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"unitMass":   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+				"unitVolume": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+				"category":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tags":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				"deletedAt":  &graphql.Field{Type: graphql.DateTime},
+				"markets": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(commodityMarketType))),
+					Args: marketsArguments,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(commodity.Commodity)
+						return loadGraphql(p, graphqlLoadersFromContext(p.Context).commodityMarkets, source.ID, graphqlIncludeDeleted(p))
+					},
+				},
+			}
+		}),
+	})
+
+	coordinatesType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Coordinates",
+		Fields: graphql.Fields{
+			"x": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"y": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"z": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	solarSystemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "SolarSystem",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"coordinates": &graphql.Field{Type: graphql.NewNonNull(coordinatesType)},
+				"starClass":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"region":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"sector":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"deletedAt":   &graphql.Field{Type: graphql.DateTime},
+				"markets": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(commodityMarketType))),
+					Args: marketsArguments,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(solarSystem.SolarSystem)
+						return loadGraphql(p, graphqlLoadersFromContext(p.Context).solarSystemMarkets, source.ID, graphqlIncludeDeleted(p))
+					},
+				},
+			}
+		}),
+	})
+
+	// a deleted market may belong to a commodity or solar system deleted
+	// along with it, so references from one include deleted rows
+	commodityMarketType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "CommodityMarket",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"basePrice":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+				"demandQuantity": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"stock":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"commodityName":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"deletedAt":      &graphql.Field{Type: graphql.DateTime},
+				"stationId": &graphql.Field{
+					Type: graphql.ID,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return optionalString(p.Source.(solarSystem.CommodityMarket).StationID), nil
+					},
+				},
+				"stationName": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return optionalString(p.Source.(solarSystem.CommodityMarket).StationName), nil
+					},
+				},
+				"commodity": &graphql.Field{
+					Type: commodityType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(solarSystem.CommodityMarket)
+						return loadGraphql(p, graphqlLoadersFromContext(p.Context).commodities, source.CommodityID, source.DeletedAt != nil)
+					},
+				},
+				"solarSystem": &graphql.Field{
+					Type: solarSystemType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(solarSystem.CommodityMarket)
+						return loadGraphql(p, graphqlLoadersFromContext(p.Context).solarSystems, source.SolarSystemID, source.DeletedAt != nil)
+					},
+				},
+			}
+		}),
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"commodities": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(commodityType))),
+				Args: commoditiesArguments,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					category, _ := p.Args["category"].(string)
+					tag, _ := p.Args["tag"].(string)
+					commodities, err := h.CommodityService.FindAllCommodity(p.Context, graphqlPagination(p), commodity.Filter{
+						IncludeDeleted: graphqlIncludeDeleted(p),
+						Category:       commodity.Category(category),
+						Tag:            tag,
+					})
+					if err != nil {
+						return nil, graphqlError(err)
+					}
+					return commodities, nil
+				},
+			},
+			"commodity": &graphql.Field{
+				Type: commodityType,
+				Args: byIdArguments,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["id"].(string)
+					if uuid.Validate(id) != nil {
+						return nil, nil
+					}
+					return loadGraphql(p, graphqlLoadersFromContext(p.Context).commodities, id, graphqlIncludeDeleted(p))
+				},
+			},
+			"solarSystems": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(solarSystemType))),
+				Args: paginationArguments,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					solarSystems, err := h.SolarSystemService.FindAllSolarSystems(p.Context, graphqlPagination(p), solarSystem.Filter{
+						IncludeDeleted: graphqlIncludeDeleted(p),
+					})
+					if err != nil {
+						return nil, graphqlError(err)
+					}
+					return solarSystems, nil
+				},
+			},
+			"solarSystem": &graphql.Field{
+				Type: solarSystemType,
+				Args: byIdArguments,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["id"].(string)
+					if uuid.Validate(id) != nil {
+						return nil, nil
+					}
+					return loadGraphql(p, graphqlLoadersFromContext(p.Context).solarSystems, id, graphqlIncludeDeleted(p))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
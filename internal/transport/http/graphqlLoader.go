@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+type loaderKey struct {
+	id             string
+	includeDeleted bool
+}
+
+// batchLoader - collects the ids requested while one level of a
+// GraphQL query resolves, and fetches them with a single call when
+// the first of their results is needed. A list of markets therefore
+// costs one query for all of their commodities rather than one each.
+//
+// graphql-go resolves a query on a single goroutine, calling the
+// returned thunks only once every field at the current depth has
+// been resolved, so the loader needs no locking.
+type batchLoader[V any] struct {
+	fetch     func(ctx context.Context, ids []string, includeDeleted bool) (map[string]V, error)
+	pending   map[bool][]string
+	requested map[loaderKey]bool
+	results   map[loaderKey]V
+	errs      map[loaderKey]error
+}
+
+func newBatchLoader[V any](fetch func(ctx context.Context, ids []string, includeDeleted bool) (map[string]V, error)) *batchLoader[V] {
+	return &batchLoader[V]{
+		fetch:     fetch,
+		pending:   map[bool][]string{},
+		requested: map[loaderKey]bool{},
+		results:   map[loaderKey]V{},
+		errs:      map[loaderKey]error{},
+	}
+}
+
+// load - queues the id and returns a thunk resolving to its value,
+// or to nil when no row matched it.
+func (l *batchLoader[V]) load(ctx context.Context, id string, includeDeleted bool) func() (interface{}, error) {
+	key := loaderKey{id: id, includeDeleted: includeDeleted}
+	if !l.requested[key] {
+		l.requested[key] = true
+		l.pending[includeDeleted] = append(l.pending[includeDeleted], id)
+	}
+
+	return func() (interface{}, error) {
+		if ids := l.pending[includeDeleted]; len(ids) > 0 {
+			delete(l.pending, includeDeleted)
+			l.dispatch(ctx, ids, includeDeleted)
+		}
+
+		if err, ok := l.errs[key]; ok {
+			return nil, err
+		}
+
+		if result, ok := l.results[key]; ok {
+			return result, nil
+		}
+
+		return nil, nil
+	}
+}
+
+func (l *batchLoader[V]) dispatch(ctx context.Context, ids []string, includeDeleted bool) {
+	results, err := l.fetch(ctx, ids, includeDeleted)
+	for _, id := range ids {
+		key := loaderKey{id: id, includeDeleted: includeDeleted}
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+
+		if result, ok := results[id]; ok {
+			l.results[key] = result
+		}
+	}
+}
+
+// graphqlLoaders - the loaders of a single GraphQL request. They are
+// never shared between requests, as what they cache was fetched with
+// the permissions of the request's principal.
+type graphqlLoaders struct {
+	commodities        *batchLoader[commodity.Commodity]
+	solarSystems       *batchLoader[solarSystem.SolarSystem]
+	solarSystemMarkets *batchLoader[[]solarSystem.CommodityMarket]
+	commodityMarkets   *batchLoader[[]solarSystem.CommodityMarket]
+}
+
+func (h *Handler) newGraphqlLoaders() *graphqlLoaders {
+	return &graphqlLoaders{
+		commodities: newBatchLoader(func(ctx context.Context, ids []string, includeDeleted bool) (map[string]commodity.Commodity, error) {
+			commodities, err := h.CommodityService.FindCommoditiesByIds(ctx, ids, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make(map[string]commodity.Commodity, len(commodities))
+			for _, commodity := range commodities {
+				results[commodity.ID] = commodity
+			}
+			return results, nil
+		}),
+		solarSystems: newBatchLoader(func(ctx context.Context, ids []string, includeDeleted bool) (map[string]solarSystem.SolarSystem, error) {
+			solarSystems, err := h.SolarSystemService.FindSolarSystemsByIds(ctx, ids, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+
+			results := make(map[string]solarSystem.SolarSystem, len(solarSystems))
+			for _, solarSystem := range solarSystems {
+				results[solarSystem.ID] = solarSystem
+			}
+			return results, nil
+		}),
+		solarSystemMarkets: newBatchLoader(func(ctx context.Context, ids []string, includeDeleted bool) (map[string][]solarSystem.CommodityMarket, error) {
+			markets, err := h.SolarSystemService.FindCommodityMarketsBySolarSystemIds(ctx, ids, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+
+			return groupCommodityMarkets(ids, markets, func(market solarSystem.CommodityMarket) string {
+				return market.SolarSystemID
+			}), nil
+		}),
+		commodityMarkets: newBatchLoader(func(ctx context.Context, ids []string, includeDeleted bool) (map[string][]solarSystem.CommodityMarket, error) {
+			markets, err := h.SolarSystemService.FindCommodityMarketsByCommodityIds(ctx, ids, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+
+			return groupCommodityMarkets(ids, markets, func(market solarSystem.CommodityMarket) string {
+				return market.CommodityID
+			}), nil
+		}),
+	}
+}
+
+// groupCommodityMarkets - gives every requested id a list, so that
+// a solar system or commodity without markets resolves to an empty
+// list rather than null.
+func groupCommodityMarkets(ids []string, markets []solarSystem.CommodityMarket, keyOf func(solarSystem.CommodityMarket) string) map[string][]solarSystem.CommodityMarket {
+	grouped := make(map[string][]solarSystem.CommodityMarket, len(ids))
+	for _, id := range ids {
+		grouped[id] = []solarSystem.CommodityMarket{}
+	}
+
+	for _, market := range markets {
+		key := keyOf(market)
+		grouped[key] = append(grouped[key], market)
+	}
+
+	return grouped
+}
@@ -7,52 +7,172 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/ratelimit"
+	"github.com/FairleyC/space-sim-service/internal/services/audit"
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
 	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
 	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/stream"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
 	"github.com/gorilla/mux"
+	"github.com/graphql-go/graphql"
 )
 
 type HttpExposedSolarSystemService interface {
-	FindAllSolarSystems(ctx context.Context, pagination data.Pagination) ([]solarSystem.SolarSystem, error)
-	FindSolarSystem(ctx context.Context, id string) (solarSystem.SolarSystemWithCommodityMarkets, error)
+	FindAllSolarSystems(ctx context.Context, pagination data.Pagination, filter solarSystem.Filter) ([]solarSystem.SolarSystem, error)
+	FindSolarSystem(ctx context.Context, id string, includeDeleted bool) (solarSystem.SolarSystemWithCommodityMarkets, error)
+	FindSolarSystemsByIds(ctx context.Context, ids []string, includeDeleted bool) ([]solarSystem.SolarSystem, error)
+	FindNearbySolarSystems(ctx context.Context, centre solarSystem.Coordinates, radius float64, pagination data.Pagination) ([]solarSystem.NearbySolarSystem, error)
+	FindNeighbors(ctx context.Context, id string, k int) ([]solarSystem.NearbySolarSystem, error)
+	FindCommodityMarketsBySolarSystemIds(ctx context.Context, solarSystemIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error)
+	FindCommodityMarketsByCommodityIds(ctx context.Context, commodityIds []string, includeDeleted bool) ([]solarSystem.CommodityMarket, error)
+	FindCommodityMarket(ctx context.Context, id string, includeDeleted bool) (solarSystem.CommodityMarket, error)
+	FindAllCommodityMarkets(ctx context.Context, pagination data.Pagination, filter solarSystem.MarketFilter) ([]solarSystem.CommodityMarket, error)
 	CreateSolarSystem(ctx context.Context, solarSystem solarSystem.SolarSystem) (solarSystem.SolarSystem, error)
 	RemoveSolarSystem(ctx context.Context, id string) error
-	CreateCommodityMarket(ctx context.Context, solarSystemId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error)
-	UpdateCommodityMarket(ctx context.Context, commodityMarketId string, commodityMarketUpdate solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error)
-	RemoveCommodityMarket(ctx context.Context, id string) error
+	RestoreSolarSystem(ctx context.Context, id string) (solarSystem.SolarSystemWithCommodityMarkets, error)
+	CreateCommodityMarket(ctx context.Context, solarSystemId string, stationId string, basePrice float64, demandQuantity int, commodityId string) (solarSystem.CommodityMarket, error)
+	UpdateCommodityMarket(ctx context.Context, solarSystemId string, commodityMarketId string, commodityMarketUpdate solarSystem.CommodityMarketUpdate) (solarSystem.CommodityMarket, error)
+	ReplaceCommodityMarkets(ctx context.Context, solarSystemId string, upserts []solarSystem.CommodityMarketUpsert) ([]solarSystem.CommodityMarketResult, error)
+	RemoveCommodityMarket(ctx context.Context, solarSystemId string, id string) error
+	RestoreCommodityMarket(ctx context.Context, solarSystemId string, id string) (solarSystem.CommodityMarket, error)
+	FindCelestialBodies(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.CelestialBody, error)
+	CreateCelestialBody(ctx context.Context, body solarSystem.CelestialBody) (solarSystem.CelestialBody, error)
+	RemoveCelestialBody(ctx context.Context, solarSystemId string, id string) error
+	RestoreCelestialBody(ctx context.Context, solarSystemId string, id string) (solarSystem.CelestialBody, error)
+	FindStations(ctx context.Context, solarSystemId string, includeDeleted bool) ([]solarSystem.Station, error)
+	FindStation(ctx context.Context, solarSystemId string, id string, includeDeleted bool) (solarSystem.StationWithCommodityMarkets, error)
+	CreateStation(ctx context.Context, station solarSystem.Station) (solarSystem.Station, error)
+	UpdateStation(ctx context.Context, solarSystemId string, id string, update solarSystem.StationUpdate) (solarSystem.Station, error)
+	RemoveStation(ctx context.Context, solarSystemId string, id string) error
+	RestoreStation(ctx context.Context, solarSystemId string, id string) (solarSystem.StationWithCommodityMarkets, error)
+	FindLegalityRules(ctx context.Context, solarSystemId string) ([]solarSystem.LegalityRule, error)
+	CreateLegalityRule(ctx context.Context, rule solarSystem.LegalityRule) (solarSystem.LegalityRule, error)
+	UpdateLegalityRule(ctx context.Context, solarSystemId string, id string, update solarSystem.LegalityRuleUpdate) (solarSystem.LegalityRule, error)
+	RemoveLegalityRule(ctx context.Context, solarSystemId string, id string) error
 }
 
 type HttpExposedCommodityService interface {
-	FindAllCommodity(ctx context.Context, pagination data.Pagination) ([]commodity.Commodity, error)
-	FindCommodity(ctx context.Context, id string) (commodity.Commodity, error)
+	FindAllCommodity(ctx context.Context, pagination data.Pagination, filter commodity.Filter) ([]commodity.Commodity, error)
+	FindCommodity(ctx context.Context, id string, includeDeleted bool) (commodity.Commodity, error)
+	FindCommoditiesByIds(ctx context.Context, ids []string, includeDeleted bool) ([]commodity.Commodity, error)
 	CreateCommodity(ctx context.Context, commodity commodity.Commodity) (commodity.Commodity, error)
-	RemoveCommodity(ctx context.Context, id string) error
+	RemoveCommodity(ctx context.Context, id string, mode commodity.RemovalMode) error
+	RestoreCommodity(ctx context.Context, id string) (commodity.Commodity, error)
+}
+
+type HttpExposedProductionService interface {
+	FindAllRecipes(ctx context.Context, pagination data.Pagination, filter production.RecipeFilter) ([]production.Recipe, error)
+	FindRecipe(ctx context.Context, id string) (production.Recipe, error)
+	CreateRecipe(ctx context.Context, recipe production.Recipe) (production.Recipe, error)
+	UpdateRecipe(ctx context.Context, recipe production.Recipe) (production.Recipe, error)
+	RemoveRecipe(ctx context.Context, id string) error
+	FindBillOfMaterials(ctx context.Context, commodityId string, quantity float64) (production.BillOfMaterials, error)
+	FindFacilities(ctx context.Context, solarSystemId string) ([]production.Facility, error)
+	FindFacility(ctx context.Context, solarSystemId string, id string) (production.Facility, error)
+	CreateFacility(ctx context.Context, facility production.Facility) (production.Facility, error)
+	UpdateFacility(ctx context.Context, solarSystemId string, id string, update production.FacilityUpdate) (production.Facility, error)
+	RemoveFacility(ctx context.Context, solarSystemId string, id string) error
+}
+
+type HttpExposedShipService interface {
+	FindAllShips(ctx context.Context, pagination data.Pagination) ([]ship.Ship, error)
+	FindShip(ctx context.Context, id string) (ship.Ship, error)
+	CreateShip(ctx context.Context, ship ship.Ship) (ship.Ship, error)
+	RemoveShip(ctx context.Context, id string) error
+	Travel(ctx context.Context, id string, destinationId string) (ship.Ship, error)
+	Trade(ctx context.Context, id string, commodityMarketId string, quantity int) (ship.Ship, error)
+}
+
+type HttpExposedAuthService interface {
+	AuthenticateApiKey(ctx context.Context, key string) (auth.Principal, error)
+	AuthenticateBearerToken(ctx context.Context, token string) (auth.Principal, error)
+	FindAllApiKeys(ctx context.Context, pagination data.Pagination) ([]auth.ApiKey, error)
+	CreateApiKey(ctx context.Context, name string, role auth.Role) (auth.CreatedApiKey, error)
+	RemoveApiKey(ctx context.Context, id string) error
+}
+
+type HttpExposedAuditService interface {
+	FindEntries(ctx context.Context, filter audit.Filter, pagination data.Pagination) ([]audit.Entry, error)
+}
+
+type HttpExposedWebhookService interface {
+	FindAllWebhooks(ctx context.Context, pagination data.Pagination) ([]webhook.Webhook, error)
+	CreateWebhook(ctx context.Context, webhookUrl string, eventTypes []string) (webhook.CreatedWebhook, error)
+	RemoveWebhook(ctx context.Context, id string) error
+	FindDeliveries(ctx context.Context, webhookId string, filter webhook.DeliveryFilter, pagination data.Pagination) ([]webhook.Delivery, error)
+	RetryDelivery(ctx context.Context, webhookId string, deliveryId string) (webhook.Delivery, error)
+}
+
+type HttpExposedUniverseService interface {
+	Import(ctx context.Context, source universe.Source, dryRun bool) (universe.Report, error)
+	Export(ctx context.Context, write func(universe.Record) error) error
+	Generate(ctx context.Context, config universe.GenerateConfig, dryRun bool) (universe.Report, error)
+}
+
+type HttpExposedStreamService interface {
+	Subscribe(ctx context.Context, topics []string, lastEventId *int64) (*stream.Subscription, error)
+	Unsubscribe(subscription *stream.Subscription)
+	Close()
 }
 
 type Handler struct {
 	Router             *mux.Router
 	CommodityService   HttpExposedCommodityService
 	SolarSystemService HttpExposedSolarSystemService
+	AuthService        HttpExposedAuthService
+	AuditService       HttpExposedAuditService
+	WebhookService     HttpExposedWebhookService
+	StreamService      HttpExposedStreamService
+	UniverseService    HttpExposedUniverseService
+	ProductionService  HttpExposedProductionService
+	ShipService        HttpExposedShipService
+	RateLimiter        *ratelimit.Limiter
+	CacheMaxAge        time.Duration
+	GraphqlSchema      graphql.Schema
 	Server             *http.Server
 }
 
-func NewHandler(commodityService HttpExposedCommodityService, solarSystemService HttpExposedSolarSystemService) *Handler {
+func NewHandler(commodityService HttpExposedCommodityService, solarSystemService HttpExposedSolarSystemService, authService HttpExposedAuthService, auditService HttpExposedAuditService, webhookService HttpExposedWebhookService, streamService HttpExposedStreamService, universeService HttpExposedUniverseService, productionService HttpExposedProductionService, shipService HttpExposedShipService, rateLimiter *ratelimit.Limiter, cacheMaxAge time.Duration) *Handler {
 	h := &Handler{
 		CommodityService:   commodityService,
 		SolarSystemService: solarSystemService,
+		AuthService:        authService,
+		AuditService:       auditService,
+		WebhookService:     webhookService,
+		StreamService:      streamService,
+		UniverseService:    universeService,
+		ProductionService:  productionService,
+		ShipService:        shipService,
+		RateLimiter:        rateLimiter,
+		CacheMaxAge:        cacheMaxAge,
 	}
 
+	graphqlSchema, err := h.newGraphqlSchema()
+	if err != nil {
+		// the schema is fixed at compile time, so this is a programming error
+		panic(fmt.Sprintf("invalid graphql schema: %v", err))
+	}
+	h.GraphqlSchema = graphqlSchema
+
 	h.Router = mux.NewRouter()
 
+	h.Router.Use(h.RateLimitMiddleware, h.VersionMiddleware, h.AuthMiddleware, h.PrincipalRateLimitMiddleware)
 	h.mapRoutes()
 
 	h.Server = &http.Server{
 		Addr:    ":8080",
 		Handler: h.Router,
 	}
+	// open streams never go idle, so they are ended for Shutdown to complete
+	h.Server.RegisterOnShutdown(h.StreamService.Close)
 
 	return h
 }
@@ -60,22 +180,104 @@ func NewHandler(commodityService HttpExposedCommodityService, solarSystemService
 var (
 	API = "/api"
 	V1  = "/v1"
+	V2  = "/v2"
 )
 
 func (h *Handler) mapRoutes() {
-	h.Router.HandleFunc(withPath(V1, "/commodities"), h.GetCommodities).Methods("GET")
-	h.Router.HandleFunc(withPath(V1, "/commodities/{id}"), h.GetCommodity).Methods("GET")
-	h.Router.HandleFunc(withPath(V1, "/commodities"), h.PostCommodity).Methods("POST")
-	h.Router.HandleFunc(withPath(V1, "/commodities/{id}"), h.DeleteCommodity).Methods("DELETE")
+	// v2 differs from v1 only in how responses are represented, which
+	// the handlers pick from the request's version
+	for _, version := range []string{V1, V2} {
+		h.mapVersionedRoutes(version)
+	}
+
+	h.Router.HandleFunc("/graphql", h.PostGraphql).Methods("POST")
 
-	h.Router.HandleFunc(withPath(V1, "/solarSystems"), h.GetSolarSystems).Methods("GET")
-	h.Router.HandleFunc(withPath(V1, "/solarSystems/{id}"), h.GetSolarSystem).Methods("GET")
-	h.Router.HandleFunc(withPath(V1, "/solarSystems"), h.PostSolarSystem).Methods("POST")
-	h.Router.HandleFunc(withPath(V1, "/solarSystems/{id}"), h.DeleteSolarSystem).Methods("DELETE")
+	h.Router.HandleFunc(API+"/openapi.json", h.GetOpenApiSpec).Methods("GET")
+	h.Router.HandleFunc(API+"/docs", h.GetApiDocs).Methods("GET")
 
-	h.Router.HandleFunc(withPath(V1, "/solarSystems/{solarSystemId}/commodityMarkets"), h.PostCommodityMarket).Methods("POST")
-	h.Router.HandleFunc(withPath(V1, "/solarSystems/{solarSystemId}/commodityMarkets/{commodityMarketId}"), h.PutCommodityMarket).Methods("PUT")
-	h.Router.HandleFunc(withPath(V1, "/solarSystems/{solarSystemId}/commodityMarkets/{commodityMarketId}"), h.DeleteCommodityMarket).Methods("DELETE")
+	h.Router.HandleFunc("/debug/vars", h.GetMetrics).Methods("GET")
+}
+
+func (h *Handler) mapVersionedRoutes(version string) {
+	h.Router.HandleFunc(withPath(version, "/commodities"), h.GetCommodities).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/commodities/{id}"), h.GetCommodity).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/commodities"), h.PostCommodity).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/commodities/{id}"), h.DeleteCommodity).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/commodities/{id}/restore"), h.RestoreCommodity).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/commodities/{id}/markets"), h.GetCommodityMarketsByCommodity).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/commodities/{id}/billOfMaterials"), h.GetBillOfMaterials).Methods("GET")
+
+	h.Router.HandleFunc(withPath(version, "/solarSystems"), h.GetSolarSystems).Methods("GET")
+	// registered ahead of /solarSystems/{id}, which would match it too
+	h.Router.HandleFunc(withPath(version, "/solarSystems/nearby"), h.GetNearbySolarSystems).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{id}"), h.GetSolarSystem).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{id}/neighbors"), h.GetSolarSystemNeighbors).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems"), h.PostSolarSystem).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{id}"), h.DeleteSolarSystem).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{id}/restore"), h.RestoreSolarSystem).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/celestialBodies"), h.GetCelestialBodies).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/celestialBodies"), h.PostCelestialBody).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/celestialBodies/{celestialBodyId}"), h.DeleteCelestialBody).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/celestialBodies/{celestialBodyId}/restore"), h.RestoreCelestialBody).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations"), h.GetStations).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations"), h.PostStation).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations/{stationId}"), h.GetStation).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations/{stationId}"), h.PutStation).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations/{stationId}"), h.DeleteStation).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/stations/{stationId}/restore"), h.RestoreStation).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/legalityRules"), h.GetLegalityRules).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/legalityRules"), h.PostLegalityRule).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/legalityRules/{legalityRuleId}"), h.PutLegalityRule).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/legalityRules/{legalityRuleId}"), h.DeleteLegalityRule).Methods("DELETE")
+
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/facilities"), h.GetFacilities).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/facilities"), h.PostFacility).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/facilities/{facilityId}"), h.GetFacility).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/facilities/{facilityId}"), h.PutFacility).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/facilities/{facilityId}"), h.DeleteFacility).Methods("DELETE")
+
+	h.Router.HandleFunc(withPath(version, "/recipes"), h.GetRecipes).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/recipes"), h.PostRecipe).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/recipes/{id}"), h.GetRecipe).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/recipes/{id}"), h.PutRecipe).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/recipes/{id}"), h.DeleteRecipe).Methods("DELETE")
+
+	h.Router.HandleFunc(withPath(version, "/ships"), h.GetShips).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/ships"), h.PostShip).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/ships/{id}"), h.GetShip).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/ships/{id}"), h.DeleteShip).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/ships/{id}/travel"), h.PostShipTravel).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/ships/{id}/trades"), h.PostShipTrade).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/commodityMarkets"), h.GetCommodityMarkets).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/commodityMarkets"), h.PostCommodityMarket).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/commodityMarkets"), h.PutCommodityMarkets).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/commodityMarkets/{commodityMarketId}"), h.PutCommodityMarket).Methods("PUT")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/commodityMarkets/{commodityMarketId}"), h.DeleteCommodityMarket).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/solarSystems/{solarSystemId}/commodityMarkets/{commodityMarketId}/restore"), h.RestoreCommodityMarket).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/auth/keys"), h.GetApiKeys).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/auth/keys"), h.PostApiKey).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/auth/keys/{id}"), h.DeleteApiKey).Methods("DELETE")
+
+	h.Router.HandleFunc(withPath(version, "/audit"), h.GetAuditEntries).Methods("GET")
+
+	h.Router.HandleFunc(withPath(version, "/webhooks"), h.GetWebhooks).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/webhooks"), h.PostWebhook).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/webhooks/{id}"), h.DeleteWebhook).Methods("DELETE")
+	h.Router.HandleFunc(withPath(version, "/webhooks/{id}/deliveries"), h.GetWebhookDeliveries).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/webhooks/{id}/deliveries/{deliveryId}/retry"), h.RetryWebhookDelivery).Methods("POST")
+
+	h.Router.HandleFunc(withPath(version, "/stream"), h.GetStream).Methods("GET")
+	h.Router.HandleFunc(withPath(version, "/stream/ws"), h.GetStreamWebSocket).Methods("GET")
+
+	h.Router.HandleFunc(withPath(version, "/import"), h.PostImport).Methods("POST")
+	h.Router.HandleFunc(withPath(version, "/export"), h.GetExport).Methods("GET")
+
+	h.Router.HandleFunc(withPath(version, "/admin/generate"), h.PostGenerate).Methods("POST")
 }
 
 func (h *Handler) Serve() error {
@@ -102,3 +304,10 @@ func (h *Handler) Serve() error {
 func withPath(version string, path string) string {
 	return fmt.Sprintf("%s%s%s", API, version, path)
 }
+
+// getIncludeDeleted - reads the includeDeleted query parameter,
+// which asks for soft deleted rows to be returned as well.
+func getIncludeDeleted(r *http.Request) bool {
+	includeDeleted, _ := strconv.ParseBool(r.URL.Query().Get("includeDeleted"))
+	return includeDeleted
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetLegalityRules(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetLegalityRules")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rules, err := h.SolarSystemService.FindLegalityRules(r.Context(), solarSystemId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting legality rules", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(LegalityRuleListV1{
+		LegalityRules: mapDtos(rules, newLegalityRuleV1),
+	}); err != nil {
+		log.Println("Error encoding legality rules", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostLegalityRule(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostLegalityRule")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request LegalityRuleRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding legality rule", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newRule, err := h.SolarSystemService.CreateLegalityRule(r.Context(), solarSystem.LegalityRule{
+		SolarSystemID: solarSystemId,
+		CommodityID:   request.CommodityID,
+		Category:      commodity.Category(request.Category),
+		Status:        solarSystem.LegalityStatus(request.Status),
+		TaxRate:       request.TaxRate,
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidLegalityRule) {
+			log.Println("Invalid legality rule", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrLegalityReferenceNotFound) {
+			log.Println("Solar system or commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrLegalityRuleConflict) {
+			log.Println("Legality rule already exists", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error creating legality rule", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newLegalityRuleV1(newRule)); err != nil {
+		log.Println("Error encoding legality rule", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PutLegalityRule(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PutLegalityRule")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	legalityRuleId := vars["legalityRuleId"]
+
+	if solarSystemId == "" || legalityRuleId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request LegalityRuleRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding legality rule", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	updatedRule, err := h.SolarSystemService.UpdateLegalityRule(r.Context(), solarSystemId, legalityRuleId, solarSystem.LegalityRuleUpdate{
+		Status:  solarSystem.LegalityStatus(request.Status),
+		TaxRate: request.TaxRate,
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidLegalityRule) {
+			log.Println("Invalid legality rule", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrLegalityRuleNotFound) {
+			log.Println("Legality rule not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error updating legality rule", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newLegalityRuleV1(updatedRule)); err != nil {
+		log.Println("Error encoding legality rule", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) DeleteLegalityRule(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteLegalityRule")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	legalityRuleId := vars["legalityRuleId"]
+
+	if solarSystemId == "" || legalityRuleId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.SolarSystemService.RemoveLegalityRule(r.Context(), solarSystemId, legalityRuleId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrLegalityRuleNotFound) {
+			log.Println("Legality rule not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting legality rule", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FairleyC/space-sim-service/internal/services/auth"
+)
+
+const (
+	ApiKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+)
+
+// AuthMiddleware - authenticates every request outside of
+// publicPaths using either an X-API-Key header or an
+// Authorization bearer credential, and attaches the resulting
+// principal to the request context.
+func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := h.authenticate(r)
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrInvalidCredentials) || errors.Is(err, auth.ErrJwtNotConfigured) {
+				log.Println("Unauthenticated request", err)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="space-sim"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			log.Println("Error authenticating request", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// RateLimitMiddleware - limits each client IP address per route
+// group. It runs ahead of authentication and ignores any credential
+// presented, so a flood of requests, made up credentials included,
+// is turned away by address before a single key is looked up in
+// the database.
+func (h *Handler) RateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.takeRateLimit(w, r, clientIpKey(r)) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PrincipalRateLimitMiddleware - runs after AuthMiddleware and limits
+// each verified principal per route group as well, wherever its
+// requests come from. Public paths carry no principal and are only
+// limited by address.
+func (h *Handler) PrincipalRateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if ok && !h.takeRateLimit(w, r, "principal:"+principal.ID) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// takeRateLimit - takes a token from the client's bucket for the
+// request's route group, writing a 429 and reporting false when the
+// bucket is empty.
+func (h *Handler) takeRateLimit(w http.ResponseWriter, r *http.Request, clientKey string) bool {
+	result, err := h.RateLimiter.Allow(r.Context(), clientKey, r.URL.Path)
+	if err != nil {
+		// a failing limiter backend should not take the API down with it
+		log.Println("Error checking rate limit", err)
+		return true
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds()))))
+
+	if !result.Allowed {
+		log.Println("Rate limit exceeded", r.URL.Path)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return false
+	}
+
+	return true
+}
+
+func clientIpKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+func (h *Handler) authenticate(r *http.Request) (auth.Principal, error) {
+	if apiKey := r.Header.Get(ApiKeyHeader); apiKey != "" {
+		return h.AuthService.AuthenticateApiKey(r.Context(), apiKey)
+	}
+
+	authorization := r.Header.Get(AuthorizationHeader)
+	scheme, credential, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || credential == "" {
+		return auth.Principal{}, auth.ErrUnauthenticated
+	}
+
+	if auth.IsApiKey(credential) {
+		return h.AuthService.AuthenticateApiKey(r.Context(), credential)
+	}
+
+	return h.AuthService.AuthenticateBearerToken(r.Context(), credential)
+}
+
+type ErrorResponse struct {
+	Error             string `json:"error"`
+	MissingPermission string `json:"missingPermission,omitempty"`
+}
+
+// writeAuthError - writes a 401 or 403 response when the error
+// is an authentication or authorization failure, reporting
+// whether a response was written.
+func writeAuthError(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		log.Println("Unauthenticated request", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return true
+	}
+
+	var forbiddenError *auth.ForbiddenError
+	if errors.As(err, &forbiddenError) {
+		log.Println("Forbidden request", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		if err := json.NewEncoder(w).Encode(ErrorResponse{
+			Error:             forbiddenError.Error(),
+			MissingPermission: forbiddenError.Permission.String(),
+		}); err != nil {
+			log.Println("Error encoding forbidden response", err)
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	_ "embed"
+	"log"
+	"net/http"
+)
+
+// openApiSpec - the OpenAPI document describing every route in
+// mapRoutes. TestOpenApiSpecCoversRoutes keeps the two in step.
+//
+//go:embed openapi.json
+var openApiSpec []byte
+
+// apiDocsPage - loads Swagger UI from a CDN rather than vendoring
+// its assets, pointing it at the spec served alongside.
+const apiDocsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Space Sim Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/api/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// publicPaths - routes served without authentication, so the docs
+// can be read before a key has been issued.
+var publicPaths = map[string]bool{
+	API + "/openapi.json": true,
+	API + "/docs":         true,
+}
+
+func (h *Handler) GetOpenApiSpec(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetOpenApiSpec")
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(openApiSpec); err != nil {
+		log.Println("Error writing openapi spec", err)
+	}
+}
+
+func (h *Handler) GetApiDocs(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetApiDocs")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(apiDocsPage)); err != nil {
+		log.Println("Error writing api docs", err)
+	}
+}
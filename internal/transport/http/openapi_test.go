@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+type openApiDocument struct {
+	OpenApi string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func loadOpenApiSpec(t *testing.T) openApiDocument {
+	t.Helper()
+
+	var document openApiDocument
+	if err := json.Unmarshal(openApiSpec, &document); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	return document
+}
+
+// registeredRoutes - every "METHOD path" pair in mapRoutes.
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+
+	h := &Handler{Router: mux.NewRouter()}
+	h.mapRoutes()
+
+	var routes []string
+	err := h.Router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			t.Errorf("route %s is registered without a method", path)
+			return nil
+		}
+
+		for _, method := range methods {
+			routes = append(routes, method+" "+path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error walking routes: %v", err)
+	}
+
+	return routes
+}
+
+func TestOpenApiSpecCoversRoutes(t *testing.T) {
+	document := loadOpenApiSpec(t)
+
+	if !strings.HasPrefix(document.OpenApi, "3.1") {
+		t.Errorf("expected an OpenAPI 3.1 document, got version %q", document.OpenApi)
+	}
+
+	registered := map[string]bool{}
+	for _, route := range registeredRoutes(t) {
+		registered[route] = true
+
+		method, path, _ := strings.Cut(route, " ")
+		if _, ok := document.Paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("route %s has no entry in openapi.json", route)
+		}
+	}
+
+	var documented []string
+	for path, operations := range document.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(documented)
+
+	for _, route := range documented {
+		if !registered[route] {
+			t.Errorf("openapi.json documents %s, which is not registered", route)
+		}
+	}
+}
+
+func TestDocsAreServedWithoutCredentials(t *testing.T) {
+	h := &Handler{Router: mux.NewRouter()}
+	h.mapRoutes()
+	handler := h.AuthMiddleware(h.Router)
+
+	for _, path := range []string{API + "/openapi.json", API + "/docs"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("GET %s returned %d, expected %d", path, recorder.Code, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/production"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetRecipes(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetRecipes")
+
+	pagination := data.GetPagination(r)
+
+	filter := production.RecipeFilter{
+		Produces: r.URL.Query().Get("produces"),
+		Consumes: r.URL.Query().Get("consumes"),
+	}
+
+	recipes, err := h.ProductionService.FindAllRecipes(r.Context(), pagination, filter)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error getting recipes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(RecipeListV1{
+		Recipes:    mapDtos(recipes, newRecipeV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding recipes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetRecipe(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetRecipe")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := h.ProductionService.FindRecipe(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, production.ErrRecipeNotFound) {
+			log.Println("Recipe not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting recipe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newRecipeV1(recipe)); err != nil {
+		log.Println("Error encoding recipe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostRecipe(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostRecipe")
+	var request RecipeRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding recipe", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newRecipe, err := h.ProductionService.CreateRecipe(r.Context(), request.toRecipe(""))
+	if err != nil {
+		h.writeRecipeError(w, err, "Error creating recipe")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newRecipeV1(newRecipe)); err != nil {
+		log.Println("Error encoding recipe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PutRecipe(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PutRecipe")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request RecipeRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding recipe", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	updatedRecipe, err := h.ProductionService.UpdateRecipe(r.Context(), request.toRecipe(id))
+	if err != nil {
+		h.writeRecipeError(w, err, "Error updating recipe")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newRecipeV1(updatedRecipe)); err != nil {
+		log.Println("Error encoding recipe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeRecipeError - maps the errors of creating and updating a recipe.
+func (h *Handler) writeRecipeError(w http.ResponseWriter, err error, message string) {
+	if writeAuthError(w, err) {
+		return
+	}
+	if errors.Is(err, production.ErrInvalidRecipe) || errors.Is(err, production.ErrRecipeReferenceNotFound) {
+		log.Println("Invalid recipe", err)
+		writeInvalidRequest(w, err)
+		return
+	}
+	if errors.Is(err, production.ErrRecipeNotFound) {
+		log.Println("Recipe not found", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, production.ErrRecipeConflict) {
+		log.Println("Recipe already exists", err)
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	log.Println(message, err)
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func (h *Handler) DeleteRecipe(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteRecipe")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.ProductionService.RemoveRecipe(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, production.ErrRecipeNotFound) {
+			log.Println("Recipe not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, production.ErrRecipeInUse) {
+			log.Println("Recipe is in use", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding recipe error", err)
+			}
+			return
+		}
+		log.Println("Error deleting recipe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBillOfMaterials - what it takes to make ?quantity, by default 1,
+// of the commodity.
+func (h *Handler) GetBillOfMaterials(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetBillOfMaterials")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	quantity := 1.0
+	if value := r.URL.Query().Get("quantity"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			log.Println("Quantity was not a number", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		quantity = parsed
+	}
+
+	bill, err := h.ProductionService.FindBillOfMaterials(r.Context(), id, quantity)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, production.ErrInvalidQuantity) {
+			log.Println("Invalid quantity", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, commodity.ErrCommodityNotFound) {
+			log.Println("Commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting bill of materials", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newBillOfMaterialsV1(bill)); err != nil {
+		log.Println("Error encoding bill of materials", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetFacilities(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetFacilities")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	facilities, err := h.ProductionService.FindFacilities(r.Context(), solarSystemId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting facilities", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(FacilityListV1{
+		Facilities: mapDtos(facilities, newFacilityV1),
+	}); err != nil {
+		log.Println("Error encoding facilities", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetFacility(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetFacility")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	facilityId := vars["facilityId"]
+
+	if solarSystemId == "" || facilityId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	facility, err := h.ProductionService.FindFacility(r.Context(), solarSystemId, facilityId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, production.ErrFacilityNotFound) {
+			log.Println("Facility not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting facility", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newFacilityV1(facility)); err != nil {
+		log.Println("Error encoding facility", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostFacility(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostFacility")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request FacilityRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding facility", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newFacility, err := h.ProductionService.CreateFacility(r.Context(), production.Facility{
+		SolarSystemID: solarSystemId,
+		StationID:     request.StationID,
+		RecipeID:      request.RecipeID,
+		Name:          request.Name,
+		Enabled:       request.enabled(),
+	})
+	if err != nil {
+		h.writeFacilityError(w, err, "Error creating facility")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newFacilityV1(newFacility)); err != nil {
+		log.Println("Error encoding facility", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PutFacility(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PutFacility")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	facilityId := vars["facilityId"]
+
+	if solarSystemId == "" || facilityId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request FacilityRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding facility", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	updatedFacility, err := h.ProductionService.UpdateFacility(r.Context(), solarSystemId, facilityId, production.FacilityUpdate{
+		Name:     request.Name,
+		RecipeID: request.RecipeID,
+		Enabled:  request.enabled(),
+	})
+	if err != nil {
+		h.writeFacilityError(w, err, "Error updating facility")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newFacilityV1(updatedFacility)); err != nil {
+		log.Println("Error encoding facility", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeFacilityError - maps the errors of creating and updating a facility.
+func (h *Handler) writeFacilityError(w http.ResponseWriter, err error, message string) {
+	if writeAuthError(w, err) {
+		return
+	}
+	if errors.Is(err, production.ErrInvalidFacility) {
+		log.Println("Invalid facility", err)
+		writeInvalidRequest(w, err)
+		return
+	}
+	if errors.Is(err, production.ErrFacilityNotFound) || errors.Is(err, production.ErrFacilityReferenceNotFound) {
+		log.Println("Facility, solar system, station or recipe not found", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, production.ErrFacilityConflict) {
+		log.Println("Facility already exists", err)
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	log.Println(message, err)
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func (h *Handler) DeleteFacility(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteFacility")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	facilityId := vars["facilityId"]
+
+	if solarSystemId == "" || facilityId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.ProductionService.RemoveFacility(r.Context(), solarSystemId, facilityId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, production.ErrFacilityNotFound) {
+			log.Println("Facility not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting facility", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
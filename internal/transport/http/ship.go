@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/ship"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetShips(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetShips")
+
+	pagination := data.GetPagination(r)
+
+	ships, err := h.ShipService.FindAllShips(r.Context(), pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error getting ships", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(ShipListV1{
+		Ships:      mapDtos(ships, newShipV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding ships", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetShip(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetShip")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	foundShip, err := h.ShipService.FindShip(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, ship.ErrShipNotFound) {
+			log.Println("Ship not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newShipV1(foundShip)); err != nil {
+		log.Println("Error encoding ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostShip(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostShip")
+	var request CreateShipRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding ship", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newShip, err := h.ShipService.CreateShip(r.Context(), ship.Ship{
+		Name:          request.Name,
+		SolarSystemID: request.SolarSystemID,
+		Speed:         request.Speed,
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, ship.ErrInvalidShip) {
+			log.Println("Invalid ship", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, ship.ErrShipReferenceNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ship.ErrShipConflict) {
+			log.Println("Ship already exists", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error creating ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newShipV1(newShip)); err != nil {
+		log.Println("Error encoding ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) DeleteShip(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteShip")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.ShipService.RemoveShip(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, ship.ErrShipNotFound) {
+			log.Println("Ship not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostShipTravel - sends a docked ship to another solar system. The
+// response shows it in transit, with the time it arrives.
+func (h *Handler) PostShipTravel(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostShipTravel")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request ShipTravelRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding travel", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	departedShip, err := h.ShipService.Travel(r.Context(), id, request.DestinationID)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, ship.ErrInvalidTravel) {
+			log.Println("Invalid travel", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, ship.ErrShipNotFound) || errors.Is(err, ship.ErrShipReferenceNotFound) {
+			log.Println("Ship or destination not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ship.ErrShipInTransit) {
+			log.Println("Ship is in transit", err)
+			writeShipConflict(w, err)
+			return
+		}
+		log.Println("Error sending ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newShipV1(departedShip)); err != nil {
+		log.Println("Error encoding ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// PostShipTrade - buys from or sells to a market of the solar system
+// the ship is docked in, returning the ship with its new cargo.
+func (h *Handler) PostShipTrade(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostShipTrade")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request ShipTradeRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding trade", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	tradedShip, err := h.ShipService.Trade(r.Context(), id, request.CommodityMarketID, request.Quantity)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, ship.ErrInvalidTrade) {
+			log.Println("Invalid trade", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, ship.ErrShipNotFound) || errors.Is(err, solarSystem.ErrCommodityMarketNotFound) {
+			log.Println("Ship or market not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ship.ErrShipInTransit) || errors.Is(err, ship.ErrMarketNotInSolarSystem) ||
+			errors.Is(err, ship.ErrInsufficientStock) || errors.Is(err, ship.ErrInsufficientCargo) {
+			log.Println("Trade refused", err)
+			writeShipConflict(w, err)
+			return
+		}
+		log.Println("Error trading", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newShipV1(tradedShip)); err != nil {
+		log.Println("Error encoding ship", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeShipConflict - a 409 that tells the caller why the ship's
+// current state refuses the request.
+func writeShipConflict(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+		log.Println("Error encoding ship conflict error", err)
+	}
+}
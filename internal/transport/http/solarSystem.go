@@ -11,26 +11,29 @@ import (
 	"github.com/gorilla/mux"
 )
 
-type SolarSystemResponse struct {
-	SolarSystems []solarSystem.SolarSystem `json:"solarSystems"`
-	Pagination   data.Pagination           `json:"pagination"`
-}
-
 func (h *Handler) GetSolarSystems(w http.ResponseWriter, r *http.Request) {
 	log.Println("REQUEST: GetSolarSystems")
 
 	pagination := data.GetPagination(r)
 
-	solarSystems, err := h.SolarSystemService.FindAllSolarSystems(r.Context(), pagination)
+	filter := solarSystem.Filter{
+		IncludeDeleted: getIncludeDeleted(r),
+	}
+
+	solarSystems, err := h.SolarSystemService.FindAllSolarSystems(r.Context(), pagination, filter)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
 		log.Println("Error getting solar systems", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(SolarSystemResponse{
-		SolarSystems: solarSystems,
-		Pagination:   pagination,
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(SolarSystemListV1{
+		SolarSystems: mapDtos(solarSystems, newSolarSystemV1),
+		Pagination:   newPaginationV1(pagination),
 	}); err != nil {
 		log.Println("Error encoding solar systems", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -49,8 +52,11 @@ func (h *Handler) GetSolarSystem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foundSolarSystem, err := h.SolarSystemService.FindSolarSystem(r.Context(), id)
+	foundSolarSystem, err := h.SolarSystemService.FindSolarSystem(r.Context(), id, getIncludeDeleted(r))
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
 		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
 			log.Println("Solar system not found", err)
 			w.WriteHeader(http.StatusNotFound)
@@ -61,41 +67,53 @@ func (h *Handler) GetSolarSystem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(foundSolarSystem); err != nil {
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(newSolarSystemDetailResponse(r, foundSolarSystem)); err != nil {
 		log.Println("Error encoding solar system", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-type SolarSystemJson struct {
-	ID   string
-	Name string
-}
-
 func (h *Handler) PostSolarSystem(w http.ResponseWriter, r *http.Request) {
 	log.Println("REQUEST: PostSolarSystem")
-	var solarSystemJson SolarSystemJson
-	if err := json.NewDecoder(r.Body).Decode(&solarSystemJson); err != nil {
+	var request CreateSolarSystemRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
 		log.Println("Error decoding solar system", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	solarSystem := solarSystem.SolarSystem{
-		ID:   solarSystemJson.ID,
-		Name: solarSystemJson.Name,
+	newSolarSystem := solarSystem.SolarSystem{
+		Name:        request.Name,
+		Coordinates: request.Coordinates.toCoordinates(),
+		StarClass:   solarSystem.StarClass(request.StarClass),
+		Region:      request.Region,
+		Sector:      request.Sector,
 	}
 
-	solarSystem, err := h.SolarSystemService.CreateSolarSystem(r.Context(), solarSystem)
+	newSolarSystem, err := h.SolarSystemService.CreateSolarSystem(r.Context(), newSolarSystem)
 
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidStarClass) || errors.Is(err, solarSystem.ErrInvalidCoordinates) {
+			log.Println("Invalid solar system", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemConflict) {
+			log.Println("Solar system name is taken", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
 		log.Println("Error creating solar system", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(solarSystem); err != nil {
+	if err := json.NewEncoder(w).Encode(newSolarSystemV1(newSolarSystem)); err != nil {
 		log.Println("Error encoding solar system", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -114,6 +132,14 @@ func (h *Handler) DeleteSolarSystem(w http.ResponseWriter, r *http.Request) {
 
 	err := h.SolarSystemService.RemoveSolarSystem(r.Context(), id)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		log.Println("Error deleting solar system", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -122,12 +148,6 @@ func (h *Handler) DeleteSolarSystem(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-type CommodityMarketJson struct {
-	BasePrice      float64
-	DemandQuantity int
-	CommodityID    string
-}
-
 func (h *Handler) PostCommodityMarket(w http.ResponseWriter, r *http.Request) {
 	log.Println("REQUEST: PostCommodityMarket")
 	vars := mux.Vars(r)
@@ -139,30 +159,104 @@ func (h *Handler) PostCommodityMarket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var commodityMarketJson CommodityMarketJson
-	if err := json.NewDecoder(r.Body).Decode(&commodityMarketJson); err != nil {
+	var request CreateCommodityMarketRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
 		log.Println("Error decoding commodity market", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	commodityMarket, err := h.SolarSystemService.CreateCommodityMarket(r.Context(), solarSystemId, commodityMarketJson.BasePrice, commodityMarketJson.DemandQuantity, commodityMarketJson.CommodityID)
+	commodityMarket, err := h.SolarSystemService.CreateCommodityMarket(r.Context(), solarSystemId, request.StationID, request.BasePrice, request.DemandQuantity, request.CommodityID)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrMarketReferenceNotFound) {
+			log.Println("Solar system, station or commodity not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityMarketConflict) {
+			log.Println("Commodity market already exists", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityBanned) {
+			log.Println("Commodity is banned", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding commodity market error", err)
+			}
+			return
+		}
 		log.Println("Error creating commodity market", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(commodityMarket); err != nil {
+	if err := json.NewEncoder(w).Encode(newCommodityMarketResponse(r, commodityMarket)); err != nil {
 		log.Println("Error encoding commodity market", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-type CommodityMarketUpdateJson struct {
-	BasePrice      float64
-	DemandQuantity int
+func (h *Handler) PutCommodityMarkets(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PutCommodityMarkets")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request ReplaceCommodityMarketsRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding commodity markets", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.CommodityMarkets == nil {
+		log.Println("Commodity markets were missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.SolarSystemService.ReplaceCommodityMarkets(r.Context(), solarSystemId, request.toUpserts())
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrDuplicateCommodity) ||
+			errors.Is(err, solarSystem.ErrMarketReferenceNotFound) ||
+			errors.Is(err, solarSystem.ErrCommodityBanned) {
+			log.Println("Invalid commodity markets", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding commodity markets error", err)
+			}
+			return
+		}
+		log.Println("Error replacing commodity markets", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newCommodityMarketResultsResponse(r, results)); err != nil {
+		log.Println("Error encoding commodity markets", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 }
 
 func (h *Handler) PutCommodityMarket(w http.ResponseWriter, r *http.Request) {
@@ -177,26 +271,34 @@ func (h *Handler) PutCommodityMarket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	commodityMarketUpdateJson := CommodityMarketUpdateJson{}
-	if err := json.NewDecoder(r.Body).Decode(&commodityMarketUpdateJson); err != nil {
+	var request UpdateCommodityMarketRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
 		log.Println("Error decoding commodity market update", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	commodityMarketUpdate := solarSystem.CommodityMarketUpdate{
-		BasePrice:      commodityMarketUpdateJson.BasePrice,
-		DemandQuantity: commodityMarketUpdateJson.DemandQuantity,
+		BasePrice:      request.BasePrice,
+		DemandQuantity: request.DemandQuantity,
 	}
 
-	commodityMarket, err := h.SolarSystemService.UpdateCommodityMarket(r.Context(), commodityMarketId, commodityMarketUpdate)
+	commodityMarket, err := h.SolarSystemService.UpdateCommodityMarket(r.Context(), solarSystemId, commodityMarketId, commodityMarketUpdate)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityMarketNotFound) {
+			log.Println("Commodity market not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		log.Println("Error updating commodity market", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(commodityMarket); err != nil {
+	if err := json.NewEncoder(w).Encode(newCommodityMarketResponse(r, commodityMarket)); err != nil {
 		log.Println("Error encoding commodity market", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -215,10 +317,215 @@ func (h *Handler) DeleteCommodityMarket(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.SolarSystemService.RemoveCommodityMarket(r.Context(), commodityMarketId)
+	err := h.SolarSystemService.RemoveCommodityMarket(r.Context(), solarSystemId, commodityMarketId)
 	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityMarketNotFound) {
+			log.Println("Commodity market not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		log.Println("Error deleting commodity market", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
+
+func (h *Handler) RestoreSolarSystem(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RestoreSolarSystem")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restoredSolarSystem, err := h.SolarSystemService.RestoreSolarSystem(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemConflict) {
+			log.Println("Solar system name has been taken since it was removed", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error restoring solar system", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newSolarSystemDetailResponse(r, restoredSolarSystem)); err != nil {
+		log.Println("Error encoding solar system", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) RestoreCommodityMarket(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RestoreCommodityMarket")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	commodityMarketId := vars["commodityMarketId"]
+
+	if solarSystemId == "" || commodityMarketId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restoredCommodityMarket, err := h.SolarSystemService.RestoreCommodityMarket(r.Context(), solarSystemId, commodityMarketId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityMarketNotFound) || errors.Is(err, solarSystem.ErrMarketReferenceNotFound) {
+			log.Println("Commodity market not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityMarketConflict) {
+			log.Println("Commodity market has been replaced", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCommodityBanned) {
+			log.Println("Commodity is banned", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding commodity market error", err)
+			}
+			return
+		}
+		log.Println("Error restoring commodity market", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newCommodityMarketResponse(r, restoredCommodityMarket)); err != nil {
+		log.Println("Error encoding commodity market", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetNearbySolarSystems(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetNearbySolarSystems")
+
+	pagination := data.GetPagination(r)
+
+	var centre solarSystem.Coordinates
+	var radius float64
+	for _, param := range []struct {
+		name  string
+		value *float64
+	}{
+		{"x", &centre.X},
+		{"y", &centre.Y},
+		{"z", &centre.Z},
+		{"radius", &radius},
+	} {
+		value, err := getFloatParam(r, param.name)
+		if err != nil || value == nil {
+			log.Println("Invalid or missing", param.name, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*param.value = *value
+	}
+
+	nearby, err := h.SolarSystemService.FindNearbySolarSystems(r.Context(), centre, radius, pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidSpatialQuery) {
+			log.Println("Invalid spatial query", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding spatial query error", err)
+			}
+			return
+		}
+		log.Println("Error getting nearby solar systems", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(NearbySolarSystemListV1{
+		SolarSystems: mapDtos(nearby, newNearbySolarSystemV1),
+		Pagination:   newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding solar systems", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetSolarSystemNeighbors(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetSolarSystemNeighbors")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	k := solarSystem.DefaultNeighbors
+	value, err := getIntParam(r, "k")
+	if err != nil {
+		log.Println("Invalid k", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if value != nil {
+		k = *value
+	}
+
+	neighbors, err := h.SolarSystemService.FindNeighbors(r.Context(), id, k)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidSpatialQuery) {
+			log.Println("Invalid spatial query", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding spatial query error", err)
+			}
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting solar system neighbors", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(NeighborListV1{
+		SolarSystems: mapDtos(neighbors, newNearbySolarSystemV1),
+	}); err != nil {
+		log.Println("Error encoding solar systems", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
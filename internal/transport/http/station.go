@@ -0,0 +1,436 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetCelestialBodies(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetCelestialBodies")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	bodies, err := h.SolarSystemService.FindCelestialBodies(r.Context(), solarSystemId, getIncludeDeleted(r))
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting celestial bodies", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(CelestialBodyListV1{
+		CelestialBodies: mapDtos(bodies, newCelestialBodyV1),
+	}); err != nil {
+		log.Println("Error encoding celestial bodies", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostCelestialBody(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostCelestialBody")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request CreateCelestialBodyRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding celestial body", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newBody, err := h.SolarSystemService.CreateCelestialBody(r.Context(), solarSystem.CelestialBody{
+		SolarSystemID: solarSystemId,
+		ParentID:      request.ParentID,
+		Name:          request.Name,
+		Kind:          solarSystem.CelestialBodyKind(request.Kind),
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidCelestialBody) {
+			log.Println("Invalid celestial body", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrBodyReferenceNotFound) {
+			log.Println("Solar system or parent body not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCelestialBodyConflict) {
+			log.Println("Celestial body name is taken", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error creating celestial body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newCelestialBodyV1(newBody)); err != nil {
+		log.Println("Error encoding celestial body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) DeleteCelestialBody(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteCelestialBody")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	celestialBodyId := vars["celestialBodyId"]
+
+	if solarSystemId == "" || celestialBodyId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.SolarSystemService.RemoveCelestialBody(r.Context(), solarSystemId, celestialBodyId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCelestialBodyNotFound) {
+			log.Println("Celestial body not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCelestialBodyInUse) {
+			log.Println("Celestial body is in use", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error deleting celestial body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreCelestialBody(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RestoreCelestialBody")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	celestialBodyId := vars["celestialBodyId"]
+
+	if solarSystemId == "" || celestialBodyId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restoredBody, err := h.SolarSystemService.RestoreCelestialBody(r.Context(), solarSystemId, celestialBodyId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCelestialBodyNotFound) || errors.Is(err, solarSystem.ErrBodyReferenceNotFound) {
+			log.Println("Celestial body not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrCelestialBodyConflict) {
+			log.Println("Celestial body name has been taken since it was removed", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error restoring celestial body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newCelestialBodyV1(restoredBody)); err != nil {
+		log.Println("Error encoding celestial body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetStations(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetStations")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	stations, err := h.SolarSystemService.FindStations(r.Context(), solarSystemId, getIncludeDeleted(r))
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrSolarSystemNotFound) {
+			log.Println("Solar system not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting stations", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(StationListV1{
+		Stations: mapDtos(stations, newStationV1),
+	}); err != nil {
+		log.Println("Error encoding stations", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) GetStation(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetStation")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	stationId := vars["stationId"]
+
+	if solarSystemId == "" || stationId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	station, err := h.SolarSystemService.FindStation(r.Context(), solarSystemId, stationId, getIncludeDeleted(r))
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationNotFound) {
+			log.Println("Station not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeCacheControl(w, r)
+	if err := json.NewEncoder(w).Encode(newStationDetailResponse(r, station)); err != nil {
+		log.Println("Error encoding station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostStation(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostStation")
+	vars := mux.Vars(r)
+
+	solarSystemId := vars["solarSystemId"]
+	if solarSystemId == "" {
+		log.Println("Solar system ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request StationRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding station", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	newStation, err := h.SolarSystemService.CreateStation(r.Context(), solarSystem.Station{
+		SolarSystemID:   solarSystemId,
+		CelestialBodyID: request.CelestialBodyID,
+		Name:            request.Name,
+		Kind:            request.kind(),
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidStation) || errors.Is(err, solarSystem.ErrInvalidCelestialBody) {
+			log.Println("Invalid station", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrBodyReferenceNotFound) {
+			log.Println("Solar system or celestial body not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationConflict) {
+			log.Println("Station name is taken", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error creating station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newStationV1(newStation)); err != nil {
+		log.Println("Error encoding station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PutStation(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PutStation")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	stationId := vars["stationId"]
+
+	if solarSystemId == "" || stationId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request StationRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding station", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	updatedStation, err := h.SolarSystemService.UpdateStation(r.Context(), solarSystemId, stationId, solarSystem.StationUpdate{
+		Name:            request.Name,
+		Kind:            request.kind(),
+		CelestialBodyID: request.CelestialBodyID,
+	})
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrInvalidStation) || errors.Is(err, solarSystem.ErrInvalidCelestialBody) {
+			log.Println("Invalid station", err)
+			writeInvalidRequest(w, err)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationNotFound) || errors.Is(err, solarSystem.ErrBodyReferenceNotFound) {
+			log.Println("Station or celestial body not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationConflict) {
+			log.Println("Station name is taken", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error updating station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newStationV1(updatedStation)); err != nil {
+		log.Println("Error encoding station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) DeleteStation(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteStation")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	stationId := vars["stationId"]
+
+	if solarSystemId == "" || stationId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.SolarSystemService.RemoveStation(r.Context(), solarSystemId, stationId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationNotFound) {
+			log.Println("Station not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreStation(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RestoreStation")
+	vars := mux.Vars(r)
+	solarSystemId := vars["solarSystemId"]
+	stationId := vars["stationId"]
+
+	if solarSystemId == "" || stationId == "" {
+		log.Println("ID was missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	restoredStation, err := h.SolarSystemService.RestoreStation(r.Context(), solarSystemId, stationId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationNotFound) || errors.Is(err, solarSystem.ErrBodyReferenceNotFound) {
+			log.Println("Station not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, solarSystem.ErrStationConflict) || errors.Is(err, solarSystem.ErrCommodityMarketConflict) {
+			log.Println("Station or one of its markets has been replaced since it was removed", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error restoring station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newStationDetailResponse(r, restoredStation)); err != nil {
+		log.Println("Error encoding station", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeInvalidRequest - a 400 that tells the caller what was wrong
+// with the body they sent.
+func writeInvalidRequest(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+		log.Println("Error encoding invalid request error", err)
+	}
+}
@@ -0,0 +1,304 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/services/events"
+	"github.com/FairleyC/space-sim-service/internal/services/stream"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// StreamHeartbeatInterval - how often an idle stream is sent a
+	// heartbeat, keeping proxies from closing it and letting both
+	// ends notice a dead connection.
+	StreamHeartbeatInterval = 15 * time.Second
+	LastEventIdHeader       = "Last-Event-ID"
+
+	streamWriteWait = 10 * time.Second
+	streamReadLimit = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// GetStream - streams the events published to the requested topics
+// as Server-Sent Events. Reconnecting clients send Last-Event-ID
+// to receive the events they missed.
+func (h *Handler) GetStream(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetStream")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Println("Streaming is not supported by the response writer")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	topics := getTopics(r)
+	if len(topics) == 0 {
+		log.Println("Topics were missing from request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	lastEventId, err := getLastEventId(r)
+	if err != nil {
+		log.Println("Invalid last event id", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	subscription, ok := h.subscribe(w, r, topics, lastEventId)
+	if !ok {
+		return
+	}
+	defer h.StreamService.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range subscription.Replay {
+		if err := writeServerSentEvent(w, event); err != nil {
+			log.Println("Error writing stream event", err)
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if err := writeServerSentEvent(w, event); err != nil {
+				log.Println("Error writing stream event", err)
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// StreamMessage - every WebSocket frame in either direction. Clients
+// send "subscribe" and "unsubscribe" with topics; the server replies
+// in kind and sends "event" and "error" messages.
+type StreamMessage struct {
+	Type   string        `json:"type"`
+	Topics []string      `json:"topics,omitempty"`
+	Event  *events.Event `json:"event,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+const (
+	StreamMessageSubscribe   = "subscribe"
+	StreamMessageUnsubscribe = "unsubscribe"
+	StreamMessageEvent       = "event"
+	StreamMessageError       = "error"
+)
+
+// GetStreamWebSocket - the WebSocket counterpart of GetStream. Topics in
+// the query string are subscribed on connect, and more can be added or
+// removed with messages; lastEventId resumes as Last-Event-ID does.
+func (h *Handler) GetStreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetStreamWebSocket")
+
+	lastEventId, err := getLastEventId(r)
+	if err != nil {
+		log.Println("Invalid last event id", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	subscription, ok := h.subscribe(w, r, getTopics(r), lastEventId)
+	if !ok {
+		return
+	}
+	defer h.StreamService.Unsubscribe(subscription)
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already written an error response
+		log.Println("Error upgrading to websocket", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	replies := make(chan StreamMessage, 16)
+	go h.readStreamMessages(ctx, cancel, conn, subscription, replies)
+
+	for _, event := range subscription.Replay {
+		if err := writeStreamMessage(conn, StreamMessage{Type: StreamMessageEvent, Event: &event}); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream closed"), time.Now().Add(streamWriteWait))
+				return
+			}
+			if err := writeStreamMessage(conn, StreamMessage{Type: StreamMessageEvent, Event: &event}); err != nil {
+				return
+			}
+		case reply := <-replies:
+			if err := writeStreamMessage(conn, reply); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readStreamMessages - applies subscription changes sent by the client
+// until the connection fails or misses two heartbeats, then cancels ctx.
+func (h *Handler) readStreamMessages(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, subscription *stream.Subscription, replies chan<- StreamMessage) {
+	defer cancel()
+
+	conn.SetReadLimit(streamReadLimit)
+	conn.SetReadDeadline(time.Now().Add(2 * StreamHeartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * StreamHeartbeatInterval))
+	})
+
+	for {
+		var message StreamMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Println("Error reading stream message", err)
+			}
+			return
+		}
+
+		reply := StreamMessage{Type: message.Type}
+		switch message.Type {
+		case StreamMessageSubscribe:
+			topics, err := stream.ParseTopics(ctx, message.Topics)
+			if err != nil {
+				reply = StreamMessage{Type: StreamMessageError, Error: err.Error()}
+				break
+			}
+			subscription.Subscribe(topics)
+			reply.Topics = subscription.Topics()
+		case StreamMessageUnsubscribe:
+			subscription.Unsubscribe(message.Topics)
+			reply.Topics = subscription.Topics()
+		default:
+			reply = StreamMessage{Type: StreamMessageError, Error: fmt.Sprintf("unknown message type %q", message.Type)}
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// subscribe - starts a subscription, writing the error response
+// and returning false if it can't be.
+func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request, topics []string, lastEventId *int64) (*stream.Subscription, bool) {
+	subscription, err := h.StreamService.Subscribe(r.Context(), topics, lastEventId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return nil, false
+		}
+		if errors.Is(err, stream.ErrInvalidTopic) {
+			log.Println("Invalid topic", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return nil, false
+		}
+		log.Println("Error subscribing to stream", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return subscription, true
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func writeStreamMessage(conn *websocket.Conn, message StreamMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+	return conn.WriteJSON(message)
+}
+
+// getTopics - reads the comma separated topics query parameter,
+// which may also be repeated.
+func getTopics(r *http.Request) []string {
+	topics := []string{}
+	for _, value := range r.URL.Query()["topics"] {
+		for _, topic := range strings.Split(value, ",") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				topics = append(topics, topic)
+			}
+		}
+	}
+
+	return topics
+}
+
+// getLastEventId - reads the id to resume after from the Last-Event-ID
+// header sent by reconnecting EventSource clients, or the lastEventId
+// query parameter. Nil means the client is not resuming.
+func getLastEventId(r *http.Request) (*int64, error) {
+	value := r.Header.Get(LastEventIdHeader)
+	if value == "" {
+		value = r.URL.Query().Get("lastEventId")
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	lastEventId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lastEventId, nil
+}
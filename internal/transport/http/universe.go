@@ -0,0 +1,547 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FairleyC/space-sim-service/internal/services/commodity"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+	"github.com/FairleyC/space-sim-service/internal/services/universe"
+)
+
+// An export is written, and an import read, one record at a time in
+// either format, so neither side ever holds the whole universe.
+//
+// JSON is a single document of three arrays in this order:
+//
+//	{"commodities": [...], "solarSystems": [...], "markets": [...]}
+//
+// CSV is one table whose kind column says which fields a row uses,
+// with a commodity's tags joined by semicolons in its tags column.
+
+const (
+	formatJson = "json"
+	formatCsv  = "csv"
+)
+
+var jsonSections = []struct {
+	key  string
+	kind universe.Kind
+}{
+	{key: "commodities", kind: universe.KindCommodity},
+	{key: "solarSystems", kind: universe.KindSolarSystem},
+	{key: "markets", kind: universe.KindMarket},
+}
+
+const csvTagSeparator = ";"
+
+var csvHeader = []string{"kind", "name", "unitMass", "unitVolume", "solarSystem", "commodity", "basePrice", "demandQuantity", "x", "y", "z", "starClass", "region", "sector", "category", "tags"}
+
+func (h *Handler) GetExport(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetExport")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatJson
+	}
+
+	body := &trackingWriter{Writer: w}
+	var writer recordWriter
+	switch format {
+	case formatJson:
+		w.Header().Set("Content-Type", "application/json")
+		writer = newJsonRecordWriter(body)
+	case formatCsv:
+		w.Header().Set("Content-Type", "text/csv")
+		writer = newCsvRecordWriter(body)
+	default:
+		log.Println("Unknown export format", format)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="universe.%s"`, format))
+
+	err := h.UniverseService.Export(r.Context(), writer.Write)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		// once the first record is written the status can't change,
+		// and the client sees a truncated document instead
+		log.Println("Error exporting universe", err)
+		if !body.written {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Println("Error writing export", err)
+	}
+}
+
+func (h *Handler) PostImport(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostImport")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatJson
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "text/csv" {
+			format = formatCsv
+		}
+	}
+
+	var source universe.Source
+	switch format {
+	case formatJson:
+		source = newJsonRecordSource(r.Body)
+	case formatCsv:
+		source = newCsvRecordSource(r.Body)
+	default:
+		log.Println("Unknown import format", format)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	report, err := h.UniverseService.Import(r.Context(), source, dryRun)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		var recordError *universe.RecordError
+		if errors.As(err, &recordError) && isInvalidImport(err) {
+			log.Println("Invalid import", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: recordError.Error()}); err != nil {
+				log.Println("Error encoding import error", err)
+			}
+			return
+		}
+		log.Println("Error importing universe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newImportReportV1(report)); err != nil {
+		log.Println("Error encoding import report", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostGenerate(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostGenerate")
+	var request GenerateRequestV1
+	// an empty body generates a universe from the defaults
+	if err := decodeJsonBody(r, &request); err != nil && !errors.Is(err, io.EOF) {
+		log.Println("Error decoding generate request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	config := request.toGenerateConfig()
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	report, err := h.UniverseService.Generate(r.Context(), config, dryRun)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, universe.ErrInvalidGenerateConfig) {
+			log.Println("Invalid generate config", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()}); err != nil {
+				log.Println("Error encoding generate error", err)
+			}
+			return
+		}
+		var recordError *universe.RecordError
+		if errors.As(err, &recordError) && isInvalidImport(err) {
+			log.Println("Invalid generated universe", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(ErrorResponse{Error: recordError.Error()}); err != nil {
+				log.Println("Error encoding generate error", err)
+			}
+			return
+		}
+		log.Println("Error generating universe", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newGenerateReportV1(config.Seed, report)); err != nil {
+		log.Println("Error encoding generate report", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// isInvalidImport - whether an import failed on its own content,
+// rather than on the server.
+func isInvalidImport(err error) bool {
+	return errors.Is(err, universe.ErrInvalidRecord) ||
+		errors.Is(err, commodity.ErrCommodityNotFound) ||
+		errors.Is(err, solarSystem.ErrSolarSystemNotFound) ||
+		errors.Is(err, solarSystem.ErrCommodityBanned)
+}
+
+type recordWriter interface {
+	Write(universe.Record) error
+	Close() error
+}
+
+// trackingWriter - notes whether any of the body has reached the
+// client, after which the status of the response is fixed.
+type trackingWriter struct {
+	io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.Writer.Write(p)
+}
+
+type jsonRecordWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	// section - the index in jsonSections of the open array, -1
+	// before the document has been started
+	section int
+	empty   bool
+}
+
+func newJsonRecordWriter(w io.Writer) *jsonRecordWriter {
+	return &jsonRecordWriter{w: w, encoder: json.NewEncoder(w), section: -1}
+}
+
+func (j *jsonRecordWriter) Write(record universe.Record) error {
+	section := -1
+	for i := range jsonSections {
+		if jsonSections[i].kind == record.Kind {
+			section = i
+		}
+	}
+	if section < j.section || section == -1 {
+		return fmt.Errorf("record of kind %q is out of order", record.Kind)
+	}
+
+	for j.section < section {
+		if err := j.openNext(); err != nil {
+			return err
+		}
+	}
+
+	if !j.empty {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.empty = false
+
+	switch record.Kind {
+	case universe.KindCommodity:
+		return j.encoder.Encode(newCommodityRecordV1(record))
+	case universe.KindSolarSystem:
+		return j.encoder.Encode(newSolarSystemRecordV1(record))
+	default:
+		return j.encoder.Encode(newMarketRecordV1(record))
+	}
+}
+
+// openNext - closes the open array, if any, and opens the next.
+func (j *jsonRecordWriter) openNext() error {
+	opening := "{"
+	if j.section >= 0 {
+		opening = "],"
+	}
+
+	j.section++
+	j.empty = true
+	_, err := fmt.Fprintf(j.w, "%s%q:[", opening, jsonSections[j.section].key)
+	return err
+}
+
+// Close - opens any sections no record was written to, so the
+// document always has all three arrays, and ends it.
+func (j *jsonRecordWriter) Close() error {
+	for j.section < len(jsonSections)-1 {
+		if err := j.openNext(); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
+
+type csvRecordWriter struct {
+	writer  *csv.Writer
+	started bool
+}
+
+func newCsvRecordWriter(w io.Writer) *csvRecordWriter {
+	return &csvRecordWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvRecordWriter) Write(record universe.Record) error {
+	if !c.started {
+		c.started = true
+		if err := c.writer.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, len(csvHeader))
+	row[0] = string(record.Kind)
+	switch record.Kind {
+	case universe.KindCommodity:
+		row[1] = record.Name
+		row[2] = strconv.FormatFloat(record.UnitMass, 'g', -1, 64)
+		row[3] = strconv.FormatFloat(record.UnitVolume, 'g', -1, 64)
+		row[14] = string(record.Category)
+		row[15] = strings.Join(record.Tags, csvTagSeparator)
+	case universe.KindSolarSystem:
+		row[1] = record.Name
+		row[8] = strconv.FormatFloat(record.Coordinates.X, 'g', -1, 64)
+		row[9] = strconv.FormatFloat(record.Coordinates.Y, 'g', -1, 64)
+		row[10] = strconv.FormatFloat(record.Coordinates.Z, 'g', -1, 64)
+		row[11] = string(record.StarClass)
+		row[12] = record.Region
+		row[13] = record.Sector
+	case universe.KindMarket:
+		row[4] = record.SolarSystem
+		row[5] = record.Commodity
+		row[6] = strconv.FormatFloat(record.BasePrice, 'g', -1, 64)
+		row[7] = strconv.Itoa(record.DemandQuantity)
+	}
+
+	return c.writer.Write(row)
+}
+
+func (c *csvRecordWriter) Close() error {
+	if !c.started {
+		c.started = true
+		if err := c.writer.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// jsonRecordSource - reads the document token by token, decoding
+// one array element per call to Next.
+type jsonRecordSource struct {
+	decoder *json.Decoder
+	started bool
+	done    bool
+	// kind - the kind of the open array, empty between arrays
+	kind universe.Kind
+}
+
+func newJsonRecordSource(r io.Reader) *jsonRecordSource {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	return &jsonRecordSource{decoder: decoder}
+}
+
+func (j *jsonRecordSource) Next() (universe.Record, error) {
+	if j.done {
+		return universe.Record{}, io.EOF
+	}
+
+	if !j.started {
+		j.started = true
+		if err := j.expectDelim('{'); err != nil {
+			return universe.Record{}, err
+		}
+	}
+
+	for j.kind == "" {
+		token, err := j.decoder.Token()
+		if err != nil {
+			return universe.Record{}, invalidJson(err)
+		}
+
+		if token == json.Delim('}') {
+			j.done = true
+			return universe.Record{}, io.EOF
+		}
+
+		key, _ := token.(string)
+		for _, section := range jsonSections {
+			if section.key == key {
+				j.kind = section.kind
+			}
+		}
+		if j.kind == "" {
+			return universe.Record{}, fmt.Errorf("%w: unknown section %q", universe.ErrInvalidRecord, key)
+		}
+
+		if err := j.expectDelim('['); err != nil {
+			return universe.Record{}, err
+		}
+	}
+
+	if !j.decoder.More() {
+		// the closing bracket of the array
+		if _, err := j.decoder.Token(); err != nil {
+			return universe.Record{}, invalidJson(err)
+		}
+		j.kind = ""
+		return j.Next()
+	}
+
+	switch j.kind {
+	case universe.KindCommodity:
+		var dto CommodityRecordV1
+		if err := j.decoder.Decode(&dto); err != nil {
+			return universe.Record{}, invalidJson(err)
+		}
+		return dto.toRecord(), nil
+	case universe.KindSolarSystem:
+		var dto SolarSystemRecordV1
+		if err := j.decoder.Decode(&dto); err != nil {
+			return universe.Record{}, invalidJson(err)
+		}
+		return dto.toRecord(), nil
+	default:
+		var dto MarketRecordV1
+		if err := j.decoder.Decode(&dto); err != nil {
+			return universe.Record{}, invalidJson(err)
+		}
+		return dto.toRecord(), nil
+	}
+}
+
+func (j *jsonRecordSource) expectDelim(delim json.Delim) error {
+	token, err := j.decoder.Token()
+	if err != nil {
+		return invalidJson(err)
+	}
+
+	if token != delim {
+		return fmt.Errorf("%w: expected %q, found %v", universe.ErrInvalidRecord, delim, token)
+	}
+
+	return nil
+}
+
+func invalidJson(err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("%w: %v", universe.ErrInvalidRecord, err)
+}
+
+// csvRecordSource - reads a table whose first row names its columns,
+// which may come in any order and may leave out unused ones.
+type csvRecordSource struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCsvRecordSource(r io.Reader) *csvRecordSource {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &csvRecordSource{reader: reader}
+}
+
+func (c *csvRecordSource) Next() (universe.Record, error) {
+	if c.columns == nil {
+		header, err := c.reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return universe.Record{}, fmt.Errorf("%w: missing header row", universe.ErrInvalidRecord)
+			}
+			return universe.Record{}, fmt.Errorf("%w: %v", universe.ErrInvalidRecord, err)
+		}
+
+		c.columns = make(map[string]int, len(header))
+		for i, column := range header {
+			if !isCsvColumn(column) {
+				return universe.Record{}, fmt.Errorf("%w: unknown column %q", universe.ErrInvalidRecord, column)
+			}
+			c.columns[column] = i
+		}
+		if _, ok := c.columns["kind"]; !ok {
+			return universe.Record{}, fmt.Errorf("%w: missing kind column", universe.ErrInvalidRecord)
+		}
+	}
+
+	row, err := c.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return universe.Record{}, io.EOF
+		}
+		return universe.Record{}, fmt.Errorf("%w: %v", universe.ErrInvalidRecord, err)
+	}
+
+	field := func(column string) string {
+		if i, ok := c.columns[column]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	record := universe.Record{
+		Kind:        universe.Kind(field("kind")),
+		Name:        field("name"),
+		StarClass:   solarSystem.StarClass(field("starClass")),
+		Region:      field("region"),
+		Sector:      field("sector"),
+		SolarSystem: field("solarSystem"),
+		Commodity:   field("commodity"),
+		Category:    commodity.Category(field("category")),
+	}
+	if tags := field("tags"); tags != "" {
+		record.Tags = strings.Split(tags, csvTagSeparator)
+	}
+
+	numbers := []struct {
+		column string
+		parse  func(string) error
+	}{
+		{"unitMass", func(value string) (err error) { record.UnitMass, err = strconv.ParseFloat(value, 64); return }},
+		{"unitVolume", func(value string) (err error) { record.UnitVolume, err = strconv.ParseFloat(value, 64); return }},
+		{"basePrice", func(value string) (err error) { record.BasePrice, err = strconv.ParseFloat(value, 64); return }},
+		{"demandQuantity", func(value string) (err error) { record.DemandQuantity, err = strconv.Atoi(value); return }},
+		{"x", func(value string) (err error) { record.Coordinates.X, err = strconv.ParseFloat(value, 64); return }},
+		{"y", func(value string) (err error) { record.Coordinates.Y, err = strconv.ParseFloat(value, 64); return }},
+		{"z", func(value string) (err error) { record.Coordinates.Z, err = strconv.ParseFloat(value, 64); return }},
+	}
+	for _, number := range numbers {
+		if value := field(number.column); value != "" {
+			if err := number.parse(value); err != nil {
+				return universe.Record{}, fmt.Errorf("%w: %s %q is not a number", universe.ErrInvalidRecord, number.column, value)
+			}
+		}
+	}
+
+	return record, nil
+}
+
+func isCsvColumn(column string) bool {
+	for _, known := range csvHeader {
+		if known == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/solarSystem"
+)
+
+// The REST API is versioned by path, with /api/v1 and /api/v2 served
+// side by side. A v1 client can move to v2 one request at a time by
+// sending MediaTypeV2 in its Accept header, and every response still
+// served as v1 announces v1's deprecation and sunset dates.
+
+const (
+	MediaTypeV1 = "application/vnd.spacesim.v1+json"
+	MediaTypeV2 = "application/vnd.spacesim.v2+json"
+)
+
+type apiVersion int
+
+const (
+	apiVersion1 apiVersion = 1
+	apiVersion2 apiVersion = 2
+)
+
+var (
+	v1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	v1SunsetAt     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+type apiVersionKey struct{}
+
+func withApiVersion(ctx context.Context, version apiVersion) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// apiVersionFrom - the version a request is served as, v1 for
+// anything VersionMiddleware has not seen.
+func apiVersionFrom(ctx context.Context) apiVersion {
+	version, ok := ctx.Value(apiVersionKey{}).(apiVersion)
+	if !ok {
+		return apiVersion1
+	}
+	return version
+}
+
+// VersionMiddleware - resolves the API version of every request
+// under /api/v1 or /api/v2. The path decides, except that a v1 path
+// is served as v2 when the client accepts MediaTypeV2. Routes
+// outside the versioned API, such as /graphql, pass through as is.
+func (h *Handler) VersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var version apiVersion
+		switch {
+		case strings.HasPrefix(r.URL.Path, API+V2+"/"):
+			version = apiVersion2
+		case strings.HasPrefix(r.URL.Path, API+V1+"/"):
+			// the same v1 url can answer in either version
+			w.Header().Add("Vary", "Accept")
+			version = apiVersion1
+			if acceptsMediaType(r, MediaTypeV2) {
+				version = apiVersion2
+				w.Header().Set("Content-Type", MediaTypeV2)
+			}
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if version == apiVersion1 {
+			writeDeprecationHeaders(w, r)
+		}
+
+		next.ServeHTTP(w, r.WithContext(withApiVersion(r.Context(), version)))
+	})
+}
+
+// writeDeprecationHeaders - the Deprecation (RFC 9745) and Sunset
+// (RFC 8594) headers of a v1 response, linking to the v2 route
+// that replaces it.
+func writeDeprecationHeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Deprecation", fmt.Sprintf("@%d", v1DeprecatedAt.Unix()))
+	w.Header().Set("Sunset", v1SunsetAt.Format(http.TimeFormat))
+
+	successor := API + V2 + strings.TrimPrefix(r.URL.Path, API+V1)
+	w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+}
+
+func acceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		acceptedType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && acceptedType == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// newCommodityMarketResponse - the market in the representation of
+// the request's API version.
+func newCommodityMarketResponse(r *http.Request, market solarSystem.CommodityMarket) any {
+	if apiVersionFrom(r.Context()) == apiVersion2 {
+		return newCommodityMarketV2(market)
+	}
+	return newCommodityMarketV1(market)
+}
+
+// newSolarSystemDetailResponse - the solar system and its markets in
+// the representation of the request's API version.
+func newSolarSystemDetailResponse(r *http.Request, system solarSystem.SolarSystemWithCommodityMarkets) any {
+	if apiVersionFrom(r.Context()) == apiVersion2 {
+		return newSolarSystemDetailV2(system)
+	}
+	return newSolarSystemDetailV1(system)
+}
+
+// newStationDetailResponse - the station and its markets in the
+// representation of the request's API version.
+func newStationDetailResponse(r *http.Request, station solarSystem.StationWithCommodityMarkets) any {
+	if apiVersionFrom(r.Context()) == apiVersion2 {
+		return newStationDetailV2(station)
+	}
+	return newStationDetailV1(station)
+}
+
+// newCommodityMarketResultsResponse - the results of replacing a solar
+// system's markets in the representation of the request's API version.
+func newCommodityMarketResultsResponse(r *http.Request, results []solarSystem.CommodityMarketResult) any {
+	if apiVersionFrom(r.Context()) == apiVersion2 {
+		return CommodityMarketResultListV2{Results: mapDtos(results, newCommodityMarketResultV2)}
+	}
+	return CommodityMarketResultListV1{Results: mapDtos(results, newCommodityMarketResultV1)}
+}
+
+// newCommodityMarketListResponse - a page of markets in the
+// representation of the request's API version.
+func newCommodityMarketListResponse(r *http.Request, markets []solarSystem.CommodityMarket, pagination data.Pagination) any {
+	if apiVersionFrom(r.Context()) == apiVersion2 {
+		return CommodityMarketListV2{CommodityMarkets: mapDtos(markets, newCommodityMarketV2), Pagination: newPaginationV1(pagination)}
+	}
+	return CommodityMarketListV1{CommodityMarkets: mapDtos(markets, newCommodityMarketV1), Pagination: newPaginationV1(pagination)}
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/FairleyC/space-sim-service/internal/data"
+	"github.com/FairleyC/space-sim-service/internal/services/webhook"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetWebhooks")
+
+	pagination := data.GetPagination(r)
+
+	webhooks, err := h.WebhookService.FindAllWebhooks(r.Context(), pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		log.Println("Error getting webhooks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(WebhookListV1{
+		Webhooks:   mapDtos(webhooks, newWebhookV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding webhooks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) PostWebhook(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: PostWebhook")
+	var request CreateWebhookRequestV1
+	if err := decodeJsonBody(r, &request); err != nil {
+		log.Println("Error decoding webhook", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	createdWebhook, err := h.WebhookService.CreateWebhook(r.Context(), request.URL, request.EventTypes)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrInvalidWebhook) {
+			log.Println("Invalid webhook", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		log.Println("Error creating webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(CreatedWebhookV1{
+		WebhookV1: newWebhookV1(createdWebhook.Webhook),
+		Secret:    createdWebhook.Secret,
+	}); err != nil {
+		log.Println("Error encoding webhook", err)
+		return
+	}
+}
+
+func (h *Handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: DeleteWebhook")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := h.WebhookService.RemoveWebhook(r.Context(), id)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			log.Println("Webhook not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error deleting webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries - lists a webhook's deliveries, newest first.
+// Passing ?status=dead gives the dead-letter view.
+func (h *Handler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: GetWebhookDeliveries")
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pagination := data.GetPagination(r)
+
+	var filter webhook.DeliveryFilter
+	if value := r.URL.Query().Get("status"); value != "" {
+		status, err := webhook.ParseDeliveryStatus(value)
+		if err != nil {
+			log.Println("Invalid delivery status", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filter.Status = status
+	}
+
+	deliveries, err := h.WebhookService.FindDeliveries(r.Context(), id, filter, pagination)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			log.Println("Webhook not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Println("Error getting webhook deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(DeliveryListV1{
+		Deliveries: mapDtos(deliveries, newDeliveryV1),
+		Pagination: newPaginationV1(pagination),
+	}); err != nil {
+		log.Println("Error encoding webhook deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	log.Println("REQUEST: RetryWebhookDelivery")
+	vars := mux.Vars(r)
+	id := vars["id"]
+	deliveryId := vars["deliveryId"]
+
+	if id == "" || deliveryId == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.WebhookService.RetryDelivery(r.Context(), id, deliveryId)
+	if err != nil {
+		if writeAuthError(w, err) {
+			return
+		}
+		if errors.Is(err, webhook.ErrDeliveryNotFound) {
+			log.Println("Webhook delivery not found", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, webhook.ErrDeliveryNotDead) {
+			log.Println("Webhook delivery can't be retried", err)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		log.Println("Error retrying webhook delivery", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newDeliveryV1(delivery)); err != nil {
+		log.Println("Error encoding webhook delivery", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    ID uuid,
+    Name VARCHAR(255),
+    Prefix VARCHAR(16),
+    Key_Hash CHAR(64) NOT NULL,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE (Key_Hash)
+);
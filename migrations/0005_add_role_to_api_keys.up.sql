@@ -0,0 +1 @@
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS Role VARCHAR(32) NOT NULL DEFAULT 'read-only';
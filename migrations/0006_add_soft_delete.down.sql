@@ -0,0 +1,13 @@
+DELETE FROM solar_system_commodity_markets
+WHERE Deleted_At IS NOT NULL
+OR Commodity_ID IN (SELECT ID FROM commodities WHERE Deleted_At IS NOT NULL)
+OR Solar_System_ID IN (SELECT ID FROM solar_systems WHERE Deleted_At IS NOT NULL);
+DELETE FROM commodities WHERE Deleted_At IS NOT NULL;
+DELETE FROM solar_systems WHERE Deleted_At IS NOT NULL;
+
+DROP INDEX IF EXISTS solar_system_commodity_markets_active_key;
+ALTER TABLE solar_system_commodity_markets ADD CONSTRAINT solar_system_commodity_markets_commodity_id_solar_system_id_key UNIQUE (Commodity_ID, Solar_System_ID);
+
+ALTER TABLE solar_system_commodity_markets DROP COLUMN IF EXISTS Deleted_At;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Deleted_At;
+ALTER TABLE commodities DROP COLUMN IF EXISTS Deleted_At;
@@ -0,0 +1,7 @@
+ALTER TABLE commodities ADD COLUMN IF NOT EXISTS Deleted_At TIMESTAMP WITH TIME ZONE;
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Deleted_At TIMESTAMP WITH TIME ZONE;
+ALTER TABLE solar_system_commodity_markets ADD COLUMN IF NOT EXISTS Deleted_At TIMESTAMP WITH TIME ZONE;
+
+-- a deleted market must not stop the commodity being listed again in the same solar system
+ALTER TABLE solar_system_commodity_markets DROP CONSTRAINT IF EXISTS solar_system_commodity_markets_commodity_id_solar_system_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS solar_system_commodity_markets_active_key ON solar_system_commodity_markets (Commodity_ID, Solar_System_ID) WHERE Deleted_At IS NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    ID uuid,
+    Actor_ID VARCHAR(255),
+    Actor_Name VARCHAR(255),
+    Action VARCHAR(32),
+    Entity_Type VARCHAR(64),
+    Entity_ID VARCHAR(255),
+    Before JSONB,
+    After JSONB,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (Entity_Type, Entity_ID, Created_At);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (Actor_ID, Created_At);
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    ID BIGSERIAL,
+    Type VARCHAR(64) NOT NULL,
+    Entity_ID VARCHAR(255),
+    Data JSONB,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Dispatched_At TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (ID)
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_undispatched_idx ON outbox_events (ID) WHERE Dispatched_At IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    ID uuid,
+    URL TEXT NOT NULL,
+    Secret CHAR(64) NOT NULL,
+    Event_Types TEXT[] NOT NULL DEFAULT '{}',
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    ID uuid,
+    Webhook_ID uuid NOT NULL,
+    Event_ID BIGINT NOT NULL,
+    Status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    Attempts INTEGER NOT NULL DEFAULT 0,
+    Last_Error TEXT,
+    Last_Response_Status INTEGER,
+    Next_Attempt_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Delivered_At TIMESTAMP WITH TIME ZONE,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE (Webhook_ID, Event_ID)
+);
+
+ALTER TABLE webhook_deliveries ADD CONSTRAINT fk_webhook_id FOREIGN KEY (Webhook_ID) REFERENCES webhooks(ID) ON DELETE CASCADE;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT fk_event_id FOREIGN KEY (Event_ID) REFERENCES outbox_events(ID);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (Next_Attempt_At) WHERE Status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON webhook_deliveries (Webhook_ID, Status, Created_At);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS solar_systems_active_name_key;
+DROP INDEX IF EXISTS commodities_active_name_key;
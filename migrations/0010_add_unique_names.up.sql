@@ -0,0 +1,3 @@
+-- names are the natural keys imports upsert by, so two live rows may not share one
+CREATE UNIQUE INDEX IF NOT EXISTS commodities_active_name_key ON commodities (Name) WHERE Deleted_At IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS solar_systems_active_name_key ON solar_systems (Name) WHERE Deleted_At IS NULL;
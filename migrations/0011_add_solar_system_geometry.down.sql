@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS solar_systems_active_position_idx;
+
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Sector;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Region;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Star_Class;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Z;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS Y;
+ALTER TABLE solar_systems DROP COLUMN IF EXISTS X;
+
+DROP EXTENSION IF EXISTS cube;
@@ -0,0 +1,13 @@
+-- cube provides the spatial index, leaving PostGIS out for a handful of points
+CREATE EXTENSION IF NOT EXISTS cube;
+
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS X DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Y DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Z DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Star_Class TEXT NOT NULL DEFAULT '';
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Region TEXT NOT NULL DEFAULT '';
+ALTER TABLE solar_systems ADD COLUMN IF NOT EXISTS Sector TEXT NOT NULL DEFAULT '';
+
+-- queries must use the same cube(ARRAY[x, y, z]) expression, for radius
+-- searches with <@ and nearest neighbours ordered by <->
+CREATE INDEX IF NOT EXISTS solar_systems_active_position_idx ON solar_systems USING gist (cube(ARRAY[X, Y, Z])) WHERE Deleted_At IS NULL;
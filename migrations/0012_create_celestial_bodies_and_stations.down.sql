@@ -0,0 +1,11 @@
+DELETE FROM solar_system_commodity_markets WHERE Station_ID IS NOT NULL;
+
+DROP INDEX IF EXISTS solar_system_commodity_markets_active_station_key;
+DROP INDEX IF EXISTS solar_system_commodity_markets_active_key;
+CREATE UNIQUE INDEX IF NOT EXISTS solar_system_commodity_markets_active_key ON solar_system_commodity_markets (Commodity_ID, Solar_System_ID) WHERE Deleted_At IS NULL;
+
+ALTER TABLE solar_system_commodity_markets DROP CONSTRAINT IF EXISTS fk_station_id;
+ALTER TABLE solar_system_commodity_markets DROP COLUMN IF EXISTS Station_ID;
+
+DROP TABLE IF EXISTS stations;
+DROP TABLE IF EXISTS celestial_bodies;
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS celestial_bodies (
+    ID uuid,
+    Solar_System_ID uuid NOT NULL,
+    -- the body orbited, e.g. a moon's planet; NULL for bodies orbiting the star
+    Parent_ID uuid,
+    Name VARCHAR(255) NOT NULL,
+    Kind VARCHAR(32) NOT NULL,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Deleted_At TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (ID)
+);
+
+ALTER TABLE celestial_bodies ADD CONSTRAINT fk_celestial_body_solar_system_id FOREIGN KEY (Solar_System_ID) REFERENCES solar_systems(ID);
+ALTER TABLE celestial_bodies ADD CONSTRAINT fk_celestial_body_parent_id FOREIGN KEY (Parent_ID) REFERENCES celestial_bodies(ID) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS celestial_bodies_active_name_key ON celestial_bodies (Solar_System_ID, Name) WHERE Deleted_At IS NULL;
+
+CREATE TABLE IF NOT EXISTS stations (
+    ID uuid,
+    Solar_System_ID uuid NOT NULL,
+    -- the body the station orbits or an outpost sits on; NULL for deep space
+    Celestial_Body_ID uuid,
+    Name VARCHAR(255) NOT NULL,
+    Kind VARCHAR(32) NOT NULL,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Deleted_At TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (ID)
+);
+
+ALTER TABLE stations ADD CONSTRAINT fk_station_solar_system_id FOREIGN KEY (Solar_System_ID) REFERENCES solar_systems(ID);
+ALTER TABLE stations ADD CONSTRAINT fk_station_celestial_body_id FOREIGN KEY (Celestial_Body_ID) REFERENCES celestial_bodies(ID) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS stations_active_name_key ON stations (Solar_System_ID, Name) WHERE Deleted_At IS NULL;
+
+-- markets without a station are traded at the solar system level,
+-- as every market was before stations
+ALTER TABLE solar_system_commodity_markets ADD COLUMN IF NOT EXISTS Station_ID uuid;
+ALTER TABLE solar_system_commodity_markets ADD CONSTRAINT fk_station_id FOREIGN KEY (Station_ID) REFERENCES stations(ID);
+
+-- a commodity is traded at most once at the system level and once per station
+DROP INDEX IF EXISTS solar_system_commodity_markets_active_key;
+CREATE UNIQUE INDEX IF NOT EXISTS solar_system_commodity_markets_active_key ON solar_system_commodity_markets (Commodity_ID, Solar_System_ID) WHERE Deleted_At IS NULL AND Station_ID IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS solar_system_commodity_markets_active_station_key ON solar_system_commodity_markets (Commodity_ID, Station_ID) WHERE Deleted_At IS NULL AND Station_ID IS NOT NULL;
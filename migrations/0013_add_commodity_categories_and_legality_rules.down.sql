@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS legality_rules;
+
+DROP INDEX IF EXISTS commodities_tags_idx;
+DROP INDEX IF EXISTS commodities_category_idx;
+
+ALTER TABLE commodities DROP COLUMN IF EXISTS Tags;
+ALTER TABLE commodities DROP COLUMN IF EXISTS Category;
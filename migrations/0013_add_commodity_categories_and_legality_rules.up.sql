@@ -0,0 +1,28 @@
+ALTER TABLE commodities ADD COLUMN IF NOT EXISTS Category VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE commodities ADD COLUMN IF NOT EXISTS Tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS commodities_category_idx ON commodities (Category);
+-- serves the tags @> ARRAY[tag] filter
+CREATE INDEX IF NOT EXISTS commodities_tags_idx ON commodities USING gin (Tags);
+
+-- a rule covers a single commodity or every commodity of a category
+-- in the solar system, and a commodity's own rule wins over its
+-- category's. Rules are configuration, so they are hard deleted
+CREATE TABLE IF NOT EXISTS legality_rules (
+    ID uuid,
+    Solar_System_ID uuid NOT NULL,
+    Commodity_ID uuid,
+    Category VARCHAR(32),
+    Status VARCHAR(32) NOT NULL,
+    Tax_Rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    CONSTRAINT legality_rules_target_check CHECK ((Commodity_ID IS NULL) <> (Category IS NULL))
+);
+
+ALTER TABLE legality_rules ADD CONSTRAINT fk_legality_rule_solar_system_id FOREIGN KEY (Solar_System_ID) REFERENCES solar_systems(ID) ON DELETE CASCADE;
+ALTER TABLE legality_rules ADD CONSTRAINT fk_legality_rule_commodity_id FOREIGN KEY (Commodity_ID) REFERENCES commodities(ID) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS legality_rules_commodity_key ON legality_rules (Solar_System_ID, Commodity_ID) WHERE Commodity_ID IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS legality_rules_category_key ON legality_rules (Solar_System_ID, Category) WHERE Category IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS facilities;
+DROP TABLE IF EXISTS recipe_components;
+DROP TABLE IF EXISTS recipes;
+
+ALTER TABLE solar_system_commodity_markets DROP CONSTRAINT IF EXISTS solar_system_commodity_markets_stock_check;
+ALTER TABLE solar_system_commodity_markets DROP COLUMN IF EXISTS Stock;
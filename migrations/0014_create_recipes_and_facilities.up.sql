@@ -0,0 +1,57 @@
+-- the units of a commodity a market holds, consumed and produced by
+-- the facilities of its solar system or station
+ALTER TABLE solar_system_commodity_markets ADD COLUMN IF NOT EXISTS Stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE solar_system_commodity_markets ADD CONSTRAINT solar_system_commodity_markets_stock_check CHECK (Stock >= 0);
+
+-- recipes are configuration, so like legality rules they are hard
+-- deleted, and only once no facility runs them
+CREATE TABLE IF NOT EXISTS recipes (
+    ID uuid,
+    Name VARCHAR(255) NOT NULL,
+    Cycle_Seconds INTEGER NOT NULL CHECK (Cycle_Seconds > 0),
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE (Name)
+);
+
+-- the quantity of a commodity one cycle of a recipe consumes or
+-- produces; a commodity is either an input or an output of a recipe
+CREATE TABLE IF NOT EXISTS recipe_components (
+    Recipe_ID uuid NOT NULL,
+    Commodity_ID uuid NOT NULL,
+    Direction VARCHAR(16) NOT NULL CHECK (Direction IN ('input', 'output')),
+    Quantity INTEGER NOT NULL CHECK (Quantity > 0),
+    PRIMARY KEY (Recipe_ID, Commodity_ID)
+);
+
+ALTER TABLE recipe_components ADD CONSTRAINT fk_recipe_component_recipe_id FOREIGN KEY (Recipe_ID) REFERENCES recipes(ID) ON DELETE CASCADE;
+ALTER TABLE recipe_components ADD CONSTRAINT fk_recipe_component_commodity_id FOREIGN KEY (Commodity_ID) REFERENCES commodities(ID) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS recipe_components_commodity_idx ON recipe_components (Commodity_ID, Direction);
+
+-- a facility runs a recipe against the markets of its station, or of
+-- its solar system when it has none, once every cycle of the recipe
+CREATE TABLE IF NOT EXISTS facilities (
+    ID uuid,
+    Solar_System_ID uuid NOT NULL,
+    Station_ID uuid,
+    Recipe_ID uuid NOT NULL,
+    Name VARCHAR(255) NOT NULL,
+    Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    Status VARCHAR(32) NOT NULL DEFAULT 'idle',
+    Stalled_Reason TEXT NOT NULL DEFAULT '',
+    Last_Cycle_At TIMESTAMP WITH TIME ZONE,
+    Next_Cycle_At TIMESTAMP WITH TIME ZONE NOT NULL,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE (Solar_System_ID, Name)
+);
+
+ALTER TABLE facilities ADD CONSTRAINT fk_facility_solar_system_id FOREIGN KEY (Solar_System_ID) REFERENCES solar_systems(ID) ON DELETE CASCADE;
+ALTER TABLE facilities ADD CONSTRAINT fk_facility_station_id FOREIGN KEY (Station_ID) REFERENCES stations(ID) ON DELETE CASCADE;
+ALTER TABLE facilities ADD CONSTRAINT fk_facility_recipe_id FOREIGN KEY (Recipe_ID) REFERENCES recipes(ID);
+
+-- serves the production job's search for due facilities
+CREATE INDEX IF NOT EXISTS facilities_due_idx ON facilities (Next_Cycle_At) WHERE Enabled;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ship_cargo;
+DROP TABLE IF EXISTS ships;
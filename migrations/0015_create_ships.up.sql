@@ -0,0 +1,35 @@
+-- a ship is docked in its solar system, or between leaving it and
+-- arriving at its destination in transit, when its destination and
+-- arrival time are set
+CREATE TABLE IF NOT EXISTS ships (
+    ID uuid,
+    Name VARCHAR(255) NOT NULL,
+    Solar_System_ID uuid NOT NULL,
+    Speed DOUBLE PRECISION NOT NULL CHECK (Speed > 0),
+    Destination_ID uuid,
+    Departed_At TIMESTAMP WITH TIME ZONE,
+    Arrives_At TIMESTAMP WITH TIME ZONE,
+    Created_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    Updated_At TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ID),
+    UNIQUE (Name),
+    CONSTRAINT ships_transit_check CHECK ((Destination_ID IS NULL) = (Arrives_At IS NULL) AND (Destination_ID IS NULL) = (Departed_At IS NULL))
+);
+
+ALTER TABLE ships ADD CONSTRAINT fk_ship_solar_system_id FOREIGN KEY (Solar_System_ID) REFERENCES solar_systems(ID) ON DELETE RESTRICT;
+ALTER TABLE ships ADD CONSTRAINT fk_ship_destination_id FOREIGN KEY (Destination_ID) REFERENCES solar_systems(ID) ON DELETE RESTRICT;
+
+-- serves the arrival job's search for ships that have arrived
+CREATE INDEX IF NOT EXISTS ships_arrival_idx ON ships (Arrives_At) WHERE Arrives_At IS NOT NULL;
+
+-- the units of each commodity a ship carries, bought from and sold to
+-- the markets of the solar system it is docked in
+CREATE TABLE IF NOT EXISTS ship_cargo (
+    Ship_ID uuid NOT NULL,
+    Commodity_ID uuid NOT NULL,
+    Quantity INTEGER NOT NULL CHECK (Quantity > 0),
+    PRIMARY KEY (Ship_ID, Commodity_ID)
+);
+
+ALTER TABLE ship_cargo ADD CONSTRAINT fk_ship_cargo_ship_id FOREIGN KEY (Ship_ID) REFERENCES ships(ID) ON DELETE CASCADE;
+ALTER TABLE ship_cargo ADD CONSTRAINT fk_ship_cargo_commodity_id FOREIGN KEY (Commodity_ID) REFERENCES commodities(ID) ON DELETE RESTRICT;